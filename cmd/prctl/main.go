@@ -0,0 +1,226 @@
+// Command prctl is a maintenance tool working directly with the service database.
+//
+// Usage:
+//
+//	prctl import [-dry-run] [-reassign-reviews] [-format yaml|csv] FILE
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/postgres"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/roster"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/config"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const usage = `Usage:
+  prctl import [-dry-run] [-reassign-reviews] [-format yaml|csv] FILE
+      Sync teams with the rosters in FILE, see README for the file layout`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Println(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Println(usage)
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// importOptions are the arguments of the import command
+type importOptions struct {
+	path            string
+	format          roster.Format
+	dryRun          bool
+	reassignReviews bool
+}
+
+// parseImportArgs parses the arguments of the import command, detecting the format by the file extension
+// unless it is given
+func parseImportArgs(args []string) (importOptions, error) {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard) // errors are reported by the command itself
+	dryRun := flags.Bool("dry-run", false, "print the planned changes without applying them")
+	reassignReviews := flags.Bool("reassign-reviews", false, "hand over open reviews of removed and deactivated members")
+	format := flags.String("format", "", "file format, yaml or csv (default: by the file extension)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return importOptions{}, err
+		}
+		return importOptions{}, fmt.Errorf("%w\n%s", err, usage)
+	}
+
+	if flags.NArg() != 1 {
+		return importOptions{}, fmt.Errorf("expected a single FILE argument\n%s", usage)
+	}
+
+	opts := importOptions{path: flags.Arg(0), format: roster.Format(*format), dryRun: *dryRun, reassignReviews: *reassignReviews}
+	switch opts.format {
+	case roster.FormatYAML, roster.FormatCSV:
+	case "":
+		var err error
+		if opts.format, err = roster.FormatFromPath(opts.path); err != nil {
+			return importOptions{}, err
+		}
+	default:
+		return importOptions{}, fmt.Errorf("unknown format %q, expected yaml or csv", *format)
+	}
+
+	return opts, nil
+}
+
+// runImport syncs every team of the file with its roster, as PUT /team/sync does.
+// Teams missing from the file are not touched.
+func runImport(args []string) error {
+	opts, err := parseImportArgs(args)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(opts.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	teams, err := roster.Parse(file, opts.format)
+	if err != nil {
+		return err
+	}
+
+	db, err := postgres.NewDB(config.LoadDBConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer postgres.CloseDB(db) //nolint:errcheck
+
+	// Errors are reported by the command itself
+	logger := zap.NewNop()
+	userRepo := postgres.NewUserRepository(db, logger)
+	teamRepo := postgres.NewTeamRepository(db, logger)
+	prRepo := postgres.NewPullRequestRepository(db, logger)
+	codeOwnersRepo := postgres.NewCodeOwnersRepository(db, logger)
+
+	prUC := usecase.NewPRUseCase(userRepo, prRepo, teamRepo, codeOwnersRepo, domain.DefaultWorkingHours, db)
+	teamUC := usecase.NewTeamUseCase(teamRepo, userRepo, prRepo, prUC, db, logger)
+
+	return importTeams(context.Background(), os.Stdout, teamUC, teams, opts)
+}
+
+// teamSyncer is the part of usecase.TeamUseCase the import works with
+type teamSyncer interface {
+	PlanTeamSync(ctx context.Context, teamName string, members []domain.RosterMember) (*domain.RosterDiff, error)
+	SyncTeam(ctx context.Context, teamName string, members []domain.RosterMember, reassignReviews bool) (*domain.Team, *domain.RosterDiff, error)
+}
+
+// importTeams checks every team against the database before applying any of them, so a roster the sync
+// would reject, e.g. with a member of another team, stops the import before anything is written.
+// Then every team is applied in its own transaction; if one still fails, the teams applied before it are reported.
+func importTeams(ctx context.Context, w io.Writer, syncer teamSyncer, teams []roster.Team, opts importOptions) error {
+	diffs := make([]*domain.RosterDiff, len(teams))
+	for i, team := range teams {
+		diff, err := syncer.PlanTeamSync(ctx, team.Name, team.Members)
+		if err != nil {
+			return fmt.Errorf("team %s: %w; nothing was applied", team.Name, err)
+		}
+		diffs[i] = diff
+	}
+
+	if opts.dryRun {
+		changed := 0
+		for i, team := range teams {
+			printDiff(w, team.Name, diffs[i])
+			if diffs[i].HasChanges() {
+				changed++
+			}
+		}
+		fmt.Fprintf(w, "%d of %d teams would change (dry run, nothing was applied)\n", changed, len(teams))
+		return nil
+	}
+
+	changed := 0
+	for i, team := range teams {
+		_, diff, err := syncer.SyncTeam(ctx, team.Name, team.Members, opts.reassignReviews)
+		if err != nil {
+			// Teams printed above are applied, the import can be rerun
+			return fmt.Errorf("team %s: %w; applied %d of %d teams, not applied: %s",
+				team.Name, err, i, len(teams), strings.Join(teamNames(teams[i:]), ", "))
+		}
+
+		printDiff(w, team.Name, diff)
+		if diff.HasChanges() {
+			changed++
+		}
+	}
+	fmt.Fprintf(w, "%d of %d teams changed\n", changed, len(teams))
+
+	return nil
+}
+
+// teamNames returns the names of the teams
+func teamNames(teams []roster.Team) []string {
+	names := make([]string, len(teams))
+	for i, team := range teams {
+		names[i] = team.Name
+	}
+	return names
+}
+
+// printDiff writes the changes of a team, a line per user
+func printDiff(w io.Writer, teamName string, diff *domain.RosterDiff) {
+	switch {
+	case diff.TeamCreated:
+		fmt.Fprintf(w, "%s: new team\n", teamName)
+	case diff.HasChanges():
+		fmt.Fprintf(w, "%s:\n", teamName)
+	default:
+		fmt.Fprintf(w, "%s: no changes\n", teamName)
+		return
+	}
+
+	for _, change := range []struct {
+		mark    string
+		label   string
+		userIDs []string
+	}{
+		{"+", "created", diff.Created},
+		{"+", "joined", diff.Joined},
+		{"~", "updated", diff.Updated},
+		{"-", "removed", diff.Removed},
+	} {
+		for _, userID := range change.userIDs {
+			fmt.Fprintf(w, "  %s %s (%s)\n", change.mark, userID, change.label)
+		}
+	}
+
+	if diff.Reassignment != nil {
+		fmt.Fprintf(w, "  reviews handed over: %d, kept: %d\n", len(diff.Reassignment.Reassigned), len(diff.Reassignment.NotReassigned))
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/roster"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestParseImportArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected importOptions
+	}{
+		{"format by extension", []string{"teams.yml"}, importOptions{path: "teams.yml", format: roster.FormatYAML}},
+		{"dry run", []string{"-dry-run", "teams.csv"}, importOptions{path: "teams.csv", format: roster.FormatCSV, dryRun: true}},
+		{"explicit format", []string{"-format", "csv", "-dry-run", "export.txt"}, importOptions{path: "export.txt", format: roster.FormatCSV, dryRun: true}},
+		{"reassign reviews", []string{"-reassign-reviews", "teams.yaml"}, importOptions{path: "teams.yaml", format: roster.FormatYAML, reassignReviews: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseImportArgs(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+}
+
+func TestParseImportArgs_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no file", []string{"-dry-run"}},
+		{"two files", []string{"a.yaml", "b.yaml"}},
+		{"unknown flag", []string{"-force", "teams.yaml"}},
+		{"unknown extension", []string{"teams.json"}},
+		{"unknown format", []string{"-format", "json", "teams.yaml"}},
+		{"flag after the file", []string{"teams.yaml", "-dry-run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportArgs(tt.args)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := parseImportArgs([]string{"-h"})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+// syncerStub plans and applies the diffs it is given, failing on the teams with an error
+type syncerStub struct {
+	diffs    map[string]*domain.RosterDiff
+	planErrs map[string]error
+	syncErrs map[string]error
+	synced   []string // teams applied, in order
+	reassign []bool   // reassignReviews of every applied team
+}
+
+func (s *syncerStub) PlanTeamSync(_ context.Context, teamName string, _ []domain.RosterMember) (*domain.RosterDiff, error) {
+	if err := s.planErrs[teamName]; err != nil {
+		return nil, err
+	}
+	return s.diffs[teamName], nil
+}
+
+func (s *syncerStub) SyncTeam(_ context.Context, teamName string, _ []domain.RosterMember, reassignReviews bool) (*domain.Team, *domain.RosterDiff, error) {
+	if err := s.syncErrs[teamName]; err != nil {
+		return nil, nil, err
+	}
+	s.synced = append(s.synced, teamName)
+	s.reassign = append(s.reassign, reassignReviews)
+	return &domain.Team{Name: teamName}, s.diffs[teamName], nil
+}
+
+func testTeams() []roster.Team {
+	return []roster.Team{
+		{Name: "backend", Members: []domain.RosterMember{{ID: "u1", Name: "Alice"}, {ID: "u3", Name: "Carol"}}},
+		{Name: "docs", Members: []domain.RosterMember{{ID: "u5", Name: "Eve"}}},
+		{Name: "frontend", Members: []domain.RosterMember{{ID: "u4", Name: "Dave"}}},
+	}
+}
+
+func testDiffs() map[string]*domain.RosterDiff {
+	return map[string]*domain.RosterDiff{
+		"backend":  {Created: []string{"u3"}, Updated: []string{"u1"}, Removed: []string{"u2"}},
+		"docs":     {TeamCreated: true, Created: []string{"u5"}},
+		"frontend": {},
+	}
+}
+
+func TestImportTeams_DryRun(t *testing.T) {
+	syncer := &syncerStub{diffs: testDiffs()}
+	var out bytes.Buffer
+
+	err := importTeams(context.Background(), &out, syncer, testTeams(), importOptions{dryRun: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, syncer.synced)
+	assert.Equal(t, `backend:
+  + u3 (created)
+  ~ u1 (updated)
+  - u2 (removed)
+docs: new team
+  + u5 (created)
+frontend: no changes
+2 of 3 teams would change (dry run, nothing was applied)
+`, out.String())
+}
+
+func TestImportTeams_Apply(t *testing.T) {
+	syncer := &syncerStub{diffs: testDiffs()}
+	var out bytes.Buffer
+
+	err := importTeams(context.Background(), &out, syncer, testTeams(), importOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "docs", "frontend"}, syncer.synced)
+	assert.Contains(t, out.String(), "docs: new team\n")
+	assert.Contains(t, out.String(), "2 of 3 teams changed\n")
+}
+
+func TestImportTeams_ReassignReviews(t *testing.T) {
+	diffs := testDiffs()
+	diffs["backend"].Reassignment = &domain.ReassignmentReport{
+		Reassigned:    []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1"}},
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-2", ReviewerID: "u2"}, {PRID: "pr-3", ReviewerID: "u2"}},
+	}
+	syncer := &syncerStub{diffs: diffs}
+	var out bytes.Buffer
+
+	err := importTeams(context.Background(), &out, syncer, testTeams(), importOptions{reassignReviews: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, true}, syncer.reassign)
+	assert.Contains(t, out.String(), "  - u2 (removed)\n  reviews handed over: 1, kept: 2\n")
+}
+
+func TestImportTeams_PlanFails(t *testing.T) {
+	syncer := &syncerStub{
+		diffs:    testDiffs(),
+		planErrs: map[string]error{"frontend": domain.ErrMemberOfOtherTeam},
+	}
+	var out bytes.Buffer
+
+	err := importTeams(context.Background(), &out, syncer, testTeams(), importOptions{})
+
+	// the last team is checked before the first one is applied
+	assert.ErrorIs(t, err, domain.ErrMemberOfOtherTeam)
+	assert.ErrorContains(t, err, "nothing was applied")
+	assert.Empty(t, syncer.synced)
+	assert.Empty(t, out.String())
+}
+
+func TestImportTeams_ApplyFails(t *testing.T) {
+	syncer := &syncerStub{
+		diffs:    testDiffs(),
+		syncErrs: map[string]error{"docs": errors.New("connection reset")},
+	}
+	var out bytes.Buffer
+
+	err := importTeams(context.Background(), &out, syncer, testTeams(), importOptions{})
+
+	require.Error(t, err)
+	assert.Equal(t, "team docs: connection reset; applied 1 of 3 teams, not applied: docs, frontend", err.Error())
+	assert.Equal(t, []string{"backend"}, syncer.synced)
+	assert.Contains(t, out.String(), "backend:\n")
+	assert.NotContains(t, out.String(), "teams changed")
+}
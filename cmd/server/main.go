@@ -7,13 +7,18 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // WORKDAY_TIMEZONE is resolved without the timezone database of the runtime image
 
 	httpAdapter "github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/notifier"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/postgres"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/config"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/usecase"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/worker"
 	"go.uber.org/zap"
 )
 
@@ -44,16 +49,51 @@ func main() {
 	userRepo := postgres.NewUserRepository(db, logger)
 	teamRepo := postgres.NewTeamRepository(db, logger)
 	prRepo := postgres.NewPullRequestRepository(db, logger)
+	codeOwnersRepo := postgres.NewCodeOwnersRepository(db, logger)
+	absenceRepo := postgres.NewAbsenceRepository(db, logger)
 
 	statsRepo := postgres.NewStatsRepository(db)
 
 	// Initialize use cases
-	userUC := usecase.NewUserUseCase(userRepo, prRepo, teamRepo, db)
-	teamUC := usecase.NewTeamUseCase(teamRepo, userRepo, db)
-	prUC := usecase.NewPRUseCase(userRepo, prRepo, db)
+	workingHours, err := cfg.SLAConfig.WorkingHours()
+	if err != nil {
+		logger.Fatal("invalid working hours", zap.Error(err))
+	}
+
+	prUC := usecase.NewPRUseCase(userRepo, prRepo, teamRepo, codeOwnersRepo, workingHours, db)
+	userUC := usecase.NewUserUseCase(userRepo, prRepo, teamRepo, prUC, db, logger)
+	teamUC := usecase.NewTeamUseCase(teamRepo, userRepo, prRepo, prUC, db, logger)
+	codeOwnersUC := usecase.NewCodeOwnersUseCase(codeOwnersRepo, db)
+	absenceUC := usecase.NewAbsenceUseCase(absenceRepo, userRepo, prUC, db)
+	reminderUC := usecase.NewReminderUseCase(prRepo, notifier.NewLogNotifier(logger), domain.ReminderPolicy{
+		After:       cfg.WorkerConfig.ReminderAfter,
+		MaxInterval: cfg.WorkerConfig.ReminderMaxInterval,
+	}, db)
 
 	statsUC := usecase.NewStatsUseCase(statsRepo)
 
+	// Start background jobs
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
+	absenceWorker := worker.NewAbsenceWorker(absenceUC, cfg.WorkerConfig.AbsenceCheckInterval, logger)
+	workers.Go(func() { absenceWorker.Run(workerCtx) })
+
+	slaWorker := worker.NewSLAWorker(prUC, cfg.WorkerConfig.SLACheckInterval, logger)
+	workers.Go(func() { slaWorker.Run(workerCtx) })
+
+	if cfg.WorkerConfig.ReminderAfter > 0 {
+		reminderWorker := worker.NewReminderWorker(reminderUC, cfg.WorkerConfig.ReminderCheckInterval, logger)
+		workers.Go(func() { reminderWorker.Run(workerCtx) })
+	} else {
+		logger.Info("REMINDER_AFTER is 0, review reminders are disabled")
+	}
+
+	if cfg.AdminConfig.Token == "" {
+		logger.Warn("ADMIN_TOKEN is not set, admin endpoints are disabled")
+	}
+
 	// Start server
 	addr := fmt.Sprintf(":%d", cfg.ServerConfig.Port)
 	logger.Info("starting HTTP server",
@@ -61,7 +101,7 @@ func main() {
 
 	server := &http.Server{
 		Addr:    addr,
-		Handler: httpAdapter.SetupRouter(teamUC, userUC, prUC, statsUC),
+		Handler: httpAdapter.SetupRouter(teamUC, userUC, prUC, codeOwnersUC, absenceUC, statsUC, cfg.AdminConfig.Token),
 
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
@@ -82,6 +122,10 @@ func main() {
 
 	logger.Info("Shutdown signal received")
 
+	// Stop background jobs and wait for them to finish, so they don't outlive the database connection
+	stopWorkers()
+	workers.Wait()
+
 	// Shutdown servers
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
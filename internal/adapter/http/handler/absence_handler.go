@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http/model"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type absenceUseCase interface {
+	AddAbsence(ctx context.Context, userID string, startsAt, endsAt time.Time, reassignReviews bool) (*domain.Absence, error)
+	GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	RemoveAbsence(ctx context.Context, userID string, absenceID int64) ([]domain.Absence, error)
+}
+
+type AbsenceHandler struct {
+	absenceUC absenceUseCase
+}
+
+func NewAbsenceHandler(absenceUC absenceUseCase) *AbsenceHandler {
+	return &AbsenceHandler{absenceUC: absenceUC}
+}
+
+// AddAbsence handles POST /users/addAbsence, scheduling an out-of-office period of a user.
+// Response:
+//
+//	201 Created with the created absence.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including empty or already ended periods)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *AbsenceHandler) AddAbsence(c *gin.Context) {
+	var req model.AddAbsenceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	absence, err := h.absenceUC.AddAbsence(c.Request.Context(), req.UserID, req.StartsAt, req.EndsAt, req.ReassignReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAbsence) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"absence": model.AbsenceFromDomain(absence)})
+}
+
+// GetAbsences handles GET /users/getAbsences, returning current and upcoming absences of a user.
+// Response:
+//
+//	200 OK with the list of absences ordered by their start.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *AbsenceHandler) GetAbsences(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	absences, err := h.absenceUC.GetAbsences(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AbsencesFromDomain(userID, absences))
+}
+
+// RemoveAbsence handles POST /users/removeAbsence, deleting an absence of a user.
+// Response:
+//
+//	200 OK with the remaining absences of the user.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *AbsenceHandler) RemoveAbsence(c *gin.Context) {
+	var req model.RemoveAbsenceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	absences, err := h.absenceUC.RemoveAbsence(c.Request.Context(), req.UserID, req.AbsenceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AbsencesFromDomain(req.UserID, absences))
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http/model"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type codeOwnersUseCase interface {
+	SetCodeOwners(ctx context.Context, repository, content string) (*domain.CodeOwners, error)
+	GetCodeOwners(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
+
+type CodeOwnersHandler struct {
+	codeOwnersUC codeOwnersUseCase
+}
+
+func NewCodeOwnersHandler(codeOwnersUC codeOwnersUseCase) *CodeOwnersHandler {
+	return &CodeOwnersHandler{codeOwnersUC: codeOwnersUC}
+}
+
+// Set handles POST /codeOwners/set, replacing the CODEOWNERS file of a repository.
+// Response:
+//
+//	200 OK with the parsed CODEOWNERS.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including syntax errors in the file, with the line in the message)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *CodeOwnersHandler) Set(c *gin.Context) {
+	var req model.SetCodeOwnersRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	codeOwners, err := h.codeOwnersUC.SetCodeOwners(c.Request.Context(), req.Repository, req.Content)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCodeOwners) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"code_owners": model.CodeOwnersFromDomain(codeOwners)})
+}
+
+// Get handles GET /codeOwners/get, returning the CODEOWNERS file of a repository.
+// Response:
+//
+//	200 OK with the parsed CODEOWNERS.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *CodeOwnersHandler) Get(c *gin.Context) {
+	repository := c.Query("repository")
+	if repository == "" {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	codeOwners, err := h.codeOwnersUC.GetCodeOwners(c.Request.Context(), repository)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"code_owners": model.CodeOwnersFromDomain(codeOwners)})
+}
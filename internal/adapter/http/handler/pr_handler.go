@@ -13,7 +13,22 @@ import (
 type prUseCase interface {
 	CreatePRAndSetReviewers(ctx context.Context, pr domain.PullRequest) (*domain.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (*domain.PullRequest, string, error)
+	PreviewReviewers(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error)
+	AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, userID string, decision domain.Decision, text string) (*domain.PullRequest, error)
+	DeclineReview(ctx context.Context, prID, userID, reason string) (*domain.PullRequest, string, error)
+	GetAssignmentHistory(ctx context.Context, prID string) ([]domain.ReviewAssignment, error)
+	GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+	GetOverdueReviews(ctx context.Context, teamName string) ([]domain.OverdueReview, error)
+	GetOrphanedPRs(ctx context.Context) ([]domain.OrphanedPR, error)
+	TopUpReviewers(ctx context.Context) ([]domain.ReviewerTopUp, error)
+	ForceMergePR(ctx context.Context, prID, forcedBy, reason string) (*domain.PullRequest, error)
+	GetForcedMerges(ctx context.Context) ([]domain.ForcedMerge, error)
+	MarkReadyForReview(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error)
 }
 
 type PRHandler struct {
@@ -33,7 +48,7 @@ func NewPRHandler(prUC prUseCase) *PRHandler {
 //
 //	400 Bad Request (INVALID_INPUT)
 //	404 Not Found (NOT_FOUND - author/team not found)
-//	409 Conflict (PR_EXISTS)
+//	409 Conflict (PR_EXISTS, NOT_ENOUGH_REVIEWERS)
 //	500 Internal Server Error (INTERNAL_ERROR)
 func (h *PRHandler) Create(c *gin.Context) {
 	var req model.CreatePRRequest
@@ -57,6 +72,11 @@ func (h *PRHandler) Create(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrNotEnoughReviewers) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeNotEnoughReviewers, err.Error()))
+			return
+		}
+
 		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
 		return
 	}
@@ -74,6 +94,8 @@ func (h *PRHandler) Create(c *gin.Context) {
 //
 //	400 Bad Request (INVALID_INPUT)
 //	404 Not Found (NOT_FOUND)
+//	409 Conflict (INVALID_TRANSITION - draft or closed PR, MERGE_BLOCKED - the message lists
+//	unmet conditions of the team merge policy)
 //	500 Internal Server Error (INTERNAL_ERROR)
 func (h *PRHandler) Merge(c *gin.Context) {
 	var req model.MergePRRequest
@@ -91,6 +113,120 @@ func (h *PRHandler) Merge(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidTransition, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrMergeBlocked) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeMergeBlocked, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// MarkReady handles POST /pullRequest/markReady, moving a DRAFT PR to OPEN and assigning reviewers.
+// Response:
+//
+//	200 OK with the PR object in OPEN state.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (INVALID_TRANSITION - PR is not a draft, NOT_ENOUGH_REVIEWERS)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) MarkReady(c *gin.Context) {
+	h.openPR(c, h.prUC.MarkReadyForReview)
+}
+
+// Reopen handles POST /pullRequest/reopen, moving a CLOSED PR back to OPEN and assigning reviewers anew.
+// Response:
+//
+//	200 OK with the PR object in OPEN state.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (INVALID_TRANSITION - PR is not closed, NOT_ENOUGH_REVIEWERS)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Reopen(c *gin.Context) {
+	h.openPR(c, h.prUC.ReopenPR)
+}
+
+// openPR runs a transition of a PR to OPEN and writes the result
+func (h *PRHandler) openPR(c *gin.Context, open func(ctx context.Context, prID string) (*domain.PullRequest, error)) {
+	var req model.PRStatusRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := open(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidTransition, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotEnoughReviewers) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeNotEnoughReviewers, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// Close handles POST /pullRequest/close, closing a PR without merging and releasing its reviewers.
+// This operation is idempotent - closing a closed PR has no additional effect.
+// Response:
+//
+//	200 OK with the PR object in CLOSED state.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (INVALID_TRANSITION - PR is merged)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Close(c *gin.Context) {
+	var req model.PRStatusRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := h.prUC.ClosePR(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidTransition, err.Error()))
+			return
+		}
+
 		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
 		return
 	}
@@ -99,15 +235,17 @@ func (h *PRHandler) Merge(c *gin.Context) {
 }
 
 // Reassign handles POST /pullRequest/reassign, replacing a reviewer with another team member.
+// The new reviewer is selected by the team's strategy unless new_user_id is given.
 // Response:
 //
-//	200 OK with the PR object and the new reviewer's user_id.
+//	200 OK with the PR object and the new reviewer's user_id
+//	(null if the reviewer was removed without replacement due to the team policy).
 //
 // Errors:
 //
 //	400 Bad Request (INVALID_INPUT)
 //	404 Not Found (NOT_FOUND - PR or user not found)
-//	409 Conflict (PR_MERGED, NOT_ASSIGNED, NO_CANDIDATE)
+//	409 Conflict (PR_MERGED, PR_NOT_OPEN, NOT_ASSIGNED, NO_CANDIDATE, INVALID_CANDIDATE - new_user_id can't review the PR)
 //	500 Internal Server Error (INTERNAL_ERROR)
 func (h *PRHandler) Reassign(c *gin.Context) {
 	var req model.ReassignReviewerRequest
@@ -118,7 +256,7 @@ func (h *PRHandler) Reassign(c *gin.Context) {
 		return
 	}
 
-	pr, newReviewerID, err := h.prUC.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID)
+	pr, newReviewerID, err := h.prUC.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
@@ -130,11 +268,27 @@ func (h *PRHandler) Reassign(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrPRNotOpen) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRNotOpen, err.Error()))
+			return
+		}
+
 		if errors.Is(err, domain.ErrNotAssigned) {
 			c.JSON(model.WriteErrorResponse(model.ErrCodeNotAssigned))
 			return
 		}
 
+		if errors.Is(err, domain.ErrInvalidCandidate) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidCandidate, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrAllAtCapacity) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeNoCandidate,
+				"all replacement candidates reached their review capacity"))
+			return
+		}
+
 		if errors.Is(err, domain.ErrNoCandidate) {
 			c.JSON(model.WriteErrorResponse(model.ErrCodeNoCandidate))
 			return
@@ -144,8 +298,458 @@ func (h *PRHandler) Reassign(c *gin.Context) {
 		return
 	}
 
+	var replacedBy *string
+	if newReviewerID != "" {
+		replacedBy = &newReviewerID
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"pr":          model.PRFromDomain(pr),
-		"replaced_by": newReviewerID,
+		"replaced_by": replacedBy,
 	})
 }
+
+// Decline handles POST /pullRequest/decline, letting a reviewer refuse the review with a reason.
+// The reviewer is replaced as by /pullRequest/reassign and is never assigned to the PR again.
+// Response:
+//
+//	200 OK with the PR object and the new reviewer's user_id
+//	(null if the reviewer was removed without replacement due to the team policy).
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (PR_MERGED, PR_NOT_OPEN, NOT_ASSIGNED, NO_CANDIDATE - the reviewer stays assigned)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Decline(c *gin.Context) {
+	var req model.DeclineReviewRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, newReviewerID, err := h.prUC.DeclineReview(c.Request.Context(), req.PullRequestID, req.UserID, req.Reason)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRMerged) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodePRMerged))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRNotOpen) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRNotOpen, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotAssigned) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotAssigned))
+			return
+		}
+
+		if errors.Is(err, domain.ErrAllAtCapacity) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeNoCandidate,
+				"all replacement candidates reached their review capacity"))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNoCandidate) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNoCandidate))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	var replacedBy *string
+	if newReviewerID != "" {
+		replacedBy = &newReviewerID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr":          model.PRFromDomain(pr),
+		"replaced_by": replacedBy,
+	})
+}
+
+// Assignments handles GET /pullRequest/assignments, returning the assignment history of a PR:
+// current reviewers and ended assignments with the reason they have ended.
+// Response:
+//
+//	200 OK with the assignments ordered by their start.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Assignments(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	assignments, err := h.prUC.GetAssignmentHistory(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AssignmentHistoryFromDomain(prID, assignments))
+}
+
+// Events handles GET /pullRequest/events, returning the timeline of a PR: its creation,
+// reviewer changes, status changes and merge, each with its actor.
+// Response:
+//
+//	200 OK with the events ordered by their time.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Events(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	events, err := h.prUC.GetEvents(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.PREventsFromDomain(prID, events))
+}
+
+// Overdue handles GET /pullRequest/overdue, listing reviews whose reviewers exceeded the review SLA
+// of the author's team without a decision. Optional team_name limits the list to PRs of the team members.
+// Response:
+//
+//	200 OK with the overdue reviews, longest waiting first.
+//
+// Errors:
+//
+//	404 Not Found (NOT_FOUND - team not found)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Overdue(c *gin.Context) {
+	reviews, err := h.prUC.GetOverdueReviews(c.Request.Context(), c.Query("team_name"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.OverdueReviewsFromDomain(reviews))
+}
+
+// Orphaned handles GET /pullRequest/orphaned, listing open and draft PRs whose author's team
+// was archived while they were open. They need to be handed over or closed by hand.
+// Response:
+//
+//	200 OK with the PRs, oldest first.
+//
+// Errors:
+//
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Orphaned(c *gin.Context) {
+	prs, err := h.prUC.GetOrphanedPRs(c.Request.Context())
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.OrphanedPRsFromDomain(prs))
+}
+
+// Preview handles POST /pullRequest/preview, explaining reviewer selection for a hypothetical PR.
+// Nothing is written, round-robin rotation is not advanced.
+// Response:
+//
+//	200 OK with the candidate pool, excluded users with reasons, the reviewers that would be assigned
+//	and the shortfall against the team's min_reviewers.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND - author/team not found, or the author has no team)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) Preview(c *gin.Context) {
+	var req model.PreviewPRRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	preview, err := h.prUC.PreviewReviewers(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preview": model.AssignmentPreviewFromDomain(preview)})
+}
+
+// AddReviewer handles POST /pullRequest/addReviewer, assigning an additional reviewer by hand.
+// Response:
+//
+//	200 OK with the PR object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND - PR or user not found)
+//	409 Conflict (PR_MERGED, PR_NOT_OPEN, INVALID_CANDIDATE, TOO_MANY_REVIEWERS)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) AddReviewer(c *gin.Context) {
+	var req model.AddReviewerRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := h.prUC.AddReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRMerged) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRMerged, "cannot change reviewers of merged PR"))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRNotOpen) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRNotOpen, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidCandidate) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidCandidate, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrTooManyReviewers) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeTooManyReviewers, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// RemoveReviewer handles POST /pullRequest/removeReviewer, unassigning a reviewer without replacement.
+// Response:
+//
+//	200 OK with the PR object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (PR_MERGED, PR_NOT_OPEN, NOT_ASSIGNED)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) RemoveReviewer(c *gin.Context) {
+	var req model.RemoveReviewerRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := h.prUC.RemoveReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRMerged) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRMerged, "cannot change reviewers of merged PR"))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRNotOpen) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRNotOpen, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotAssigned) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotAssigned))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// SubmitReview handles POST /pullRequest/submitReview, recording the decision of a reviewer.
+// Response:
+//
+//	200 OK with the PR object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (PR_MERGED, PR_NOT_OPEN, NOT_ASSIGNED)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) SubmitReview(c *gin.Context) {
+	var req model.SubmitReviewRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := h.prUC.SubmitReview(c.Request.Context(), req.PullRequestID, req.UserID, domain.Decision(req.Decision), req.Text)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRMerged) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRMerged, "cannot review merged PR"))
+			return
+		}
+
+		if errors.Is(err, domain.ErrPRNotOpen) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodePRNotOpen, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotAssigned) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotAssigned))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// TopUpReviewers handles POST /admin/topUpReviewers, filling missing reviewer places
+// of all open PRs up to their teams' maximum.
+// Response:
+//
+//	200 OK with the list of assigned reviewers (empty if there were no gaps or no candidates).
+//
+// Errors:
+//
+//	401 Unauthorized (UNAUTHORIZED)
+//	500 Internal Server Error (INTERNAL_ERROR - reviewers assigned before the error are kept)
+func (h *PRHandler) TopUpReviewers(c *gin.Context) {
+	assigned, err := h.prUC.TopUpReviewers(c.Request.Context())
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TopUpReviewersFromDomain(assigned))
+}
+
+// ForceMerge handles POST /admin/forceMerge, merging a PR regardless of the team merge policy.
+// The merge is recorded in the audit trail with the conditions that were not met.
+// Response:
+//
+//	200 OK with the PR object in MERGED state.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	401 Unauthorized (UNAUTHORIZED)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (INVALID_TRANSITION - draft or closed PR)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) ForceMerge(c *gin.Context) {
+	var req model.ForceMergeRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	pr, err := h.prUC.ForceMergePR(c.Request.Context(), req.PullRequestID, req.ForcedBy, req.Reason)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidTransition, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": model.PRFromDomain(pr)})
+}
+
+// GetForcedMerges handles GET /admin/forcedMerges, returning the audit trail of forced merges.
+// Response:
+//
+//	200 OK with the forced merges, newest first.
+//
+// Errors:
+//
+//	401 Unauthorized (UNAUTHORIZED)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *PRHandler) GetForcedMerges(c *gin.Context) {
+	merges, err := h.prUC.GetForcedMerges(c.Request.Context())
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ForcedMergesFromDomain(merges))
+}
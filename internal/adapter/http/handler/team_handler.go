@@ -13,6 +13,17 @@ import (
 type teamUseCase interface {
 	CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	SetAssignmentStrategy(ctx context.Context, teamName string, strategy domain.AssignmentStrategy) (*domain.Team, error)
+	SetReviewersPolicy(ctx context.Context, teamName string, minReviewers, maxReviewers int) (*domain.Team, error)
+	SetFallbackTeams(ctx context.Context, teamName string, fallbackTeamNames []string) (*domain.Team, error)
+	SetMergePolicy(ctx context.Context, teamName string, minApprovals int, allowChangesRequested bool) (*domain.Team, error)
+	SetReviewSLA(ctx context.Context, teamName string, slaHours, reassignHours int) (*domain.Team, error)
+	AddTeamMembers(ctx context.Context, teamName string, members []domain.User) (*domain.Team, error)
+	RemoveTeamMember(ctx context.Context, teamName, userID string, reassignReviews bool) (*domain.Team, *domain.ReassignmentReport, error)
+	MoveTeamMember(ctx context.Context, userID, teamName string, reassignReviews bool) (*domain.User, *domain.ReassignmentReport, error)
+	SyncTeam(ctx context.Context, teamName string, members []domain.RosterMember, reassignReviews bool) (*domain.Team, *domain.RosterDiff, error)
+	ArchiveTeam(ctx context.Context, teamName string) (*domain.TeamArchive, error)
+	DeleteTeam(ctx context.Context, teamName string) error
 }
 
 type TeamHandler struct {
@@ -49,6 +60,17 @@ func (h *TeamHandler) Add(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrInvalidReviewersPolicy) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidStrategy) || errors.Is(err, domain.ErrInvalidMergePolicy) ||
+			errors.Is(err, domain.ErrInvalidReviewSLA) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
 		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
 		return
 	}
@@ -86,3 +108,454 @@ func (h *TeamHandler) Get(c *gin.Context) {
 
 	c.JSON(http.StatusOK, model.TeamFromDomain(team))
 }
+
+// SetAssignmentStrategy handles POST /team/setAssignmentStrategy, changing how reviewers
+// are selected for PRs of the team members.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) SetAssignmentStrategy(c *gin.Context) {
+	var req model.SetAssignmentStrategyRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.SetAssignmentStrategy(c.Request.Context(), req.TeamName, domain.AssignmentStrategy(req.AssignmentStrategy))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStrategy) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// SetReviewersPolicy handles POST /team/setReviewersPolicy, changing how many reviewers
+// are assigned to PRs of the team members.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including min_reviewers greater than max_reviewers
+//	or max_reviewers below the approvals required by the merge policy)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) SetReviewersPolicy(c *gin.Context) {
+	var req model.SetReviewersPolicyRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.SetReviewersPolicy(c.Request.Context(), req.TeamName, *req.MinReviewers, req.MaxReviewers)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidReviewersPolicy) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidMergePolicy) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// SetMergePolicy handles POST /admin/setMergePolicy, changing when PRs of the team members can be merged.
+// Admin-only, as relaxing the policy lets PRs merge without the audit of POST /admin/forceMerge.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including min_approvals greater than max_reviewers)
+//	401 Unauthorized (UNAUTHORIZED)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) SetMergePolicy(c *gin.Context) {
+	var req model.SetMergePolicyRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.SetMergePolicy(c.Request.Context(), req.TeamName, *req.MinApprovals, req.AllowChangesRequested)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidMergePolicy) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// SetReviewSLA handles POST /team/setReviewSLA, changing when reviews of PRs of the team members
+// are overdue and when overdue reviews are reassigned.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including sla_reassign_hours below review_sla_hours)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) SetReviewSLA(c *gin.Context) {
+	var req model.SetReviewSLARequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.SetReviewSLA(c.Request.Context(), req.TeamName, *req.ReviewSLAHours, req.SLAReassignHours)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidReviewSLA) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// SetFallbackTeams handles POST /team/setFallbackTeams, replacing the teams that provide
+// reviewers when the team itself lacks candidates.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT - including the team itself or duplicates in fallback_teams)
+//	404 Not Found (NOT_FOUND - team or fallback team not found)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) SetFallbackTeams(c *gin.Context) {
+	var req model.SetFallbackTeamsRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.SetFallbackTeams(c.Request.Context(), req.TeamName, req.FallbackTeams)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidFallbackTeam) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// AddMembers handles POST /team/addMembers, adding new or team-less users to an existing team.
+// Response:
+//
+//	200 OK with the team object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (MEMBER_OF_OTHER_TEAM - the user has to be moved instead)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) AddMembers(c *gin.Context) {
+	var req model.AddTeamMembersRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, err := h.teamUC.AddTeamMembers(c.Request.Context(), req.TeamName, req.MembersToDomain())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+		if errors.Is(err, domain.ErrMemberOfOtherTeam) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeMemberOfOtherTeam, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": model.TeamFromDomain(team)})
+}
+
+// RemoveMember handles POST /team/removeMember, removing a user from the team.
+// With reassign_reviews open reviews of the user are handed over in the same transaction.
+// Response:
+//
+//	200 OK with the team object and, if requested, the reassignment report.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND - including users that are not members of the team)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	var req model.RemoveTeamMemberRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, report, err := h.teamUC.RemoveTeamMember(c.Request.Context(), req.TeamName, req.UserID, req.ReassignReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotTeamMember) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeNotFound, err.Error()))
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	resp := model.RemoveTeamMemberResponse{Team: model.TeamFromDomain(team)}
+	if report != nil {
+		reassignment := model.ReassignmentFromDomain(report)
+		resp.Reassignment = &reassignment
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MoveMember handles POST /team/moveMember, moving a user to another team.
+// With reassign_reviews open reviews of the user are handed over in the same transaction.
+// Response:
+//
+//	200 OK with the updated user object and, if requested, the reassignment report.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) MoveMember(c *gin.Context) {
+	var req model.MoveTeamMemberRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	user, report, err := h.teamUC.MoveTeamMember(c.Request.Context(), req.UserID, req.TeamName, req.ReassignReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	resp := model.MoveTeamMemberResponse{User: model.UserFromDomain(user)}
+	if report != nil {
+		reassignment := model.ReassignmentFromDomain(report)
+		resp.Reassignment = &reassignment
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Sync handles PUT /team/sync, reconciling the team with the full desired roster.
+// A missing team is created with default settings.
+// With reassign_reviews, open reviews of removed and deactivated members are handed over.
+// Response:
+//
+//	200 OK with the team object, the diff of the changes and, if requested, the reassignment report.
+//	201 Created with the same body if the team was created.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT, TEAM_EXISTS - the name is taken by an archived team or the team was created concurrently)
+//	409 Conflict (MEMBER_OF_OTHER_TEAM - the user has to be moved instead)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) Sync(c *gin.Context) {
+	var req model.SyncTeamRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	team, diff, err := h.teamUC.SyncTeam(c.Request.Context(), req.TeamName, req.MembersToDomain(), req.ReassignReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRoster) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, err.Error()))
+			return
+		}
+		if errors.Is(err, domain.ErrMemberOfOtherTeam) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeMemberOfOtherTeam, err.Error()))
+			return
+		}
+		if errors.Is(err, domain.ErrTeamExists) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeTeamExists))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	status := http.StatusOK
+	if diff.TeamCreated {
+		status = http.StatusCreated
+	}
+
+	resp := model.SyncTeamResponse{
+		Team: model.TeamFromDomain(team),
+		Diff: model.RosterDiffFromDomain(diff),
+	}
+	if diff.Reassignment != nil {
+		reassignment := model.ReassignmentFromDomain(diff.Reassignment)
+		resp.Reassignment = &reassignment
+	}
+
+	c.JSON(status, resp)
+}
+
+// Archive handles POST /admin/archiveTeam, hiding the team and detaching its members, who are deactivated.
+// Open and draft PRs of the members are flagged, see GET /pullRequest/orphaned; their open reviews are handed over.
+// Response:
+//
+//	200 OK with the detached members, flagged PRs and the reassignment report.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	401 Unauthorized (UNAUTHORIZED)
+//	404 Not Found (NOT_FOUND - including archived teams)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) Archive(c *gin.Context) {
+	var req model.TeamNameRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	archive, err := h.teamUC.ArchiveTeam(c.Request.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TeamArchiveFromDomain(archive))
+}
+
+// Delete handles POST /admin/deleteTeam, removing an archived or active team for good.
+// Remaining members are detached from the team, not deleted.
+// Response:
+//
+//	200 OK with the name of the deleted team.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	401 Unauthorized (UNAUTHORIZED)
+//	404 Not Found (NOT_FOUND)
+//	409 Conflict (TEAM_IN_USE - open reviews still reference the team)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *TeamHandler) Delete(c *gin.Context) {
+	var req model.TeamNameRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	err := h.teamUC.DeleteTeam(c.Request.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+		if errors.Is(err, domain.ErrTeamInUse) {
+			c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeTeamInUse, err.Error()))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team_name": req.TeamName})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http/model"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// teamUseCaseStub creates teams it is given; other methods are not expected to be called
+type teamUseCaseStub struct {
+	teamUseCase
+	created []domain.Team
+}
+
+func (s *teamUseCaseStub) CreateTeam(_ context.Context, team domain.Team) (*domain.Team, error) {
+	s.created = append(s.created, team)
+	return &team, nil
+}
+
+func serveTeamHandler(t *testing.T, register func(r *gin.Engine, h *TeamHandler), uc teamUseCase, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	register(router, NewTeamHandler(uc))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTeamHandler_Add_InvalidMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		member string
+	}{
+		{"negative capacity", `{"user_id": "u1", "username": "Alice", "is_active": true, "max_open_reviews": -1}`},
+		{"missing username", `{"user_id": "u1", "is_active": true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &teamUseCaseStub{}
+			w := serveTeamHandler(t, func(r *gin.Engine, h *TeamHandler) { r.POST("/team/add", h.Add) },
+				uc, http.MethodPost, "/team/add", `{"team_name": "backend", "members": [`+tt.member+`]}`)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var resp model.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, model.ErrCodeInvalidInput, resp.Error.Code)
+			assert.Empty(t, uc.created)
+		})
+	}
+}
+
+func TestTeamHandler_Add(t *testing.T) {
+	uc := &teamUseCaseStub{}
+	w := serveTeamHandler(t, func(r *gin.Engine, h *TeamHandler) { r.POST("/team/add", h.Add) },
+		uc, http.MethodPost, "/team/add",
+		`{"team_name": "backend", "members": [{"user_id": "u1", "username": "Alice", "is_active": true, "max_open_reviews": 3}]}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, uc.created, 1)
+	assert.Equal(t, 3, uc.created[0].Members[0].MaxOpenReviews)
+}
@@ -11,8 +11,10 @@ import (
 )
 
 type userUseCase interface {
-	SetUserIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
-	GetAssignedPRs(ctx context.Context, userID string) ([]*domain.PullRequest, error)
+	SetUserIsActive(ctx context.Context, userID string, isActive, reassignReviews bool) (*domain.User, *domain.ReassignmentReport, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (*domain.User, error)
+	SetTags(ctx context.Context, userID string, tags []string) (*domain.User, error)
+	GetAssignedPRs(ctx context.Context, userID string, status domain.ReviewStatus) ([]*domain.PullRequest, error)
 }
 
 type UserHandler struct {
@@ -24,9 +26,10 @@ func NewUserHandler(userUC userUseCase) *UserHandler {
 }
 
 // SetIsActive handles POST /users/setIsActive, updating a user's active status.
+// On deactivation with reassign_reviews open reviews of the user are handed over in the same transaction.
 // Response:
 //
-//	200 OK with the updated user object.
+//	200 OK with the updated user object and, if requested, the reassignment report.
 //
 // Errors:
 //
@@ -41,7 +44,77 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userUC.SetUserIsActive(c.Request.Context(), req.UserID, *req.IsActive)
+	user, report, err := h.userUC.SetUserIsActive(c.Request.Context(), req.UserID, *req.IsActive, req.ReassignReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	resp := model.SetIsActiveResponse{User: model.UserFromDomain(user)}
+	if report != nil {
+		reassignment := model.ReassignmentFromDomain(report)
+		resp.Reassignment = &reassignment
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetMaxOpenReviews handles POST /users/setMaxOpenReviews, updating a user's review capacity.
+// Response:
+//
+//	200 OK with the updated user object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *UserHandler) SetMaxOpenReviews(c *gin.Context) {
+	var req model.SetMaxOpenReviewsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	user, err := h.userUC.SetMaxOpenReviews(c.Request.Context(), req.UserID, *req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
+			return
+		}
+
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInternal))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SetIsActiveResponse{User: model.UserFromDomain(user)})
+}
+
+// SetTags handles POST /users/setTags, replacing a user's skill tags.
+// Response:
+//
+//	200 OK with the updated user object.
+//
+// Errors:
+//
+//	400 Bad Request (INVALID_INPUT)
+//	404 Not Found (NOT_FOUND)
+//	500 Internal Server Error (INTERNAL_ERROR)
+func (h *UserHandler) SetTags(c *gin.Context) {
+	var req model.SetTagsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(model.WriteErrorResponse(model.ErrCodeInvalidInput))
+		return
+	}
+
+	user, err := h.userUC.SetTags(c.Request.Context(), req.UserID, req.Tags)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
@@ -56,6 +129,8 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 }
 
 // GetReview handles GET /users/getReview, returning PRs where the user is assigned as a reviewer.
+// Optional review_status query parameter (PENDING or DECIDED) selects PRs by whether
+// the user has submitted a decision.
 // Response:
 //
 //	200 OK with the list of PRs (both OPEN and MERGED).
@@ -72,7 +147,13 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 		return
 	}
 
-	prs, err := h.userUC.GetAssignedPRs(c.Request.Context(), userID)
+	status := domain.ReviewStatus(c.Query("review_status"))
+	if status != domain.ReviewStatusAny && status != domain.ReviewStatusPending && status != domain.ReviewStatusDecided {
+		c.JSON(model.WriteErrorResponseWithMessage(model.ErrCodeInvalidInput, "review_status must be PENDING or DECIDED"))
+		return
+	}
+
+	prs, err := h.userUC.GetAssignedPRs(c.Request.Context(), userID, status)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(model.WriteErrorResponse(model.ErrCodeNotFound))
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http/model"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// adminAuth allows only requests carrying "Authorization: Bearer <token>" with the admin token.
+// If the token is not configured, every request is rejected.
+func adminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(model.WriteErrorResponse(model.ErrCodeUnauthorized))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// actorHeader optionally tells who makes the request; the actor is recorded in the PR events it causes
+const actorHeader = "X-Actor"
+
+// actorContext puts the actor from the X-Actor header into the request context.
+// The header is informational and not authenticated.
+func actorContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := strings.TrimSpace(c.GetHeader(actorHeader)); actor != "" {
+			c.Request = c.Request.WithContext(domain.WithActor(c.Request.Context(), actor))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// AddAbsenceRequest represents request body for POST /users/addAbsence
+type AddAbsenceRequest struct {
+	UserID   string    `json:"user_id" binding:"required"`
+	StartsAt time.Time `json:"starts_at" binding:"required"` // RFC 3339
+	EndsAt   time.Time `json:"ends_at" binding:"required"`   // RFC 3339, exclusive
+	// ReassignReviews requests handing over open reviews of the user when the absence starts
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+// RemoveAbsenceRequest represents request body for POST /users/removeAbsence
+type RemoveAbsenceRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	AbsenceID int64  `json:"absence_id" binding:"required"`
+}
+
+// AbsenceResponse represents an out-of-office period of a user in responses
+type AbsenceResponse struct {
+	AbsenceID       int64   `json:"absence_id"`
+	UserID          string  `json:"user_id"`
+	StartsAt        string  `json:"starts_at"`
+	EndsAt          string  `json:"ends_at"`
+	ReassignReviews bool    `json:"reassign_reviews"`
+	ReassignedAt    *string `json:"reassigned_at,omitempty"`
+}
+
+// AbsenceFromDomain converts domain.Absence to AbsenceResponse
+func AbsenceFromDomain(absence *domain.Absence) AbsenceResponse {
+	resp := AbsenceResponse{
+		AbsenceID:       absence.ID,
+		UserID:          absence.UserID,
+		StartsAt:        absence.StartsAt.Format(time.RFC3339),
+		EndsAt:          absence.EndsAt.Format(time.RFC3339),
+		ReassignReviews: absence.ReassignReviews,
+	}
+
+	if absence.ReassignedAt != nil {
+		reassignedAt := absence.ReassignedAt.Format(time.RFC3339)
+		resp.ReassignedAt = &reassignedAt
+	}
+
+	return resp
+}
+
+// AbsencesResponse represents response for GET /users/getAbsences and POST /users/removeAbsence
+type AbsencesResponse struct {
+	UserID   string            `json:"user_id"`
+	Absences []AbsenceResponse `json:"absences"`
+}
+
+// AbsencesFromDomain converts absences of the user to AbsencesResponse
+func AbsencesFromDomain(userID string, absences []domain.Absence) AbsencesResponse {
+	resp := AbsencesResponse{
+		UserID:   userID,
+		Absences: make([]AbsenceResponse, len(absences)),
+	}
+	for i := range absences {
+		resp.Absences[i] = AbsenceFromDomain(&absences[i])
+	}
+	return resp
+}
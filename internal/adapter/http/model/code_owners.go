@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// SetCodeOwnersRequest represents request body for POST /codeOwners/set
+type SetCodeOwnersRequest struct {
+	Repository string `json:"repository" binding:"required"`
+	Content    string `json:"content"` // CODEOWNERS file, empty content removes all rules
+}
+
+// CodeOwnersRuleResponse represents a single parsed CODEOWNERS rule
+type CodeOwnersRuleResponse struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// CodeOwnersResponse represents CODEOWNERS of a repository in responses
+type CodeOwnersResponse struct {
+	Repository string                   `json:"repository"`
+	Content    string                   `json:"content"`
+	Rules      []CodeOwnersRuleResponse `json:"rules"`
+	UpdatedAt  string                   `json:"updatedAt"`
+}
+
+// CodeOwnersFromDomain converts domain.CodeOwners to CodeOwnersResponse
+func CodeOwnersFromDomain(codeOwners *domain.CodeOwners) CodeOwnersResponse {
+	rules := make([]CodeOwnersRuleResponse, len(codeOwners.Rules))
+	for i, r := range codeOwners.Rules {
+		owners := r.Owners
+		if owners == nil {
+			owners = []string{}
+		}
+		rules[i] = CodeOwnersRuleResponse{Pattern: r.Pattern, Owners: owners}
+	}
+
+	return CodeOwnersResponse{
+		Repository: codeOwners.Repository,
+		Content:    codeOwners.Content,
+		Rules:      rules,
+		UpdatedAt:  codeOwners.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -5,14 +5,23 @@ import "net/http"
 type ErrorCode string
 
 const (
-	ErrCodeTeamExists   ErrorCode = "TEAM_EXISTS"
-	ErrCodePRExists     ErrorCode = "PR_EXISTS"
-	ErrCodePRMerged     ErrorCode = "PR_MERGED"
-	ErrCodeNotAssigned  ErrorCode = "NOT_ASSIGNED"
-	ErrCodeNoCandidate  ErrorCode = "NO_CANDIDATE"
-	ErrCodeNotFound     ErrorCode = "NOT_FOUND"
-	ErrCodeInternal     ErrorCode = "INTERNAL_ERROR"
-	ErrCodeInvalidInput ErrorCode = "INVALID_INPUT"
+	ErrCodeTeamExists         ErrorCode = "TEAM_EXISTS"
+	ErrCodePRExists           ErrorCode = "PR_EXISTS"
+	ErrCodePRMerged           ErrorCode = "PR_MERGED"
+	ErrCodeNotAssigned        ErrorCode = "NOT_ASSIGNED"
+	ErrCodeNoCandidate        ErrorCode = "NO_CANDIDATE"
+	ErrCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrCodeNotEnoughReviewers ErrorCode = "NOT_ENOUGH_REVIEWERS"
+	ErrCodeInvalidCandidate   ErrorCode = "INVALID_CANDIDATE"
+	ErrCodeTooManyReviewers   ErrorCode = "TOO_MANY_REVIEWERS"
+	ErrCodeMergeBlocked       ErrorCode = "MERGE_BLOCKED"
+	ErrCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrCodeInvalidTransition  ErrorCode = "INVALID_TRANSITION"
+	ErrCodePRNotOpen          ErrorCode = "PR_NOT_OPEN"
+	ErrCodeMemberOfOtherTeam  ErrorCode = "MEMBER_OF_OTHER_TEAM"
+	ErrCodeTeamInUse          ErrorCode = "TEAM_IN_USE"
+	ErrCodeInternal           ErrorCode = "INTERNAL_ERROR"
+	ErrCodeInvalidInput       ErrorCode = "INVALID_INPUT"
 )
 
 func WriteErrorResponse(code ErrorCode) (int, ErrorResponse) {
@@ -27,6 +36,24 @@ func WriteErrorResponse(code ErrorCode) (int, ErrorResponse) {
 		return http.StatusConflict, NewErrorResponse(code, "reviewer is not assigned to this PR")
 	case ErrCodeNoCandidate:
 		return http.StatusConflict, NewErrorResponse(code, "no active replacement candidate in team")
+	case ErrCodeNotEnoughReviewers:
+		return http.StatusConflict, NewErrorResponse(code, "team cannot provide the required number of reviewers")
+	case ErrCodeInvalidCandidate:
+		return http.StatusConflict, NewErrorResponse(code, "user cannot review this PR")
+	case ErrCodeTooManyReviewers:
+		return http.StatusConflict, NewErrorResponse(code, "PR already has the maximum number of reviewers")
+	case ErrCodeMergeBlocked:
+		return http.StatusConflict, NewErrorResponse(code, "PR does not meet the merge policy of the team")
+	case ErrCodeInvalidTransition:
+		return http.StatusConflict, NewErrorResponse(code, "PR cannot move to the requested status")
+	case ErrCodePRNotOpen:
+		return http.StatusConflict, NewErrorResponse(code, "PR is not open for review")
+	case ErrCodeMemberOfOtherTeam:
+		return http.StatusConflict, NewErrorResponse(code, "user is a member of another team")
+	case ErrCodeTeamInUse:
+		return http.StatusConflict, NewErrorResponse(code, "team is referenced by open reviews")
+	case ErrCodeUnauthorized:
+		return http.StatusUnauthorized, NewErrorResponse(code, "valid admin token required")
 	case ErrCodeNotFound:
 		return http.StatusNotFound, NewErrorResponse(code, "resource not found")
 	case ErrCodeInvalidInput:
@@ -38,6 +65,14 @@ func WriteErrorResponse(code ErrorCode) (int, ErrorResponse) {
 	}
 }
 
+// WriteErrorResponseWithMessage works like WriteErrorResponse, but replaces the default message
+// with a more specific one
+func WriteErrorResponseWithMessage(code ErrorCode, message string) (int, ErrorResponse) {
+	status, resp := WriteErrorResponse(code)
+	resp.Error.Message = message
+	return status, resp
+}
+
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"math"
 	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
@@ -11,14 +12,31 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id" binding:"required"`
 	PullRequestName string `json:"pull_request_name" binding:"required"`
 	AuthorID        string `json:"author_id" binding:"required"`
+	// Repository and ChangedFiles are optional; when given, owners of the changed files
+	// according to the repository CODEOWNERS are preferred as reviewers
+	Repository   string   `json:"repository"`
+	ChangedFiles []string `json:"changed_files" binding:"omitempty,dive,required"`
+	// Labels describe what the PR is about (go, sql, ...); reviewers with matching tags are preferred
+	Labels []string `json:"labels"`
+	// Draft PRs get no reviewers until they are marked ready for review
+	Draft bool `json:"draft"`
 }
 
 // ToDomain converts HTTP request to domain model
 func (r *CreatePRRequest) ToDomain() domain.PullRequest {
+	var status domain.PRStatus
+	if r.Draft {
+		status = domain.StatusDraft
+	}
+
 	return domain.PullRequest{
-		ID:       r.PullRequestID,
-		Name:     r.PullRequestName,
-		AuthorID: r.AuthorID,
+		ID:           r.PullRequestID,
+		Name:         r.PullRequestName,
+		AuthorID:     r.AuthorID,
+		Repository:   r.Repository,
+		ChangedFiles: r.ChangedFiles,
+		Labels:       r.Labels,
+		Status:       status,
 	}
 }
 
@@ -27,21 +45,86 @@ type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
 }
 
+// ForceMergeRequest represents request body for POST /admin/forceMerge
+type ForceMergeRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	ForcedBy      string `json:"forced_by" binding:"required"`
+	Reason        string `json:"reason" binding:"required"`
+}
+
+// PRStatusRequest represents request body for POST /pullRequest/markReady, /pullRequest/close
+// and /pullRequest/reopen
+type PRStatusRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+}
+
 // ReassignReviewerRequest represents request body for POST /pullRequest/reassign
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
 	OldUserID     string `json:"old_user_id" binding:"required"`
+	// NewUserID is optional; when given, this user becomes the reviewer instead of a selected one
+	NewUserID string `json:"new_user_id"`
+}
+
+// AddReviewerRequest represents request body for POST /pullRequest/addReviewer
+type AddReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+}
+
+// RemoveReviewerRequest represents request body for POST /pullRequest/removeReviewer
+type RemoveReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+}
+
+// SubmitReviewRequest represents request body for POST /pullRequest/submitReview
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+	Decision      string `json:"decision" binding:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+	Text          string `json:"text"`
+}
+
+// DeclineReviewRequest represents request body for POST /pullRequest/decline
+type DeclineReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+	Reason        string `json:"reason" binding:"required"`
+}
+
+// ReviewDeclineResponse represents a reviewer that declined to review the PR
+type ReviewDeclineResponse struct {
+	UserID     string `json:"user_id"`
+	Reason     string `json:"reason"`
+	DeclinedAt string `json:"declined_at"`
+}
+
+// ReviewDecisionResponse represents the latest decision of a reviewer
+type ReviewDecisionResponse struct {
+	UserID    string `json:"user_id"`
+	Decision  string `json:"decision"`
+	Text      string `json:"text,omitempty"`
+	DecidedAt string `json:"decided_at"`
 }
 
 // PullRequestResponse represents full PR object in responses
 type PullRequestResponse struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	CreatedAt         *string  `json:"createdAt,omitempty"`
-	MergedAt          *string  `json:"mergedAt,omitempty"`
+	PullRequestID      string   `json:"pull_request_id"`
+	PullRequestName    string   `json:"pull_request_name"`
+	AuthorID           string   `json:"author_id"`
+	Status             string   `json:"status"`
+	AssignedReviewers  []string `json:"assigned_reviewers"`
+	Labels             []string `json:"labels"`
+	FallbackReviewers  []string `json:"fallback_reviewers,omitempty"`   // subset of assigned_reviewers taken from fallback teams
+	CodeOwnerReviewers []string `json:"code_owner_reviewers,omitempty"` // subset of assigned_reviewers owning the changed files
+	ManualReviewers    []string `json:"manual_reviewers,omitempty"`     // subset of assigned_reviewers added by hand
+	// latest decisions of assigned reviewers, reviewers without a decision are pending
+	ReviewDecisions []ReviewDecisionResponse `json:"review_decisions,omitempty"`
+	// users that declined to review the PR, they are never assigned to it again
+	Declines  []ReviewDeclineResponse `json:"declines,omitempty"`
+	CreatedAt *string                 `json:"createdAt,omitempty"`
+	MergedAt  *string                 `json:"mergedAt,omitempty"`
 }
 
 // PullRequestShortResponse represents short PR object in list responses
@@ -67,14 +150,54 @@ func PRFromDomain(pr *domain.PullRequest) PullRequestResponse {
 	}
 
 	return PullRequestResponse{
-		PullRequestID:     pr.ID,
-		PullRequestName:   pr.Name,
-		AuthorID:          pr.AuthorID,
-		Status:            string(pr.Status),
-		AssignedReviewers: pr.ReviewersIDs,
-		CreatedAt:         createdAt,
-		MergedAt:          mergedAt,
+		PullRequestID:      pr.ID,
+		PullRequestName:    pr.Name,
+		AuthorID:           pr.AuthorID,
+		Status:             string(pr.Status),
+		AssignedReviewers:  pr.ReviewersIDs,
+		Labels:             nonNilStrings(pr.Labels),
+		FallbackReviewers:  pr.ReviewersIDsBySource(domain.SourceFallback),
+		CodeOwnerReviewers: pr.ReviewersIDsBySource(domain.SourceCodeOwners),
+		ManualReviewers:    pr.ReviewersIDsBySource(domain.SourceManual),
+		ReviewDecisions:    reviewDecisionsFromDomain(pr),
+		Declines:           reviewDeclinesFromDomain(pr),
+		CreatedAt:          createdAt,
+		MergedAt:           mergedAt,
+	}
+}
+
+// reviewDecisionsFromDomain lists decisions of the PR reviewers in the order of assigned reviewers
+func reviewDecisionsFromDomain(pr *domain.PullRequest) []ReviewDecisionResponse {
+	var decisions []ReviewDecisionResponse
+	for _, id := range pr.ReviewersIDs {
+		decision, ok := pr.Decisions[id]
+		if !ok {
+			continue
+		}
+
+		decisions = append(decisions, ReviewDecisionResponse{
+			UserID:    id,
+			Decision:  string(decision.Decision),
+			Text:      decision.Text,
+			DecidedAt: decision.DecidedAt.Format(time.RFC3339),
+		})
 	}
+
+	return decisions
+}
+
+// reviewDeclinesFromDomain converts declines of the PR to their responses
+func reviewDeclinesFromDomain(pr *domain.PullRequest) []ReviewDeclineResponse {
+	var declines []ReviewDeclineResponse
+	for _, d := range pr.Declines {
+		declines = append(declines, ReviewDeclineResponse{
+			UserID:     d.UserID,
+			Reason:     d.Reason,
+			DeclinedAt: d.DeclinedAt.Format(time.RFC3339),
+		})
+	}
+
+	return declines
 }
 
 // PRShortFromDomain converts domain.PullRequest to PullRequestShortResponse
@@ -121,3 +244,202 @@ func GetAssignedPRsFromDomain(userID string, prs []*domain.PullRequest) GetAssig
 		PullRequests: prResponses,
 	}
 }
+
+// ReviewerTopUpResponse represents a reviewer assigned to a missing place of a PR
+type ReviewerTopUpResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Source        string `json:"source"`
+}
+
+// TopUpReviewersResponse represents response for POST /admin/topUpReviewers
+type TopUpReviewersResponse struct {
+	Assigned []ReviewerTopUpResponse `json:"assigned"`
+}
+
+// TopUpReviewersFromDomain converts assigned reviewers to TopUpReviewersResponse
+func TopUpReviewersFromDomain(topUps []domain.ReviewerTopUp) TopUpReviewersResponse {
+	assigned := make([]ReviewerTopUpResponse, len(topUps))
+	for i, t := range topUps {
+		assigned[i] = ReviewerTopUpResponse{
+			PullRequestID: t.PRID,
+			UserID:        t.ReviewerID,
+			Source:        string(t.Source),
+		}
+	}
+
+	return TopUpReviewersResponse{Assigned: assigned}
+}
+
+// ForcedMergeResponse represents an audit record of a merge that bypassed the merge policy
+type ForcedMergeResponse struct {
+	PullRequestID   string   `json:"pull_request_id"`
+	ForcedBy        string   `json:"forced_by"`
+	Reason          string   `json:"reason"`
+	UnmetConditions []string `json:"unmet_conditions"`
+	CreatedAt       string   `json:"createdAt"`
+}
+
+// ForcedMergesResponse represents response for GET /admin/forcedMerges
+type ForcedMergesResponse struct {
+	ForcedMerges []ForcedMergeResponse `json:"forced_merges"`
+}
+
+// ForcedMergesFromDomain converts the audit trail of forced merges to ForcedMergesResponse
+func ForcedMergesFromDomain(merges []domain.ForcedMerge) ForcedMergesResponse {
+	result := make([]ForcedMergeResponse, len(merges))
+	for i, m := range merges {
+		result[i] = ForcedMergeResponse{
+			PullRequestID:   m.PRID,
+			ForcedBy:        m.ForcedBy,
+			Reason:          m.Reason,
+			UnmetConditions: nonNilStrings(m.UnmetConditions),
+			CreatedAt:       m.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return ForcedMergesResponse{ForcedMerges: result}
+}
+
+// ReviewAssignmentResponse represents a current or ended assignment of a reviewer to a PR
+type ReviewAssignmentResponse struct {
+	UserID         string  `json:"user_id"`
+	Source         string  `json:"source"`
+	AssignedAt     string  `json:"assigned_at"`
+	UnassignedAt   *string `json:"unassigned_at"`   // null while the assignment is current
+	UnassignReason *string `json:"unassign_reason"` // null while the assignment is current
+}
+
+// AssignmentHistoryResponse represents response for GET /pullRequest/assignments
+type AssignmentHistoryResponse struct {
+	PullRequestID string                     `json:"pull_request_id"`
+	Assignments   []ReviewAssignmentResponse `json:"assignments"`
+}
+
+// AssignmentHistoryFromDomain converts assignments of the PR to AssignmentHistoryResponse
+func AssignmentHistoryFromDomain(prID string, assignments []domain.ReviewAssignment) AssignmentHistoryResponse {
+	result := make([]ReviewAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		result[i] = ReviewAssignmentResponse{
+			UserID:     a.UserID,
+			Source:     string(a.Source),
+			AssignedAt: a.AssignedAt.Format(time.RFC3339),
+		}
+		if a.UnassignedAt != nil {
+			unassignedAt := a.UnassignedAt.Format(time.RFC3339)
+			reason := string(a.UnassignReason)
+			result[i].UnassignedAt = &unassignedAt
+			result[i].UnassignReason = &reason
+		}
+	}
+
+	return AssignmentHistoryResponse{PullRequestID: prID, Assignments: result}
+}
+
+// PREventResponse represents an entry of the PR timeline
+type PREventResponse struct {
+	ID        int64          `json:"id"`
+	Type      string         `json:"type"`
+	Actor     *string        `json:"actor"` // null if unknown
+	Payload   map[string]any `json:"payload"`
+	CreatedAt string         `json:"created_at"`
+}
+
+// PREventsResponse represents response for GET /pullRequest/events
+type PREventsResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Events        []PREventResponse `json:"events"`
+}
+
+// PREventsFromDomain converts the timeline of the PR to PREventsResponse
+func PREventsFromDomain(prID string, events []domain.PREvent) PREventsResponse {
+	result := make([]PREventResponse, len(events))
+	for i, e := range events {
+		result[i] = PREventResponse{
+			ID:        e.ID,
+			Type:      string(e.Type),
+			Payload:   e.Payload,
+			CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		}
+		if e.Actor != "" {
+			actor := e.Actor
+			result[i].Actor = &actor
+		}
+		if result[i].Payload == nil {
+			result[i].Payload = map[string]any{}
+		}
+	}
+
+	return PREventsResponse{PullRequestID: prID, Events: result}
+}
+
+// OverdueReviewResponse represents a review whose reviewer exceeded the review SLA
+type OverdueReviewResponse struct {
+	PullRequestID    string  `json:"pull_request_id"`
+	PullRequestName  string  `json:"pull_request_name"`
+	ReviewerID       string  `json:"reviewer_id"`
+	TeamName         string  `json:"team_name"`
+	AssignedAt       string  `json:"assigned_at"`
+	WaitingHours     float64 `json:"waiting_hours"` // working hours since the assignment
+	ReviewSLAHours   int     `json:"review_sla_hours"`
+	SLAReassignHours int     `json:"sla_reassign_hours"`
+	OverdueAt        *string `json:"overdue_at"` // null until the SLA job marks the review
+}
+
+// OverdueReviewsResponse represents response for GET /pullRequest/overdue
+type OverdueReviewsResponse struct {
+	Reviews []OverdueReviewResponse `json:"reviews"`
+}
+
+// OverdueReviewsFromDomain converts overdue reviews to OverdueReviewsResponse
+func OverdueReviewsFromDomain(reviews []domain.OverdueReview) OverdueReviewsResponse {
+	result := make([]OverdueReviewResponse, len(reviews))
+	for i, r := range reviews {
+		result[i] = OverdueReviewResponse{
+			PullRequestID:    r.PRID,
+			PullRequestName:  r.PRName,
+			ReviewerID:       r.ReviewerID,
+			TeamName:         r.TeamName,
+			AssignedAt:       r.AssignedAt.Format(time.RFC3339),
+			WaitingHours:     math.Round(r.Waiting.Hours()*10) / 10,
+			ReviewSLAHours:   r.ReviewSLAHours,
+			SLAReassignHours: r.SLAReassignHours,
+		}
+		if r.OverdueAt != nil {
+			overdueAt := r.OverdueAt.Format(time.RFC3339)
+			result[i].OverdueAt = &overdueAt
+		}
+	}
+
+	return OverdueReviewsResponse{Reviews: result}
+}
+
+// OrphanedPRResponse represents an open or draft PR whose author's team was archived
+type OrphanedPRResponse struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+	Status          string `json:"status"`
+	ArchivedTeam    string `json:"archived_team"`
+}
+
+// OrphanedPRsResponse represents response for GET /pullRequest/orphaned
+type OrphanedPRsResponse struct {
+	PullRequests []OrphanedPRResponse `json:"pull_requests"`
+}
+
+// OrphanedPRsFromDomain converts orphaned PRs to OrphanedPRsResponse
+func OrphanedPRsFromDomain(prs []domain.OrphanedPR) OrphanedPRsResponse {
+	result := make([]OrphanedPRResponse, len(prs))
+	for i, pr := range prs {
+		result[i] = OrphanedPRResponse{
+			PullRequestID:   pr.PRID,
+			PullRequestName: pr.PRName,
+			AuthorID:        pr.AuthorID,
+			Status:          string(pr.Status),
+			ArchivedTeam:    pr.ArchivedTeam,
+		}
+	}
+
+	return OrphanedPRsResponse{PullRequests: result}
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// PreviewPRRequest represents request body for POST /pullRequest/preview.
+// Describes a hypothetical PR, nothing is created.
+type PreviewPRRequest struct {
+	AuthorID     string   `json:"author_id" binding:"required"`
+	Repository   string   `json:"repository"`
+	ChangedFiles []string `json:"changed_files" binding:"omitempty,dive,required"`
+	Labels       []string `json:"labels"`
+	// AssignedReviewers are treated as already assigned to the PR
+	AssignedReviewers []string `json:"assigned_reviewers" binding:"omitempty,dive,required"`
+}
+
+// ToDomain converts HTTP request to domain model
+func (r *PreviewPRRequest) ToDomain() domain.PullRequest {
+	return domain.PullRequest{
+		AuthorID:     r.AuthorID,
+		Repository:   r.Repository,
+		ChangedFiles: r.ChangedFiles,
+		Labels:       r.Labels,
+		ReviewersIDs: r.AssignedReviewers,
+	}
+}
+
+// PreviewCandidateResponse represents a user that can be assigned as a reviewer
+type PreviewCandidateResponse struct {
+	UserID         string   `json:"user_id"`
+	Source         string   `json:"source"`
+	OpenReviews    int      `json:"open_reviews"`
+	MaxOpenReviews int      `json:"max_open_reviews"` // 0 means no limit
+	Tags           []string `json:"tags"`
+}
+
+// ExcludedCandidateResponse represents a user that can't be assigned as a reviewer
+type ExcludedCandidateResponse struct {
+	UserID string `json:"user_id"`
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// AssignmentPreviewResponse represents response for POST /pullRequest/preview
+type AssignmentPreviewResponse struct {
+	TeamName           string                      `json:"team_name"`
+	AssignmentStrategy string                      `json:"assignment_strategy"`
+	MinReviewers       int                         `json:"min_reviewers"`
+	ReviewersToAssign  int                         `json:"reviewers_to_assign"`
+	Candidates         []PreviewCandidateResponse  `json:"candidates"`
+	Excluded           []ExcludedCandidateResponse `json:"excluded"`
+	SelectedReviewers  []PreviewCandidateResponse  `json:"selected_reviewers"`
+	// ReviewersShortfall is positive if PR creation would fail with NOT_ENOUGH_REVIEWERS
+	ReviewersShortfall int `json:"reviewers_shortfall"`
+}
+
+// AssignmentPreviewFromDomain converts domain.AssignmentPreview to AssignmentPreviewResponse
+func AssignmentPreviewFromDomain(preview *domain.AssignmentPreview) AssignmentPreviewResponse {
+	excluded := make([]ExcludedCandidateResponse, len(preview.Excluded))
+	for i, e := range preview.Excluded {
+		excluded[i] = ExcludedCandidateResponse{
+			UserID: e.UserID,
+			Source: string(e.Source),
+			Reason: string(e.Reason),
+		}
+	}
+
+	return AssignmentPreviewResponse{
+		TeamName:           preview.Team.Name,
+		AssignmentStrategy: string(preview.Team.AssignmentStrategy),
+		MinReviewers:       preview.Team.MinReviewers,
+		ReviewersToAssign:  preview.Amount,
+		Candidates:         previewCandidatesFromDomain(preview.Candidates),
+		Excluded:           excluded,
+		SelectedReviewers:  previewCandidatesFromDomain(preview.Reviewers),
+		ReviewersShortfall: preview.Shortfall,
+	}
+}
+
+// previewCandidatesFromDomain converts candidates to PreviewCandidateResponse slice
+func previewCandidatesFromDomain(candidates []domain.ReviewCandidate) []PreviewCandidateResponse {
+	result := make([]PreviewCandidateResponse, len(candidates))
+	for i, c := range candidates {
+		result[i] = PreviewCandidateResponse{
+			UserID:         c.UserID,
+			Source:         string(c.Source),
+			OpenReviews:    c.OpenReviews,
+			MaxOpenReviews: c.MaxOpenReviews,
+			Tags:           nonNilStrings(c.Tags),
+		}
+	}
+	return result
+}
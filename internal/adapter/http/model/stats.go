@@ -8,6 +8,8 @@ type StatsResponse struct {
 	TotalPRs   int                      `json:"total_prs"`
 	OpenPRs    int                      `json:"open_prs"`
 	MergedPRs  int                      `json:"merged_prs"`
+	DraftPRs   int                      `json:"draft_prs"`
+	ClosedPRs  int                      `json:"closed_prs"`
 	Reviewers  []domain.UserReviewStats `json:"top_reviewers"`
 }
 
@@ -18,6 +20,8 @@ func StatsFromDomain(stats *domain.Stats) StatsResponse {
 		TotalPRs:   stats.TotalPRs,
 		OpenPRs:    stats.OpenPRs,
 		MergedPRs:  stats.MergedPRs,
+		DraftPRs:   stats.DraftPRs,
+		ClosedPRs:  stats.ClosedPRs,
 		Reviewers:  stats.Reviewers,
 	}
 }
@@ -1,46 +1,180 @@
 package model
 
-import "github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+import (
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
 
 // CreateTeamRequest represents request body for POST /team/add
 type CreateTeamRequest struct {
-	TeamName string       `json:"team_name" binding:"required"`
-	Members  []TeamMember `json:"members" binding:"required,min=1"`
+	TeamName              string       `json:"team_name" binding:"required"`
+	Members               []TeamMember `json:"members" binding:"required,min=1,dive"`
+	AssignmentStrategy    string       `json:"assignment_strategy" binding:"omitempty,oneof=RANDOM ROUND_ROBIN LEAST_LOADED"`
+	MinReviewers          int          `json:"min_reviewers" binding:"min=0"`
+	MaxReviewers          int          `json:"max_reviewers" binding:"min=0"` // 0 means default
+	MinApprovals          int          `json:"min_approvals" binding:"min=0"`
+	AllowChangesRequested bool         `json:"allow_changes_requested"`
+	ReviewSLAHours        int          `json:"review_sla_hours" binding:"min=0"`   // 0 means no SLA
+	SLAReassignHours      int          `json:"sla_reassign_hours" binding:"min=0"` // 0 means overdue reviews are not reassigned
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id" binding:"required"`
-	Username string `json:"username" binding:"required"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id" binding:"required"`
+	Username       string   `json:"username" binding:"required"`
+	IsActive       bool     `json:"is_active"`
+	MaxOpenReviews int      `json:"max_open_reviews" binding:"min=0"` // 0 means no limit
+	Tags           []string `json:"tags"`
+}
+
+// membersToDomain converts team members of a request to domain users
+func membersToDomain(members []TeamMember) []domain.User {
+	users := make([]domain.User, len(members))
+	for i, m := range members {
+		users[i] = domain.User{
+			ID:             m.UserID,
+			Name:           m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Tags:           m.Tags,
+		}
+	}
+	return users
 }
 
 // ToDomain converts HTTP request to domain model
 func (r *CreateTeamRequest) ToDomain() domain.Team {
-	members := make([]domain.User, len(r.Members))
+	return domain.Team{
+		Name:                  r.TeamName,
+		AssignmentStrategy:    domain.AssignmentStrategy(r.AssignmentStrategy),
+		MinReviewers:          r.MinReviewers,
+		MaxReviewers:          r.MaxReviewers,
+		MinApprovals:          r.MinApprovals,
+		AllowChangesRequested: r.AllowChangesRequested,
+		ReviewSLAHours:        r.ReviewSLAHours,
+		SLAReassignHours:      r.SLAReassignHours,
+		Members:               membersToDomain(r.Members),
+	}
+}
+
+// AddTeamMembersRequest represents request body for POST /team/addMembers
+type AddTeamMembersRequest struct {
+	TeamName string       `json:"team_name" binding:"required"`
+	Members  []TeamMember `json:"members" binding:"required,min=1,dive"`
+}
+
+// MembersToDomain converts the added members to domain users
+func (r *AddTeamMembersRequest) MembersToDomain() []domain.User {
+	return membersToDomain(r.Members)
+}
+
+// RemoveTeamMemberRequest represents request body for POST /team/removeMember
+type RemoveTeamMemberRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	UserID   string `json:"user_id" binding:"required"`
+	// ReassignReviews requests handing over open reviews of the user, otherwise the user keeps them
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+// MoveTeamMemberRequest represents request body for POST /team/moveMember
+type MoveTeamMemberRequest struct {
+	UserID   string `json:"user_id" binding:"required"`
+	TeamName string `json:"team_name" binding:"required"` // team to move the user to
+	// ReassignReviews requests handing over open reviews of the user, otherwise the user keeps them
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+// SyncTeamRequest represents request body for PUT /team/sync
+type SyncTeamRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	// Members is the full desired roster, an empty list detaches all members
+	Members []SyncTeamMember `json:"members" binding:"required,dive"`
+	// ReassignReviews requests handing over open reviews of removed and deactivated members, otherwise they keep them
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+// SyncTeamMember is a roster entry of PUT /team/sync, omitted review capacity and tags keep the stored values
+type SyncTeamMember struct {
+	UserID         string   `json:"user_id" binding:"required"`
+	Username       string   `json:"username" binding:"required"`
+	IsActive       bool     `json:"is_active"`
+	MaxOpenReviews *int     `json:"max_open_reviews" binding:"omitempty,min=0"`
+	Tags           []string `json:"tags"`
+}
+
+// MembersToDomain converts the roster to domain roster members
+func (r *SyncTeamRequest) MembersToDomain() []domain.RosterMember {
+	members := make([]domain.RosterMember, len(r.Members))
 	for i, m := range r.Members {
-		members[i] = domain.User{
-			ID:       m.UserID,
-			Name:     m.Username,
-			IsActive: m.IsActive,
+		members[i] = domain.RosterMember{
+			ID:             m.UserID,
+			Name:           m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Tags:           m.Tags,
 		}
 	}
+	return members
+}
 
-	return domain.Team{
-		Name:    r.TeamName,
-		Members: members,
-	}
+// TeamNameRequest represents request body for POST /admin/archiveTeam and POST /admin/deleteTeam
+type TeamNameRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+}
+
+// SetAssignmentStrategyRequest represents request body for POST /team/setAssignmentStrategy
+type SetAssignmentStrategyRequest struct {
+	TeamName           string `json:"team_name" binding:"required"`
+	AssignmentStrategy string `json:"assignment_strategy" binding:"required,oneof=RANDOM ROUND_ROBIN LEAST_LOADED"`
+}
+
+// SetReviewersPolicyRequest represents request body for POST /team/setReviewersPolicy
+type SetReviewersPolicyRequest struct {
+	TeamName     string `json:"team_name" binding:"required"`
+	MinReviewers *int   `json:"min_reviewers" binding:"required,min=0"`
+	MaxReviewers int    `json:"max_reviewers" binding:"required,min=1"`
+}
+
+// SetMergePolicyRequest represents request body for POST /admin/setMergePolicy
+type SetMergePolicyRequest struct {
+	TeamName              string `json:"team_name" binding:"required"`
+	MinApprovals          *int   `json:"min_approvals" binding:"required,min=0"`
+	AllowChangesRequested bool   `json:"allow_changes_requested"`
+}
+
+// SetReviewSLARequest represents request body for POST /team/setReviewSLA
+type SetReviewSLARequest struct {
+	TeamName         string `json:"team_name" binding:"required"`
+	ReviewSLAHours   *int   `json:"review_sla_hours" binding:"required,min=0"`
+	SLAReassignHours int    `json:"sla_reassign_hours" binding:"min=0"`
+}
+
+// SetFallbackTeamsRequest represents request body for POST /team/setFallbackTeams
+type SetFallbackTeamsRequest struct {
+	TeamName      string   `json:"team_name" binding:"required"`
+	FallbackTeams []string `json:"fallback_teams" binding:"required,dive,required"`
 }
 
 // TeamResponse represents response for team endpoints
 type TeamResponse struct {
-	TeamName string               `json:"team_name"`
-	Members  []TeamMemberResponse `json:"members"`
+	TeamName              string               `json:"team_name"`
+	AssignmentStrategy    string               `json:"assignment_strategy"`
+	MinReviewers          int                  `json:"min_reviewers"`
+	MaxReviewers          int                  `json:"max_reviewers"`
+	MinApprovals          int                  `json:"min_approvals"`
+	AllowChangesRequested bool                 `json:"allow_changes_requested"`
+	ReviewSLAHours        int                  `json:"review_sla_hours"`
+	SLAReassignHours      int                  `json:"sla_reassign_hours"`
+	FallbackTeams         []string             `json:"fallback_teams"`
+	Members               []TeamMemberResponse `json:"members"`
 }
 
 type TeamMemberResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	MaxOpenReviews int      `json:"max_open_reviews"`
+	Tags           []string `json:"tags"`
 }
 
 // TeamFromDomain converts domain.Team to TeamResponse
@@ -48,15 +182,25 @@ func TeamFromDomain(team *domain.Team) TeamResponse {
 	members := make([]TeamMemberResponse, len(team.Members))
 	for i, m := range team.Members {
 		members[i] = TeamMemberResponse{
-			UserID:   m.ID,
-			Username: m.Name,
-			IsActive: m.IsActive,
+			UserID:         m.ID,
+			Username:       m.Name,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Tags:           nonNilStrings(m.Tags),
 		}
 	}
 
 	return TeamResponse{
-		TeamName: team.Name,
-		Members:  members,
+		TeamName:              team.Name,
+		AssignmentStrategy:    string(team.AssignmentStrategy),
+		MinReviewers:          team.MinReviewers,
+		MaxReviewers:          team.MaxReviewers,
+		MinApprovals:          team.MinApprovals,
+		AllowChangesRequested: team.AllowChangesRequested,
+		ReviewSLAHours:        team.ReviewSLAHours,
+		SLAReassignHours:      team.SLAReassignHours,
+		FallbackTeams:         nonNilStrings(team.FallbackTeams),
+		Members:               members,
 	}
 }
 
@@ -64,3 +208,66 @@ func TeamFromDomain(team *domain.Team) TeamResponse {
 type CreateTeamResponse struct {
 	Team TeamResponse `json:"team"`
 }
+
+// RemoveTeamMemberResponse represents response for POST /team/removeMember
+type RemoveTeamMemberResponse struct {
+	Team TeamResponse `json:"team"`
+	// Reassignment is present only if open reviews were requested to be handed over
+	Reassignment *ReassignmentResponse `json:"reassignment,omitempty"`
+}
+
+// MoveTeamMemberResponse represents response for POST /team/moveMember
+type MoveTeamMemberResponse struct {
+	User UserResponse `json:"user"`
+	// Reassignment is present only if open reviews were requested to be handed over
+	Reassignment *ReassignmentResponse `json:"reassignment,omitempty"`
+}
+
+// RosterDiffResponse represents changes made by a team roster sync
+type RosterDiffResponse struct {
+	TeamCreated bool     `json:"team_created"`
+	Created     []string `json:"created"`
+	Joined      []string `json:"joined"`
+	Updated     []string `json:"updated"`
+	Removed     []string `json:"removed"`
+}
+
+// RosterDiffFromDomain converts domain.RosterDiff to RosterDiffResponse
+func RosterDiffFromDomain(diff *domain.RosterDiff) RosterDiffResponse {
+	return RosterDiffResponse{
+		TeamCreated: diff.TeamCreated,
+		Created:     nonNilStrings(diff.Created),
+		Joined:      nonNilStrings(diff.Joined),
+		Updated:     nonNilStrings(diff.Updated),
+		Removed:     nonNilStrings(diff.Removed),
+	}
+}
+
+// SyncTeamResponse represents response for PUT /team/sync
+type SyncTeamResponse struct {
+	Team TeamResponse       `json:"team"`
+	Diff RosterDiffResponse `json:"diff"`
+	// Reassignment is present only if open reviews were requested to be handed over
+	Reassignment *ReassignmentResponse `json:"reassignment,omitempty"`
+}
+
+// TeamArchiveResponse represents response for POST /admin/archiveTeam
+type TeamArchiveResponse struct {
+	TeamName        string   `json:"team_name"`
+	ArchivedAt      string   `json:"archived_at"`
+	DetachedMembers []string `json:"detached_members"`
+	FlaggedPRs      []string `json:"flagged_pull_requests"`
+	// Reassignment reports open reviews of the former members, see ReassignmentFromDomain
+	Reassignment ReassignmentResponse `json:"reassignment"`
+}
+
+// TeamArchiveFromDomain converts domain.TeamArchive to TeamArchiveResponse
+func TeamArchiveFromDomain(archive *domain.TeamArchive) TeamArchiveResponse {
+	return TeamArchiveResponse{
+		TeamName:        archive.TeamName,
+		ArchivedAt:      archive.ArchivedAt.Format(time.RFC3339),
+		DetachedMembers: nonNilStrings(archive.DetachedMembers),
+		FlaggedPRs:      nonNilStrings(archive.FlaggedPRs),
+		Reassignment:    ReassignmentFromDomain(archive.Reassignment),
+	}
+}
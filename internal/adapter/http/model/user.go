@@ -6,6 +6,20 @@ import "github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id" binding:"required"`
 	IsActive *bool  `json:"is_active" binding:"required"`
+	// ReassignReviews requests handing over open reviews of a deactivated user, ignored on activation
+	ReassignReviews bool `json:"reassign_reviews"`
+}
+
+// SetMaxOpenReviewsRequest represents request body for POST /users/setMaxOpenReviews
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id" binding:"required"`
+	MaxOpenReviews *int   `json:"max_open_reviews" binding:"required,min=0"`
+}
+
+// SetTagsRequest represents request body for POST /users/setTags
+type SetTagsRequest struct {
+	UserID string   `json:"user_id" binding:"required"`
+	Tags   []string `json:"tags" binding:"required"` // empty list removes all tags
 }
 
 // UserResponse represents user object in responses
@@ -14,6 +28,9 @@ type UserResponse struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews is the review capacity of the user, 0 means no limit
+	MaxOpenReviews int      `json:"max_open_reviews"`
+	Tags           []string `json:"tags"`
 }
 
 // UserFromDomain converts domain.User to UserResponse
@@ -24,10 +41,64 @@ func UserFromDomain(user *domain.User) UserResponse {
 		Username: user.Name,
 		TeamName: user.TeamName,
 		IsActive: user.IsActive,
+
+		MaxOpenReviews: user.MaxOpenReviews,
+		Tags:           nonNilStrings(user.Tags),
 	}
 }
 
+// nonNilStrings makes nil slices render as empty JSON arrays
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 // SetIsActiveResponse represents response for POST /users/setIsActive
 type SetIsActiveResponse struct {
 	User UserResponse `json:"user"`
+	// Reassignment is present only if open reviews were requested to be handed over
+	Reassignment *ReassignmentResponse `json:"reassignment,omitempty"`
+}
+
+// ReviewReassignmentResponse represents a review handed over to another reviewer
+type ReviewReassignmentResponse struct {
+	PullRequestID string  `json:"pull_request_id"`
+	OldUserID     string  `json:"old_user_id"`
+	ReplacedBy    *string `json:"replaced_by"` // null if the reviewer was removed without replacement
+}
+
+// UnreassignedReviewResponse represents a review that could not be handed over
+type UnreassignedReviewResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"` // reviewer that stays assigned
+	Reason        string `json:"reason"`
+}
+
+// ReassignmentResponse represents a report of handing over open reviews of a user or of several users
+type ReassignmentResponse struct {
+	Reassigned    []ReviewReassignmentResponse `json:"reassigned"`
+	NotReassigned []UnreassignedReviewResponse `json:"not_reassigned"`
+}
+
+// ReassignmentFromDomain converts domain.ReassignmentReport to ReassignmentResponse
+func ReassignmentFromDomain(report *domain.ReassignmentReport) ReassignmentResponse {
+	resp := ReassignmentResponse{
+		Reassigned:    make([]ReviewReassignmentResponse, len(report.Reassigned)),
+		NotReassigned: make([]UnreassignedReviewResponse, len(report.NotReassigned)),
+	}
+
+	for i, r := range report.Reassigned {
+		resp.Reassigned[i] = ReviewReassignmentResponse{PullRequestID: r.PRID, OldUserID: r.OldReviewerID}
+		if r.NewReviewerID != "" {
+			replacedBy := r.NewReviewerID
+			resp.Reassigned[i].ReplacedBy = &replacedBy
+		}
+	}
+	for i, r := range report.NotReassigned {
+		resp.NotReassigned[i] = UnreassignedReviewResponse{PullRequestID: r.PRID, UserID: r.ReviewerID, Reason: r.Reason}
+	}
+
+	return resp
 }
@@ -10,15 +10,21 @@ func SetupRouter(
 	teamUC *usecase.TeamUseCase,
 	userUC *usecase.UserUseCase,
 	prUC *usecase.PRUseCase,
-	statsUC *usecase.StatsUseCase) *gin.Engine {
+	codeOwnersUC *usecase.CodeOwnersUseCase,
+	absenceUC *usecase.AbsenceUseCase,
+	statsUC *usecase.StatsUseCase,
+	adminToken string) *gin.Engine {
 
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(actorContext())
 
 	// Handlers
 	teamHandler := handler.NewTeamHandler(teamUC)
 	userHandler := handler.NewUserHandler(userUC)
 	prHandler := handler.NewPRHandler(prUC)
+	codeOwnersHandler := handler.NewCodeOwnersHandler(codeOwnersUC)
+	absenceHandler := handler.NewAbsenceHandler(absenceUC)
 
 	statsHandler := handler.NewStatsHandler(statsUC)
 
@@ -31,7 +37,12 @@ func SetupRouter(
 	user := router.Group("/users")
 	{
 		user.POST("/setIsActive", userHandler.SetIsActive)
+		user.POST("/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
+		user.POST("/setTags", userHandler.SetTags)
 		user.GET("/getReview", userHandler.GetReview)
+		user.POST("/addAbsence", absenceHandler.AddAbsence)
+		user.GET("/getAbsences", absenceHandler.GetAbsences)
+		user.POST("/removeAbsence", absenceHandler.RemoveAbsence)
 	}
 
 	// Team endpoints
@@ -39,6 +50,14 @@ func SetupRouter(
 	{
 		team.POST("/add", teamHandler.Add)
 		team.GET("/get", teamHandler.Get)
+		team.POST("/setAssignmentStrategy", teamHandler.SetAssignmentStrategy)
+		team.POST("/setReviewersPolicy", teamHandler.SetReviewersPolicy)
+		team.POST("/setFallbackTeams", teamHandler.SetFallbackTeams)
+		team.POST("/setReviewSLA", teamHandler.SetReviewSLA)
+		team.POST("/addMembers", teamHandler.AddMembers)
+		team.POST("/removeMember", teamHandler.RemoveMember)
+		team.POST("/moveMember", teamHandler.MoveMember)
+		team.PUT("/sync", teamHandler.Sync)
 	}
 
 	// Pull Request endpoints
@@ -46,7 +65,37 @@ func SetupRouter(
 	{
 		pr.POST("/create", prHandler.Create)
 		pr.POST("/merge", prHandler.Merge)
+		pr.POST("/markReady", prHandler.MarkReady)
+		pr.POST("/close", prHandler.Close)
+		pr.POST("/reopen", prHandler.Reopen)
 		pr.POST("/reassign", prHandler.Reassign)
+		pr.POST("/preview", prHandler.Preview)
+		pr.POST("/addReviewer", prHandler.AddReviewer)
+		pr.POST("/removeReviewer", prHandler.RemoveReviewer)
+		pr.POST("/submitReview", prHandler.SubmitReview)
+		pr.POST("/decline", prHandler.Decline)
+		pr.GET("/assignments", prHandler.Assignments)
+		pr.GET("/events", prHandler.Events)
+		pr.GET("/overdue", prHandler.Overdue)
+		pr.GET("/orphaned", prHandler.Orphaned)
+	}
+
+	// CODEOWNERS endpoints
+	codeOwners := router.Group("/codeOwners")
+	{
+		codeOwners.POST("/set", codeOwnersHandler.Set)
+		codeOwners.GET("/get", codeOwnersHandler.Get)
+	}
+
+	// Maintenance endpoints, available only with the admin token
+	admin := router.Group("/admin", adminAuth(adminToken))
+	{
+		admin.POST("/topUpReviewers", prHandler.TopUpReviewers)
+		admin.POST("/forceMerge", prHandler.ForceMerge)
+		admin.POST("/setMergePolicy", teamHandler.SetMergePolicy)
+		admin.GET("/forcedMerges", prHandler.GetForcedMerges)
+		admin.POST("/archiveTeam", teamHandler.Archive)
+		admin.POST("/deleteTeam", teamHandler.Delete)
 	}
 
 	router.GET("/stats", statsHandler.GetStats)
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupRouter_AdminEndpointsRequireToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// The requests are rejected before reaching the handlers, so no use cases are needed
+	router := SetupRouter(nil, nil, nil, nil, nil, nil, "secret")
+
+	paths := []string{
+		"/admin/topUpReviewers",
+		"/admin/forceMerge",
+		"/admin/setMergePolicy",
+		"/admin/archiveTeam",
+		"/admin/deleteTeam",
+	}
+	for _, path := range paths {
+		for _, header := range []string{"", "Bearer wrong", "secret"} {
+			t.Run(path+" "+header, func(t *testing.T) {
+				body := `{"team_name": "backend", "min_approvals": 0, "allow_changes_requested": true}`
+				req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				if header != "" {
+					req.Header.Set("Authorization", header)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusUnauthorized, w.Code)
+				assert.Contains(t, w.Body.String(), "UNAUTHORIZED")
+			})
+		}
+	}
+}
+
+func TestSetupRouter_MergePolicyIsNotPublic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := SetupRouter(nil, nil, nil, nil, nil, nil, "secret")
+
+	req := httptest.NewRequest(http.MethodPost, "/team/setMergePolicy", strings.NewReader(`{"team_name": "backend", "min_approvals": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes reminders to the log instead of delivering them, for local runs
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+// NewLogNotifier creates a new instance of LogNotifier
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs a line per PR in the reminder. It never fails.
+func (n *LogNotifier) Notify(_ context.Context, reminder domain.ReviewReminder) error {
+	for _, item := range reminder.Items {
+		n.logger.Info("review reminder",
+			zap.String("reviewer_id", reminder.ReviewerID),
+			zap.String("pr_id", item.PR.ID),
+			zap.String("pr_name", item.PR.Name),
+			zap.String("author_id", item.PR.AuthorID),
+			zap.Time("assigned_at", item.AssignedAt),
+			zap.Int("reminder", item.Reminder))
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogNotifier_Notify(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	n := NewLogNotifier(zap.New(core))
+	assignedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := n.Notify(context.Background(), domain.ReviewReminder{
+		ReviewerID: "u2",
+		Items: []domain.ReminderItem{
+			{PR: &domain.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u1"}, AssignedAt: assignedAt, Reminder: 1},
+			{PR: &domain.PullRequest{ID: "pr-2", Name: "Feature", AuthorID: "u3"}, AssignedAt: assignedAt, Reminder: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("review reminder").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"reviewer_id": "u2",
+		"pr_id":       "pr-2",
+		"pr_name":     "Feature",
+		"author_id":   "u3",
+		"assigned_at": assignedAt,
+		"reminder":    int64(3),
+	}, entries[1].ContextMap())
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+// AbsenceRepository handles database operations for out-of-office periods of users
+type AbsenceRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewAbsenceRepository creates a new instance of AbsenceRepository
+func NewAbsenceRepository(db *sql.DB, logger *zap.Logger) *AbsenceRepository {
+	return &AbsenceRepository{db: db, logger: logger}
+}
+
+// Create inserts a new absence. Sets ID of the given object.
+func (a *AbsenceRepository) Create(ctx context.Context, tx *sql.Tx, absence *domain.Absence) error {
+	query := `
+			INSERT INTO user_absences (user_id, starts_at, ends_at, reassign_reviews)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`
+
+	err := tx.QueryRowContext(ctx, query, absence.UserID, absence.StartsAt, absence.EndsAt, absence.ReassignReviews).
+		Scan(&absence.ID)
+	if err != nil {
+		a.logger.Error("DB error on Absence insert",
+			zap.Error(err),
+			zap.String("user_id", absence.UserID))
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the absence of the user.
+// Returns ErrNotFound if the user has no absence with such ID.
+func (a *AbsenceRepository) Delete(ctx context.Context, tx *sql.Tx, userID string, absenceID int64) error {
+	query := `
+			DELETE FROM user_absences
+			WHERE id = $1 AND user_id = $2`
+
+	res, err := tx.ExecContext(ctx, query, absenceID, userID)
+	if err != nil {
+		a.logger.Error("DB error on Absence delete",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.Int64("absence_id", absenceID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByUser returns current and upcoming absences of the user ordered by their start.
+// Absences that have already ended are skipped.
+func (a *AbsenceRepository) GetByUser(ctx context.Context, userID string) ([]domain.Absence, error) {
+	query := `
+			SELECT id, user_id, starts_at, ends_at, reassign_reviews, reassigned_at
+			FROM user_absences
+			WHERE user_id = $1
+				AND ends_at > NOW()
+			ORDER BY starts_at, id`
+
+	rows, err := a.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		a.logger.Error("DB error on Absences select",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanAbsences(rows)
+}
+
+// GetStartedToReassign returns absences covering the given moment that request
+// reassignment of open reviews which has not been done yet.
+func (a *AbsenceRepository) GetStartedToReassign(ctx context.Context, now time.Time) ([]domain.Absence, error) {
+	query := `
+			SELECT id, user_id, starts_at, ends_at, reassign_reviews, reassigned_at
+			FROM user_absences
+			WHERE reassign_reviews = true
+				AND reassigned_at IS NULL
+				AND starts_at <= $1
+				AND ends_at > $1
+			ORDER BY starts_at, id`
+
+	rows, err := a.db.QueryContext(ctx, query, now)
+	if err != nil {
+		a.logger.Error("DB error on started Absences select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanAbsences(rows)
+}
+
+// MarkReassigned records that open reviews of the absent user were handed over.
+// Returns ErrNotFound if the absence doesn't exist.
+func (a *AbsenceRepository) MarkReassigned(ctx context.Context, tx *sql.Tx, absenceID int64, reassignedAt time.Time) error {
+	query := `
+			UPDATE user_absences
+			SET reassigned_at = $1
+			WHERE id = $2`
+
+	res, err := tx.ExecContext(ctx, query, reassignedAt, absenceID)
+	if err != nil {
+		a.logger.Error("DB error on Absence update",
+			zap.Error(err),
+			zap.Int64("absence_id", absenceID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// scanAbsences reads absences selected as (id, user_id, starts_at, ends_at, reassign_reviews, reassigned_at)
+func scanAbsences(rows *sql.Rows) ([]domain.Absence, error) {
+	var absences []domain.Absence
+	for rows.Next() {
+		var absence domain.Absence
+		var reassignedAt sql.NullTime
+		err := rows.Scan(&absence.ID, &absence.UserID, &absence.StartsAt, &absence.EndsAt,
+			&absence.ReassignReviews, &reassignedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if reassignedAt.Valid {
+			absence.ReassignedAt = &reassignedAt.Time
+		}
+		absences = append(absences, absence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return absences, nil
+}
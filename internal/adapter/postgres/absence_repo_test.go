@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var absenceColumns = []string{"id", "user_id", "starts_at", "ends_at", "reassign_reviews", "reassigned_at"}
+
+func TestAbsenceRepository_Create(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &AbsenceRepository{db: db, logger: zap.NewNop()}
+
+	absence := &domain.Absence{
+		UserID:          "u1",
+		StartsAt:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:          time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		ReassignReviews: true,
+	}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`INSERT INTO user_absences \(user_id, starts_at, ends_at, reassign_reviews\)`).
+		WithArgs(absence.UserID, absence.StartsAt, absence.EndsAt, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	err := repo.Create(context.Background(), tx, absence)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), absence.ID)
+
+	// Case with error
+	mock.ExpectQuery(`INSERT INTO user_absences`).
+		WithArgs(absence.UserID, absence.StartsAt, absence.EndsAt, true).
+		WillReturnError(errors.New("db error"))
+
+	err = repo.Create(context.Background(), tx, absence)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAbsenceRepository_Delete(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &AbsenceRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`DELETE FROM user_absences WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(int64(7), "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.Delete(context.Background(), tx, "u1", 7)
+	require.NoError(t, err)
+
+	// Absence of another user or unknown absence
+	mock.ExpectExec(`DELETE FROM user_absences`).
+		WithArgs(int64(7), "u2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.Delete(context.Background(), tx, "u2", 7)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAbsenceRepository_GetByUser(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &AbsenceRepository{db: db, logger: zap.NewNop()}
+
+	startsAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	reassignedAt := time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, user_id, starts_at, ends_at, reassign_reviews, reassigned_at FROM user_absences WHERE user_id = \$1 AND ends_at > NOW\(\)`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(absenceColumns).
+			AddRow(1, "u1", startsAt, endsAt, true, reassignedAt).
+			AddRow(2, "u1", endsAt, endsAt.Add(24*time.Hour), false, nil))
+
+	absences, err := repo.GetByUser(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Absence{
+		{ID: 1, UserID: "u1", StartsAt: startsAt, EndsAt: endsAt, ReassignReviews: true, ReassignedAt: &reassignedAt},
+		{ID: 2, UserID: "u1", StartsAt: endsAt, EndsAt: endsAt.Add(24 * time.Hour)},
+	}, absences)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM user_absences`).
+		WithArgs("u1").
+		WillReturnError(errors.New("qfail"))
+
+	absences, err = repo.GetByUser(context.Background(), "u1")
+	assert.Error(t, err)
+	assert.Nil(t, absences)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAbsenceRepository_GetStartedToReassign(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &AbsenceRepository{db: db, logger: zap.NewNop()}
+
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	startsAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM user_absences WHERE reassign_reviews = true AND reassigned_at IS NULL AND starts_at <= \$1 AND ends_at > \$1`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows(absenceColumns).AddRow(1, "u1", startsAt, endsAt, true, nil))
+
+	absences, err := repo.GetStartedToReassign(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Absence{
+		{ID: 1, UserID: "u1", StartsAt: startsAt, EndsAt: endsAt, ReassignReviews: true},
+	}, absences)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAbsenceRepository_MarkReassigned(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &AbsenceRepository{db: db, logger: zap.NewNop()}
+
+	reassignedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`UPDATE user_absences SET reassigned_at = \$1 WHERE id = \$2`).
+		WithArgs(reassignedAt, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.MarkReassigned(context.Background(), tx, 1, reassignedAt)
+	require.NoError(t, err)
+
+	// Deleted meanwhile
+	mock.ExpectExec(`UPDATE user_absences`).
+		WithArgs(reassignedAt, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.MarkReassigned(context.Background(), tx, 2, reassignedAt)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
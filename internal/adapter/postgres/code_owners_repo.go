@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+// CodeOwnersRepository handles database operations for CODEOWNERS files of repositories
+type CodeOwnersRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewCodeOwnersRepository creates a new instance of CodeOwnersRepository
+func NewCodeOwnersRepository(db *sql.DB, logger *zap.Logger) *CodeOwnersRepository {
+	return &CodeOwnersRepository{db: db, logger: logger}
+}
+
+// Upsert stores the CODEOWNERS file of the repository, replacing the previous one.
+// Sets UpdatedAt of the given object.
+func (c *CodeOwnersRepository) Upsert(ctx context.Context, tx *sql.Tx, codeOwners *domain.CodeOwners) error {
+	query := `
+			INSERT INTO code_owners (repository, content, updated_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (repository) DO UPDATE
+			SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+			RETURNING updated_at`
+
+	err := tx.QueryRowContext(ctx, query, codeOwners.Repository, codeOwners.Content).Scan(&codeOwners.UpdatedAt)
+	if err != nil {
+		c.logger.Error("DB error on CodeOwners upsert",
+			zap.Error(err),
+			zap.String("repository", codeOwners.Repository))
+		return err
+	}
+
+	return nil
+}
+
+// GetByRepository retrieves the CODEOWNERS file of the repository. Rules are not parsed.
+// Returns ErrNotFound if the repository has no CODEOWNERS.
+func (c *CodeOwnersRepository) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	query := `
+			SELECT repository, content, updated_at
+			FROM code_owners
+			WHERE repository = $1`
+
+	var codeOwners domain.CodeOwners
+	err := c.db.QueryRowContext(ctx, query, repository).Scan(&codeOwners.Repository, &codeOwners.Content, &codeOwners.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		c.logger.Error("DB error on CodeOwners select",
+			zap.Error(err),
+			zap.String("repository", repository))
+		return nil, err
+	}
+
+	return &codeOwners, nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCodeOwnersRepository_Upsert(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &CodeOwnersRepository{db: db, logger: zap.NewNop()}
+
+	codeOwners := &domain.CodeOwners{Repository: "avito/service", Content: "*.go @u1\n"}
+	updatedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`INSERT INTO code_owners .* ON CONFLICT \(repository\) DO UPDATE`).
+		WithArgs(codeOwners.Repository, codeOwners.Content).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	err := repo.Upsert(context.Background(), tx, codeOwners)
+	require.NoError(t, err)
+	assert.Equal(t, updatedAt, codeOwners.UpdatedAt)
+
+	// Case with error
+	mock.ExpectQuery(`INSERT INTO code_owners`).
+		WithArgs(codeOwners.Repository, codeOwners.Content).
+		WillReturnError(errors.New("db error"))
+
+	err = repo.Upsert(context.Background(), tx, codeOwners)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCodeOwnersRepository_GetByRepository(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &CodeOwnersRepository{db: db, logger: zap.NewNop()}
+
+	updatedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT repository, content, updated_at FROM code_owners`).
+		WithArgs("avito/service").
+		WillReturnRows(sqlmock.NewRows([]string{"repository", "content", "updated_at"}).
+			AddRow("avito/service", "*.go @u1\n", updatedAt))
+
+	codeOwners, err := repo.GetByRepository(context.Background(), "avito/service")
+	require.NoError(t, err)
+	assert.Equal(t, "*.go @u1\n", codeOwners.Content)
+	assert.Equal(t, updatedAt, codeOwners.UpdatedAt)
+
+	// Not found
+	mock.ExpectQuery(`SELECT repository, content, updated_at FROM code_owners`).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"repository", "content", "updated_at"}))
+
+	codeOwners, err = repo.GetByRepository(context.Background(), "unknown")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, codeOwners)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -23,6 +23,9 @@ type IntegrationTestSuite struct {
 	userRepo *UserRepository
 	teamRepo *TeamRepository
 	prRepo   *PullRequestRepository
+
+	codeOwnersRepo *CodeOwnersRepository
+	absenceRepo    *AbsenceRepository
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
@@ -46,6 +49,8 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	s.teamRepo = NewTeamRepository(db, logger)
 	s.userRepo = NewUserRepository(db, logger)
 	s.prRepo = NewPullRequestRepository(db, logger)
+	s.codeOwnersRepo = NewCodeOwnersRepository(db, logger)
+	s.absenceRepo = NewAbsenceRepository(db, logger)
 }
 
 func (s *IntegrationTestSuite) TearDownSuite() {
@@ -63,7 +68,7 @@ func (s *IntegrationTestSuite) TearDownTest() {
 }
 
 func (s *IntegrationTestSuite) cleanupTables() {
-	tables := []string{"pr_reviewers", "pull_requests", "users", "teams"}
+	tables := []string{"pr_reviewers", "pull_requests", "user_absences", "users", "team_fallbacks", "teams", "code_owners", "forced_merges", "review_declines", "pr_events"}
 	for _, table := range tables {
 		_, err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
 		require.NoError(s.T(), err)
@@ -72,7 +77,7 @@ func (s *IntegrationTestSuite) cleanupTables() {
 
 // ==== TeamRepository tests ====
 func (s *IntegrationTestSuite) TestTeamCreate_Success() {
-	team := &domain.Team{Name: "backend-team"}
+	team := &domain.Team{Name: "backend-team", MaxReviewers: domain.DefaultMaxReviewers}
 
 	tx, _ := s.db.Begin()
 	err := s.teamRepo.Create(context.Background(), tx, team)
@@ -83,8 +88,8 @@ func (s *IntegrationTestSuite) TestTeamCreate_Success() {
 }
 
 func (s *IntegrationTestSuite) TestTeamCreate_DuplicateName() {
-	team1 := &domain.Team{Name: "backend-team"}
-	team2 := &domain.Team{Name: "backend-team"}
+	team1 := &domain.Team{Name: "backend-team", MaxReviewers: domain.DefaultMaxReviewers}
+	team2 := &domain.Team{Name: "backend-team", MaxReviewers: domain.DefaultMaxReviewers}
 
 	tx, _ := s.db.Begin()
 
@@ -99,7 +104,7 @@ func (s *IntegrationTestSuite) TestTeamCreate_DuplicateName() {
 
 func (s *IntegrationTestSuite) TestTeamGetByName_Found() {
 	// Create team and users
-	team := &domain.Team{Name: "frontend-team"}
+	team := &domain.Team{Name: "frontend-team", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	err := s.teamRepo.Create(context.Background(), tx, team)
 	require.NoError(s.T(), err)
@@ -126,7 +131,7 @@ func (s *IntegrationTestSuite) TestTeamGetByName_NotFound() {
 }
 
 func (s *IntegrationTestSuite) TestTeamGetByName_EmptyMembers() {
-	team := &domain.Team{Name: "empty-team"}
+	team := &domain.Team{Name: "empty-team", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 	require.NoError(s.T(), tx.Commit())
@@ -137,9 +142,208 @@ func (s *IntegrationTestSuite) TestTeamGetByName_EmptyMembers() {
 	assert.Empty(s.T(), result.Members)
 }
 
+func (s *IntegrationTestSuite) TestTeamUpdate_ReviewersPolicy() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "platform", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	require.NoError(s.T(), tx.Commit())
+
+	team.MinReviewers, team.MaxReviewers = 3, 3
+	tx, _ = s.db.Begin()
+	err := s.teamRepo.Update(ctx, tx, team)
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
+
+	result, err := s.teamRepo.GetByID(ctx, team.ID)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, result.MinReviewers)
+	assert.Equal(s.T(), 3, result.MaxReviewers)
+}
+
+func (s *IntegrationTestSuite) TestTeamUpdate_MergePolicy() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "payments", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers, MinApprovals: 1}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	require.NoError(s.T(), tx.Commit())
+
+	result, err := s.teamRepo.GetByName(ctx, "payments")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, result.MinApprovals)
+	assert.False(s.T(), result.AllowChangesRequested)
+
+	team.MinApprovals, team.AllowChangesRequested = 2, true
+	tx, _ = s.db.Begin()
+	err = s.teamRepo.Update(ctx, tx, team)
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
+
+	result, err = s.teamRepo.GetByID(ctx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, result.MinApprovals)
+	assert.True(s.T(), result.AllowChangesRequested)
+}
+
+func (s *IntegrationTestSuite) TestTeamSetFallbackTeams() {
+	ctx := context.Background()
+	docs := &domain.Team{Name: "docs", MaxReviewers: domain.DefaultMaxReviewers}
+	backend := &domain.Team{Name: "backend", MaxReviewers: domain.DefaultMaxReviewers}
+	frontend := &domain.Team{Name: "frontend", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, docs)
+	s.teamRepo.Create(ctx, tx, backend)
+	s.teamRepo.Create(ctx, tx, frontend)
+	require.NoError(s.T(), tx.Commit())
+
+	tx, _ = s.db.Begin()
+	err := s.teamRepo.SetFallbackTeams(ctx, tx, docs.ID, []int64{frontend.ID, backend.ID})
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
+
+	result, err := s.teamRepo.GetByName(ctx, "docs")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"frontend", "backend"}, result.FallbackTeams)
+
+	// replacing the list drops previous entries
+	tx, _ = s.db.Begin()
+	err = s.teamRepo.SetFallbackTeams(ctx, tx, docs.ID, []int64{backend.ID})
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
+
+	fallbacks, err := s.teamRepo.GetFallbackTeams(ctx, docs.ID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), fallbacks, 1)
+	assert.Equal(s.T(), backend.ID, fallbacks[0].ID)
+}
+
 // ==== UserRepository tests ====
+func (s *IntegrationTestSuite) TestTeamArchiveAndDelete() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-archive", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+
+	// pr-1 and pr-3 are still open, pr-2 is merged
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-1", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-2", Name: "PR", AuthorID: "u1", Status: domain.StatusMerged},
+		{ID: "pr-3", Name: "PR", AuthorID: "u1", Status: domain.StatusDraft},
+	} {
+		s.prRepo.Create(ctx, tx, pr)
+	}
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u2", domain.SourceTeam)
+	require.NoError(s.T(), tx.Commit())
+
+	tx, _ = s.db.Begin()
+	locked, err := s.teamRepo.GetByNameForUpdate(ctx, tx, "team-archive")
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), locked.ArchivedAt)
+
+	_, err = s.teamRepo.Archive(ctx, tx, team.ID)
+	require.NoError(s.T(), err)
+	flagged, err := s.prRepo.FlagTeamArchived(ctx, tx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"pr-1", "pr-3"}, flagged)
+	require.NoError(s.T(), s.prRepo.FlagTeamReviewsArchived(ctx, tx, team.ID))
+	detached, err := s.userRepo.DetachTeamMembers(ctx, tx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"u1", "u2"}, detached)
+
+	// Archiving twice is refused
+	_, err = s.teamRepo.Archive(ctx, tx, team.ID)
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	require.NoError(s.T(), tx.Commit())
+
+	// The team is hidden, its members are kept without a team and inactive;
+	// the flagged PRs still find it by ID for its merge policy
+	_, err = s.teamRepo.GetByName(ctx, "team-archive")
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	archived, err := s.teamRepo.GetByID(ctx, team.ID)
+	require.NoError(s.T(), err)
+	assert.NotNil(s.T(), archived.ArchivedAt)
+	pr, err := s.prRepo.GetByID(ctx, "pr-1")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), team.ID, pr.ArchivedTeamID)
+
+	user, err := s.userRepo.GetByID(ctx, "u1")
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), user.TeamID)
+	assert.False(s.T(), user.IsActive)
+
+	orphaned, err := s.prRepo.GetOrphanedPRs(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), orphaned, 2)
+	assert.Equal(s.T(), "team-archive", orphaned[0].ArchivedTeam)
+
+	// The review of pr-1 still references the team
+	tx, _ = s.db.Begin()
+	count, err := s.prRepo.CountOpenReviewsByTeam(ctx, tx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+
+	require.NoError(s.T(), s.prRepo.RemoveReviewer(ctx, tx, "pr-1", "u2", domain.UnassignManual))
+	count, err = s.prRepo.CountOpenReviewsByTeam(ctx, tx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), count)
+
+	require.NoError(s.T(), s.teamRepo.Delete(ctx, tx, team.ID))
+	require.NoError(s.T(), tx.Commit())
+
+	// Deleting the team keeps the users and releases the PRs
+	_, err = s.userRepo.GetByID(ctx, "u2")
+	assert.NoError(s.T(), err)
+
+	orphaned, err = s.prRepo.GetOrphanedPRs(ctx)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), orphaned)
+}
+
+func (s *IntegrationTestSuite) TestTeamArchiveAndDelete_FormerMemberReview() {
+	ctx := context.Background()
+	archived := &domain.Team{Name: "team-archive-reviewer", MaxReviewers: domain.DefaultMaxReviewers}
+	other := &domain.Team{Name: "team-archive-other", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, archived)
+	s.teamRepo.Create(ctx, tx, other)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: archived.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: other.ID})
+
+	// u1 reviews a PR of the other team
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-1", Name: "PR", AuthorID: "u2", Status: domain.StatusOpen})
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u1", domain.SourceFallback)
+	require.NoError(s.T(), tx.Commit())
+
+	tx, _ = s.db.Begin()
+	_, err := s.teamRepo.Archive(ctx, tx, archived.ID)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.prRepo.FlagTeamReviewsArchived(ctx, tx, archived.ID))
+	_, err = s.userRepo.DetachTeamMembers(ctx, tx, archived.ID)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), tx.Commit())
+
+	// The review of the former member still references the archived team, not the other one
+	tx, _ = s.db.Begin()
+	count, err := s.prRepo.CountOpenReviewsByTeam(ctx, tx, archived.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+
+	// A new assignment of the former member doesn't reference the team
+	require.NoError(s.T(), s.prRepo.RemoveReviewer(ctx, tx, "pr-1", "u1", domain.UnassignManual))
+	require.NoError(s.T(), s.prRepo.AddReviewer(ctx, tx, "pr-1", "u1", domain.SourceManual))
+	count, err = s.prRepo.CountOpenReviewsByTeam(ctx, tx, archived.ID)
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), count)
+
+	require.NoError(s.T(), s.teamRepo.Delete(ctx, tx, archived.ID))
+	require.NoError(s.T(), tx.Commit())
+}
+
 func (s *IntegrationTestSuite) TestUserCreate_Success() {
-	team := &domain.Team{Name: "team-1"}
+	team := &domain.Team{Name: "team-1", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -171,7 +375,7 @@ func (s *IntegrationTestSuite) TestUserCreate_InvalidTeamID() {
 }
 
 func (s *IntegrationTestSuite) TestUserGetByID_Found() {
-	team := &domain.Team{Name: "team-2"}
+	team := &domain.Team{Name: "team-2", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -184,6 +388,7 @@ func (s *IntegrationTestSuite) TestUserGetByID_Found() {
 	require.NoError(s.T(), err)
 	assert.Equal(s.T(), "Diana", result.Name)
 	assert.Equal(s.T(), team.ID, result.TeamID)
+	assert.Empty(s.T(), result.Tags)
 }
 
 func (s *IntegrationTestSuite) TestUserGetByID_NotFound() {
@@ -192,7 +397,7 @@ func (s *IntegrationTestSuite) TestUserGetByID_NotFound() {
 }
 
 func (s *IntegrationTestSuite) TestUserUpdate_Success() {
-	team := &domain.Team{Name: "team-3"}
+	team := &domain.Team{Name: "team-3", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -202,6 +407,7 @@ func (s *IntegrationTestSuite) TestUserUpdate_Success() {
 	// update
 	user.Name = "Eve Updated"
 	user.IsActive = false
+	user.Tags = []string{"go", "sql"}
 
 	err := s.userRepo.Update(context.Background(), tx, user)
 	require.NoError(s.T(), tx.Commit())
@@ -211,36 +417,249 @@ func (s *IntegrationTestSuite) TestUserUpdate_Success() {
 	updated, _ := s.userRepo.GetByID(context.Background(), "user-20")
 	assert.Equal(s.T(), "Eve Updated", updated.Name)
 	assert.False(s.T(), updated.IsActive)
+	assert.Equal(s.T(), []string{"go", "sql"}, updated.Tags)
 }
 
-func (s *IntegrationTestSuite) TestUserGetActiveTeamMembers_FilterCorrectly() {
-	team := &domain.Team{Name: "team-4"}
+func (s *IntegrationTestSuite) TestUserUpdate_RemoveFromTeam() {
+	team := &domain.Team{Name: "team-leave", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
-	// Create users
-	s.userRepo.Create(context.Background(), tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
-	s.userRepo.Create(context.Background(), tx, &domain.User{ID: "u2", Name: "B", IsActive: false, TeamID: team.ID})
-	s.userRepo.Create(context.Background(), tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID})
-	s.userRepo.Create(context.Background(), tx, &domain.User{ID: "u4", Name: "D", IsActive: true, TeamID: team.ID})
+	user := &domain.User{ID: "user-21", Name: "Frank", IsActive: true, TeamID: team.ID}
+	s.userRepo.Create(context.Background(), tx, user)
+
+	user.TeamID = 0
+	err := s.userRepo.Update(context.Background(), tx, user)
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
+
+	// the user is kept without a team
+	updated, err := s.userRepo.GetByID(context.Background(), "user-21")
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), updated.TeamID)
+
+	candidates, err := s.userRepo.GetReviewCandidates(context.Background(), team.ID, "")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), candidates)
+}
+
+func (s *IntegrationTestSuite) TestUserGetReviewCandidates_CountsOpenReviews() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-load", AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID, MaxOpenReviews: 2, Tags: []string{"sql"}})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u4", Name: "D", IsActive: false, TeamID: team.ID})
+
+	// u2 reviews two open PRs and one merged, u3 reviews nothing
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-l1", Name: "L1", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-l2", Name: "L2", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-l3", Name: "L3", AuthorID: "u1", Status: domain.StatusMerged},
+	} {
+		s.prRepo.Create(ctx, tx, pr)
+		s.prRepo.AddReviewer(ctx, tx, pr.ID, "u2", domain.SourceTeam)
+	}
+	require.NoError(s.T(), tx.Commit())
+
+	candidates, err := s.userRepo.GetReviewCandidates(ctx, team.ID, "u1")
+
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), []domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 2, MaxOpenReviews: 2, Tags: []string{"sql"}},
+		{UserID: "u3", OpenReviews: 0, Tags: []string{}},
+	}, candidates) // u1 excluded, u4 inactive
+}
+
+func (s *IntegrationTestSuite) TestUserGetReviewCandidatesByIDs() {
+	ctx := context.Background()
+	team1 := &domain.Team{Name: "team-a", MaxReviewers: domain.DefaultMaxReviewers}
+	team2 := &domain.Team{Name: "team-b", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team1)
+	s.teamRepo.Create(ctx, tx, team2)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team1.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team2.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: false, TeamID: team2.ID})
+	require.NoError(s.T(), tx.Commit())
+
+	candidates, err := s.userRepo.GetReviewCandidatesByIDs(ctx, []string{"u1", "u2", "u3", "unknown"}, "u1")
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.ReviewCandidate{{UserID: "u2", Tags: []string{}}}, candidates) // u1 excluded, u3 inactive
+}
+
+func (s *IntegrationTestSuite) TestUserGetReviewCandidates_SkipsAbsentUsers() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-absent", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u4", Name: "D", IsActive: true, TeamID: team.ID})
+
+	// u2 is absent now, u3 will be absent tomorrow, u4 was absent yesterday
+	now := time.Now()
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)})
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u3", StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(48 * time.Hour)})
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u4", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-24 * time.Hour)})
+	require.NoError(s.T(), tx.Commit())
+
+	candidates, err := s.userRepo.GetReviewCandidates(ctx, team.ID, "u1")
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), []domain.ReviewCandidate{
+		{UserID: "u3", Tags: []string{}},
+		{UserID: "u4", Tags: []string{}},
+	}, candidates)
+
+	candidates, err = s.userRepo.GetReviewCandidatesByIDs(ctx, []string{"u2", "u3"}, "u1")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.ReviewCandidate{{UserID: "u3", Tags: []string{}}}, candidates)
+}
+
+func (s *IntegrationTestSuite) TestUserGetTeamReviewPool() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-pool", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: false, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID, MaxOpenReviews: 1})
+
+	now := time.Now()
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)})
+
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-1", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen})
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u3", domain.SourceTeam)
+	require.NoError(s.T(), tx.Commit())
+
+	// Unavailable users are returned too, with the reason
+	members, err := s.userRepo.GetTeamReviewPool(ctx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u1", Tags: []string{}}, IsActive: true, Absent: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u2", Tags: []string{}}},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u3", OpenReviews: 1, MaxOpenReviews: 1, Tags: []string{}}, IsActive: true},
+	}, members)
+
+	members, err = s.userRepo.GetReviewPoolByIDs(ctx, []string{"u2", "unknown"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u2", Tags: []string{}}},
+	}, members)
+}
+
+// ==== AbsenceRepository tests ====
+func (s *IntegrationTestSuite) TestAbsenceCreateGetDelete() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-vacation", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	current := &domain.Absence{UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), ReassignReviews: true}
+	upcoming := &domain.Absence{UserID: "u1", StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(48 * time.Hour)}
+	ended := &domain.Absence{UserID: "u1", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-24 * time.Hour)}
+	for _, a := range []*domain.Absence{upcoming, current, ended} {
+		require.NoError(s.T(), s.absenceRepo.Create(ctx, tx, a))
+		assert.NotZero(s.T(), a.ID)
+	}
+	require.NoError(s.T(), tx.Commit())
+
+	// ended absences are skipped, the rest are ordered by start
+	absences, err := s.absenceRepo.GetByUser(ctx, "u1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), absences, 2)
+	assert.Equal(s.T(), current.ID, absences[0].ID)
+	assert.True(s.T(), absences[0].StartsAt.Equal(current.StartsAt))
+	assert.True(s.T(), absences[0].ReassignReviews)
+	assert.Equal(s.T(), upcoming.ID, absences[1].ID)
+
+	// absence of another user can't be deleted
+	tx, _ = s.db.Begin()
+	err = s.absenceRepo.Delete(ctx, tx, "u2", current.ID)
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	require.NoError(s.T(), s.absenceRepo.Delete(ctx, tx, "u1", current.ID))
+	require.NoError(s.T(), tx.Commit())
+
+	absences, err = s.absenceRepo.GetByUser(ctx, "u1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), absences, 1)
+	assert.Equal(s.T(), upcoming.ID, absences[0].ID)
+}
+
+func (s *IntegrationTestSuite) TestAbsenceGetStartedToReassign() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-reassign", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+
+	now := time.Now()
+	started := &domain.Absence{UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), ReassignReviews: true}
+	s.absenceRepo.Create(ctx, tx, started)
+	// no reassignment requested
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)})
+	// not started yet
+	s.absenceRepo.Create(ctx, tx, &domain.Absence{UserID: "u2", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), ReassignReviews: true})
+	require.NoError(s.T(), tx.Commit())
+
+	absences, err := s.absenceRepo.GetStartedToReassign(ctx, now)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), absences, 1)
+	assert.Equal(s.T(), started.ID, absences[0].ID)
+	assert.Nil(s.T(), absences[0].ReassignedAt)
+
+	// processed absences are not returned again
+	tx, _ = s.db.Begin()
+	require.NoError(s.T(), s.absenceRepo.MarkReassigned(ctx, tx, started.ID, now))
+	require.NoError(s.T(), tx.Commit())
+
+	absences, err = s.absenceRepo.GetStartedToReassign(ctx, now)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), absences)
 
+	absences, err = s.absenceRepo.GetByUser(ctx, "u1")
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), absences[0].ReassignedAt)
+}
+
+// ==== CodeOwnersRepository tests ====
+func (s *IntegrationTestSuite) TestCodeOwnersUpsert() {
+	ctx := context.Background()
+
+	tx, _ := s.db.Begin()
+	err := s.codeOwnersRepo.Upsert(ctx, tx, &domain.CodeOwners{Repository: "avito/service", Content: "* @u1"})
 	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
 
-	// Get active members, excluding u1
-	teamIDStr := team.ID
-	members, err := s.userRepo.GetActiveTeamMembersIDs(context.Background(), teamIDStr, "u1")
+	// second upload replaces the file
+	tx, _ = s.db.Begin()
+	err = s.codeOwnersRepo.Upsert(ctx, tx, &domain.CodeOwners{Repository: "avito/service", Content: "* @u2"})
+	require.NoError(s.T(), tx.Commit())
+	require.NoError(s.T(), err)
 
+	result, err := s.codeOwnersRepo.GetByRepository(ctx, "avito/service")
 	require.NoError(s.T(), err)
-	assert.Len(s.T(), members, 2) // u3, u4
-	assert.Contains(s.T(), members, "u3")
-	assert.Contains(s.T(), members, "u4")
-	assert.NotContains(s.T(), members, "u1") // excluded
-	assert.NotContains(s.T(), members, "u2") // inactive
+	assert.Equal(s.T(), "* @u2", result.Content)
+	assert.False(s.T(), result.UpdatedAt.IsZero())
+
+	_, err = s.codeOwnersRepo.GetByRepository(ctx, "avito/other")
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
 }
 
 // ==== PullRequestRepository tests ====
 func (s *IntegrationTestSuite) TestPRCreate_Success() {
-	team := &domain.Team{Name: "team-5"}
+	team := &domain.Team{Name: "team-5", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -278,14 +697,14 @@ func (s *IntegrationTestSuite) TestPRCreate_InvalidAuthorID() {
 }
 
 func (s *IntegrationTestSuite) TestPRGetByID_Found() {
-	team := &domain.Team{Name: "team-6"}
+	team := &domain.Team{Name: "team-6", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
 	author := &domain.User{ID: "author-2", Name: "Author2", IsActive: true, TeamID: team.ID}
 	s.userRepo.Create(context.Background(), tx, author)
 
-	pr := &domain.PullRequest{ID: "pr-2001", Name: "Fix bug", AuthorID: "author-2", Status: domain.StatusOpen}
+	pr := &domain.PullRequest{ID: "pr-2001", Name: "Fix bug", AuthorID: "author-2", Status: domain.StatusOpen, Labels: []string{"sql"}}
 	s.prRepo.Create(context.Background(), tx, pr)
 	require.NoError(s.T(), tx.Commit())
 
@@ -295,6 +714,7 @@ func (s *IntegrationTestSuite) TestPRGetByID_Found() {
 	assert.Equal(s.T(), "Fix bug", result.Name)
 	assert.Equal(s.T(), domain.StatusOpen, result.Status)
 	assert.Nil(s.T(), result.MergedAt)
+	assert.Equal(s.T(), []string{"sql"}, result.Labels)
 }
 
 func (s *IntegrationTestSuite) TestPRGetByID_NotFound() {
@@ -303,7 +723,7 @@ func (s *IntegrationTestSuite) TestPRGetByID_NotFound() {
 }
 
 func (s *IntegrationTestSuite) TestPRUpdate_StatusToMerged() {
-	team := &domain.Team{Name: "team-7"}
+	team := &domain.Team{Name: "team-7", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -330,6 +750,33 @@ func (s *IntegrationTestSuite) TestPRUpdate_StatusToMerged() {
 	assert.NotNil(s.T(), updated.MergedAt)
 }
 
+func (s *IntegrationTestSuite) TestPRUpdate_DraftToClosed() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-drafts", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "author-dr", Name: "Author", IsActive: true, TeamID: team.ID})
+
+	pr := &domain.PullRequest{ID: "pr-dr", Name: "WIP", AuthorID: "author-dr", Status: domain.StatusDraft}
+	require.NoError(s.T(), s.prRepo.Create(ctx, tx, pr))
+	require.NoError(s.T(), tx.Commit())
+
+	pr.Status = domain.StatusClosed
+	tx, _ = s.db.Begin()
+	require.NoError(s.T(), s.prRepo.Update(ctx, tx, pr))
+	require.NoError(s.T(), tx.Commit())
+
+	result, err := s.prRepo.GetByID(ctx, "pr-dr")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.StatusClosed, result.Status)
+
+	// Unknown statuses are rejected by the schema
+	pr.Status = domain.PRStatus("REJECTED")
+	tx, _ = s.db.Begin()
+	defer tx.Rollback() //nolint:errcheck
+	assert.Error(s.T(), s.prRepo.Update(ctx, tx, pr))
+}
+
 func (s *IntegrationTestSuite) TestPRUpdate_NotFound() {
 	tx, _ := s.db.Begin()
 	defer tx.Rollback()
@@ -342,7 +789,7 @@ func (s *IntegrationTestSuite) TestPRUpdate_NotFound() {
 
 // ==== Reviewer tests ====
 func (s *IntegrationTestSuite) TestPRAddReviewer_Success() {
-	team := &domain.Team{Name: "team-8"}
+	team := &domain.Team{Name: "team-8", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -355,7 +802,7 @@ func (s *IntegrationTestSuite) TestPRAddReviewer_Success() {
 	s.prRepo.Create(context.Background(), tx, pr)
 
 	// Add reviewer
-	err := s.prRepo.AddReviewer(context.Background(), tx, "pr-4001", "reviewer-1")
+	err := s.prRepo.AddReviewer(context.Background(), tx, "pr-4001", "reviewer-1", domain.SourceTeam)
 	require.NoError(s.T(), err)
 	require.NoError(s.T(), tx.Commit())
 
@@ -365,7 +812,7 @@ func (s *IntegrationTestSuite) TestPRAddReviewer_Success() {
 }
 
 func (s *IntegrationTestSuite) TestPRAddReviewer_Duplicate_Idempotent() {
-	team := &domain.Team{Name: "team-9"}
+	team := &domain.Team{Name: "team-9", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -381,9 +828,9 @@ func (s *IntegrationTestSuite) TestPRAddReviewer_Duplicate_Idempotent() {
 	require.NoError(s.T(), err)
 
 	// Add twice
-	err = s.prRepo.AddReviewer(context.Background(), tx, "pr-5001", "reviewer-2")
+	err = s.prRepo.AddReviewer(context.Background(), tx, "pr-5001", "reviewer-2", domain.SourceTeam)
 	require.NoError(s.T(), err)
-	err = s.prRepo.AddReviewer(context.Background(), tx, "pr-5001", "reviewer-2")
+	err = s.prRepo.AddReviewer(context.Background(), tx, "pr-5001", "reviewer-2", domain.SourceTeam)
 	require.NoError(s.T(), tx.Commit())
 
 	// Operation must not conflict (ON CONFLICT DO NOTHING)
@@ -396,7 +843,7 @@ func (s *IntegrationTestSuite) TestPRAddReviewer_Duplicate_Idempotent() {
 }
 
 func (s *IntegrationTestSuite) TestPRRemoveReviewer_Success() {
-	team := &domain.Team{Name: "team-10"}
+	team := &domain.Team{Name: "team-10", MaxReviewers: domain.DefaultMaxReviewers}
 
 	// Create team, two users, and PR
 	tx, _ := s.db.Begin()
@@ -413,22 +860,45 @@ func (s *IntegrationTestSuite) TestPRRemoveReviewer_Success() {
 
 	// Add reviewer
 	tx, _ = s.db.Begin()
-	s.prRepo.AddReviewer(context.Background(), tx, "pr-6001", "reviewer-3")
+	s.prRepo.AddReviewer(context.Background(), tx, "pr-6001", "reviewer-3", domain.SourceTeam)
 	require.NoError(s.T(), tx.Commit())
 
-	// Delete reviewer
+	// Remove reviewer
 	tx, _ = s.db.Begin()
-	err := s.prRepo.RemoveReviewer(context.Background(), tx, "pr-6001", "reviewer-3")
+	err := s.prRepo.RemoveReviewer(context.Background(), tx, "pr-6001", "reviewer-3", domain.UnassignManual)
 	require.NoError(s.T(), err)
 	require.NoError(s.T(), tx.Commit())
 
-	// Check that reviewer was deleted
+	// Check that reviewer was removed, but the assignment is kept in the history
 	result, _ := s.prRepo.GetByID(context.Background(), "pr-6001")
 	assert.Empty(s.T(), result.ReviewersIDs)
+
+	assignments, err := s.prRepo.GetAssignments(context.Background(), "pr-6001")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), assignments, 1)
+	assert.NotNil(s.T(), assignments[0].UnassignedAt)
+	assert.Equal(s.T(), domain.UnassignManual, assignments[0].UnassignReason)
+
+	// The ended assignment can't be ended again, but the user can be assigned anew
+	tx, _ = s.db.Begin()
+	err = s.prRepo.RemoveReviewer(context.Background(), tx, "pr-6001", "reviewer-3", domain.UnassignManual)
+	assert.ErrorIs(s.T(), err, domain.ErrNotAssigned)
+	require.NoError(s.T(), s.prRepo.AddReviewer(context.Background(), tx, "pr-6001", "reviewer-3", domain.SourceManual))
+	require.NoError(s.T(), tx.Commit())
+
+	result, _ = s.prRepo.GetByID(context.Background(), "pr-6001")
+	assert.Equal(s.T(), []string{"reviewer-3"}, result.ReviewersIDs)
+	assert.Equal(s.T(), domain.SourceManual, result.ReviewerSources["reviewer-3"])
+
+	assignments, err = s.prRepo.GetAssignments(context.Background(), "pr-6001")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), assignments, 2)
+	assert.Nil(s.T(), assignments[1].UnassignedAt)
+	assert.Empty(s.T(), assignments[1].UnassignReason)
 }
 
 func (s *IntegrationTestSuite) TestPRRemoveReviewer_NotAssigned() {
-	team := &domain.Team{Name: "team-11"}
+	team := &domain.Team{Name: "team-11", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -439,14 +909,140 @@ func (s *IntegrationTestSuite) TestPRRemoveReviewer_NotAssigned() {
 	s.prRepo.Create(context.Background(), tx, pr)
 
 	// Try to delete reviewer that does not exist
-	err := s.prRepo.RemoveReviewer(context.Background(), tx, "pr-7001", "nonexistent-reviewer")
+	err := s.prRepo.RemoveReviewer(context.Background(), tx, "pr-7001", "nonexistent-reviewer", domain.UnassignManual)
 	require.NoError(s.T(), tx.Rollback())
 
 	assert.ErrorIs(s.T(), err, domain.ErrNotAssigned)
 }
 
+func (s *IntegrationTestSuite) TestPRSetDecision() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-decisions", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "author-d", Name: "Author", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "reviewer-d1", Name: "Reviewer1", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "reviewer-d2", Name: "Reviewer2", IsActive: true, TeamID: team.ID})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-d", Name: "Feature", AuthorID: "author-d", Status: domain.StatusOpen})
+	s.prRepo.AddReviewer(ctx, tx, "pr-d", "reviewer-d1", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-d", "reviewer-d2", domain.SourceTeam)
+
+	// The second decision replaces the first one
+	err := s.prRepo.SetDecision(ctx, tx, "pr-d", "reviewer-d1", &domain.ReviewDecision{Decision: domain.DecisionChangesRequested, Text: "fix"})
+	require.NoError(s.T(), err)
+	approval := &domain.ReviewDecision{Decision: domain.DecisionApproved}
+	err = s.prRepo.SetDecision(ctx, tx, "pr-d", "reviewer-d1", approval)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), approval.DecidedAt.IsZero())
+
+	err = s.prRepo.SetDecision(ctx, tx, "pr-d", "author-d", &domain.ReviewDecision{Decision: domain.DecisionApproved})
+	assert.ErrorIs(s.T(), err, domain.ErrNotAssigned)
+	require.NoError(s.T(), tx.Commit())
+
+	pr, err := s.prRepo.GetByID(ctx, "pr-d")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), pr.Decisions, 1)
+	assert.Equal(s.T(), domain.DecisionApproved, pr.Decisions["reviewer-d1"].Decision)
+	assert.Empty(s.T(), pr.Decisions["reviewer-d1"].Text)
+
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, "reviewer-d2")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), prs, 1)
+	assert.False(s.T(), prs[0].HasDecision("reviewer-d2"))
+}
+
+func (s *IntegrationTestSuite) TestPRForcedMerges() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-forced", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "author-f", Name: "Author", IsActive: true, TeamID: team.ID})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-f1", Name: "Hotfix", AuthorID: "author-f", Status: domain.StatusOpen})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-f2", Name: "Release", AuthorID: "author-f", Status: domain.StatusOpen})
+
+	first := &domain.ForcedMerge{PRID: "pr-f1", ForcedBy: "lead", Reason: "hotfix", UnmetConditions: []string{"1 approvals required, 0 given"}}
+	err := s.prRepo.AddForcedMerge(ctx, tx, first)
+	require.NoError(s.T(), err)
+	assert.NotZero(s.T(), first.ID)
+
+	second := &domain.ForcedMerge{PRID: "pr-f2", ForcedBy: "lead", Reason: "release"}
+	err = s.prRepo.AddForcedMerge(ctx, tx, second)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), tx.Commit())
+
+	merges, err := s.prRepo.GetForcedMerges(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), merges, 2)
+
+	// Newest first
+	assert.Equal(s.T(), "pr-f2", merges[0].PRID)
+	assert.Empty(s.T(), merges[0].UnmetConditions)
+	assert.Equal(s.T(), "pr-f1", merges[1].PRID)
+	assert.Equal(s.T(), []string{"1 approvals required, 0 given"}, merges[1].UnmetConditions)
+}
+
+func (s *IntegrationTestSuite) TestPRAddDecline() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-decline", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "author-d", Name: "Author", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "reviewer-d", Name: "Reviewer", IsActive: true, TeamID: team.ID})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-d1", Name: "Feature", AuthorID: "author-d", Status: domain.StatusOpen})
+
+	decline := &domain.ReviewDecline{UserID: "reviewer-d", Reason: "conflict of interest"}
+	err := s.prRepo.AddDecline(ctx, tx, "pr-d1", decline)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), decline.DeclinedAt.IsZero())
+	require.NoError(s.T(), tx.Commit())
+
+	pr, err := s.prRepo.GetByID(ctx, "pr-d1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), pr.Declines, 1)
+	assert.Equal(s.T(), "reviewer-d", pr.Declines[0].UserID)
+	assert.Equal(s.T(), "conflict of interest", pr.Declines[0].Reason)
+
+	tx, _ = s.db.Begin()
+	pr, err = s.prRepo.GetByIDForUpdate(ctx, tx, "pr-d1")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"reviewer-d"}, pr.DeclinedIDs())
+	_ = tx.Rollback()
+}
+
+func (s *IntegrationTestSuite) TestPREvents() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-events", MaxReviewers: domain.DefaultMaxReviewers}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "author-e", Name: "Author", IsActive: true, TeamID: team.ID})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-e1", Name: "Feature", AuthorID: "author-e", Status: domain.StatusOpen})
+
+	created := &domain.PREvent{PRID: "pr-e1", Type: domain.EventCreated, Actor: "author-e", Payload: map[string]any{"status": "OPEN"}}
+	err := s.prRepo.AddEvent(ctx, tx, created)
+	require.NoError(s.T(), err)
+	assert.NotZero(s.T(), created.ID)
+	err = s.prRepo.AddEvent(ctx, tx, &domain.PREvent{PRID: "pr-e1", Type: domain.EventMerged})
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), tx.Commit())
+
+	events, err := s.prRepo.GetEvents(ctx, "pr-e1")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), events, 2)
+	assert.Equal(s.T(), domain.EventCreated, events[0].Type)
+	assert.Equal(s.T(), "author-e", events[0].Actor)
+	assert.Equal(s.T(), map[string]any{"status": "OPEN"}, events[0].Payload)
+	assert.Equal(s.T(), domain.EventMerged, events[1].Type)
+	assert.Empty(s.T(), events[1].Actor)
+	assert.Empty(s.T(), events[1].Payload)
+
+	// Recorded events can't be changed
+	_, err = s.db.Exec("UPDATE pr_events SET actor = 'someone' WHERE id = $1", created.ID)
+	assert.Error(s.T(), err)
+}
+
 func (s *IntegrationTestSuite) TestPRGetPRsByReviewer_MultipleFound() {
-	team := &domain.Team{Name: "team-12"}
+	team := &domain.Team{Name: "team-12", MaxReviewers: domain.DefaultMaxReviewers}
 	tx, _ := s.db.Begin()
 	s.teamRepo.Create(context.Background(), tx, team)
 
@@ -462,8 +1058,8 @@ func (s *IntegrationTestSuite) TestPRGetPRsByReviewer_MultipleFound() {
 	s.prRepo.Create(context.Background(), tx, pr2)
 
 	// Assign reviewers for both PRs
-	s.prRepo.AddReviewer(context.Background(), tx, "pr-8001", "reviewer-4")
-	s.prRepo.AddReviewer(context.Background(), tx, "pr-8002", "reviewer-4")
+	s.prRepo.AddReviewer(context.Background(), tx, "pr-8001", "reviewer-4", domain.SourceTeam)
+	s.prRepo.AddReviewer(context.Background(), tx, "pr-8002", "reviewer-4", domain.SourceTeam)
 	require.NoError(s.T(), tx.Commit())
 
 	// Get PR of the reviewer
@@ -473,6 +1069,154 @@ func (s *IntegrationTestSuite) TestPRGetPRsByReviewer_MultipleFound() {
 	assert.Len(s.T(), prs, 2)
 }
 
+func (s *IntegrationTestSuite) TestPRGetUnderstaffedPRIDs() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-gaps", MaxReviewers: 2}
+	other := &domain.Team{Name: "team-other", MaxReviewers: 1}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.teamRepo.Create(ctx, tx, other)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "o1", Name: "D", IsActive: true, TeamID: other.ID})
+
+	// pr-1 lacks a reviewer, pr-2 is full, pr-3 is merged, pr-4 lacks a reviewer in another team
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-1", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-2", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-3", Name: "PR", AuthorID: "u1", Status: domain.StatusMerged},
+		{ID: "pr-4", Name: "PR", AuthorID: "o1", Status: domain.StatusOpen},
+	} {
+		s.prRepo.Create(ctx, tx, pr)
+	}
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u2", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-2", "u2", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-2", "u3", domain.SourceTeam)
+	require.NoError(s.T(), tx.Commit())
+
+	ids, err := s.prRepo.GetUnderstaffedPRIDs(ctx)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), []string{"pr-1", "pr-4"}, ids)
+
+	ids, err = s.prRepo.GetUnderstaffedPRIDsByTeam(ctx, team.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"pr-1"}, ids)
+}
+
+func (s *IntegrationTestSuite) TestPRPendingReviews() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-sla", MaxReviewers: 2, ReviewSLAHours: 24, SLAReassignHours: 48}
+	noSLA := &domain.Team{Name: "team-no-sla", MaxReviewers: 1}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+	s.teamRepo.Create(ctx, tx, noSLA)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "o1", Name: "D", IsActive: true, TeamID: noSLA.ID})
+
+	// pr-1 is pending for u2 only, pr-2 is merged, pr-3 belongs to a team without SLA
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-1", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-2", Name: "PR", AuthorID: "u1", Status: domain.StatusMerged},
+		{ID: "pr-3", Name: "PR", AuthorID: "o1", Status: domain.StatusOpen},
+	} {
+		s.prRepo.Create(ctx, tx, pr)
+	}
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u2", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u3", domain.SourceTeam)
+	s.prRepo.SetDecision(ctx, tx, "pr-1", "u3", &domain.ReviewDecision{Decision: domain.DecisionApproved})
+	s.prRepo.AddReviewer(ctx, tx, "pr-2", "u2", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-3", "u1", domain.SourceTeam)
+	require.NoError(s.T(), tx.Commit())
+
+	reviews, err := s.prRepo.GetPendingReviews(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), reviews, 1)
+	assert.Equal(s.T(), "pr-1", reviews[0].PRID)
+	assert.Equal(s.T(), "u2", reviews[0].ReviewerID)
+	assert.Equal(s.T(), "team-sla", reviews[0].TeamName)
+	assert.Equal(s.T(), 24, reviews[0].ReviewSLAHours)
+	assert.Equal(s.T(), 48, reviews[0].SLAReassignHours)
+	assert.Nil(s.T(), reviews[0].OverdueAt)
+
+	tx, _ = s.db.Begin()
+	err = s.prRepo.MarkOverdue(ctx, tx, "pr-1", "u2", time.Now())
+	require.NoError(s.T(), err)
+	// Marked only once
+	err = s.prRepo.MarkOverdue(ctx, tx, "pr-1", "u2", time.Now())
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	require.NoError(s.T(), tx.Commit())
+
+	reviews, err = s.prRepo.GetPendingReviews(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), reviews, 1)
+	assert.NotNil(s.T(), reviews[0].OverdueAt)
+}
+
+func (s *IntegrationTestSuite) TestPRRemindableAssignments() {
+	ctx := context.Background()
+	team := &domain.Team{Name: "team-remind", MaxReviewers: 2}
+	tx, _ := s.db.Begin()
+	s.teamRepo.Create(ctx, tx, team)
+
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u1", Name: "A", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u2", Name: "B", IsActive: true, TeamID: team.ID})
+	s.userRepo.Create(ctx, tx, &domain.User{ID: "u3", Name: "C", IsActive: false, TeamID: team.ID})
+
+	// u3 is inactive, pr-2 is merged
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-1", Name: "PR", AuthorID: "u1", Status: domain.StatusOpen})
+	s.prRepo.Create(ctx, tx, &domain.PullRequest{ID: "pr-2", Name: "PR", AuthorID: "u1", Status: domain.StatusMerged})
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u2", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-1", "u3", domain.SourceTeam)
+	s.prRepo.AddReviewer(ctx, tx, "pr-2", "u2", domain.SourceTeam)
+	require.NoError(s.T(), tx.Commit())
+
+	// Assignments made later are not remindable yet
+	assignments, err := s.prRepo.GetRemindableAssignments(ctx, time.Now().Add(-time.Hour))
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), assignments)
+
+	assignments, err = s.prRepo.GetRemindableAssignments(ctx, time.Now().Add(time.Hour))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), assignments, 1)
+	assert.Equal(s.T(), "pr-1", assignments[0].PRID)
+	assert.Equal(s.T(), "u2", assignments[0].ReviewerID)
+	assert.Zero(s.T(), assignments[0].RemindersSent)
+	assert.Nil(s.T(), assignments[0].LastRemindedAt)
+
+	tx, _ = s.db.Begin()
+	err = s.prRepo.MarkReminded(ctx, tx, "pr-1", "u2", 0, time.Now())
+	require.NoError(s.T(), err)
+	// A stale count means somebody else has reminded already
+	err = s.prRepo.MarkReminded(ctx, tx, "pr-1", "u2", 0, time.Now())
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	require.NoError(s.T(), tx.Commit())
+
+	assignments, err = s.prRepo.GetRemindableAssignments(ctx, time.Now().Add(time.Hour))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), assignments, 1)
+	assert.Equal(s.T(), 1, assignments[0].RemindersSent)
+	assert.NotNil(s.T(), assignments[0].LastRemindedAt)
+
+	// An undelivered reminder is reverted
+	tx, _ = s.db.Begin()
+	err = s.prRepo.UnmarkReminded(ctx, tx, "pr-1", "u2", 0, nil)
+	require.NoError(s.T(), err)
+	err = s.prRepo.UnmarkReminded(ctx, tx, "pr-1", "u2", 0, nil)
+	assert.ErrorIs(s.T(), err, domain.ErrNotFound)
+	require.NoError(s.T(), tx.Commit())
+
+	assignments, err = s.prRepo.GetRemindableAssignments(ctx, time.Now().Add(time.Hour))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), assignments, 1)
+	assert.Zero(s.T(), assignments[0].RemindersSent)
+	assert.Nil(s.T(), assignments[0].LastRemindedAt)
+}
+
 func TestIntegrationSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests")
@@ -3,9 +3,13 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
@@ -24,10 +28,10 @@ func NewPullRequestRepository(db *sql.DB, logger *zap.Logger) *PullRequestReposi
 // Returns domain ErrPRExists if PR with given ID is present in DB
 func (p *PullRequestRepository) Create(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest) error {
 	query := `
-			INSERT INTO pull_requests (id, name, author_id, status)
-			VALUES ($1, $2, $3, $4)
+			INSERT INTO pull_requests (id, name, author_id, status, labels)
+			VALUES ($1, $2, $3, $4, $5)
 			RETURNING created_at`
-	err := tx.QueryRowContext(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status).Scan(&pr.CreatedAt)
+	err := tx.QueryRowContext(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status, textArray(pr.Labels)).Scan(&pr.CreatedAt)
 
 	// if PR already exists - return domain.ErrPRExists
 	if err != nil {
@@ -79,17 +83,18 @@ func (p *PullRequestRepository) Update(ctx context.Context, tx *sql.Tx, pr *doma
 	return nil
 }
 
-// GetByID retrieves a pull request by ID including all assigned reviewers.
+// GetByID retrieves a pull request by ID including all assigned reviewers and declines.
 // Returns ErrNotFound if the PR doesn't exist.
 func (p *PullRequestRepository) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	query := `
-			SELECT id, name, author_id, status, created_at, merged_at
+			SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id
 			FROM pull_requests
 			WHERE id = $1`
 
 	var pr domain.PullRequest
 	var mergedAt sql.NullTime
-	err := p.db.QueryRowContext(ctx, query, prID).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+	var archivedTeamID sql.NullInt64
+	err := p.db.QueryRowContext(ctx, query, prID).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, pq.Array(&pr.Labels), &archivedTeamID)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -104,8 +109,14 @@ func (p *PullRequestRepository) GetByID(ctx context.Context, prID string) (*doma
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	pr.ArchivedTeamID = archivedTeamID.Int64
+
+	err = p.getReviewers(ctx, &pr)
+	if err != nil {
+		return nil, err
+	}
 
-	pr.ReviewersIDs, err = p.getReviewerIDs(ctx, prID)
+	err = p.getDeclines(ctx, &pr)
 	if err != nil {
 		return nil, err
 	}
@@ -113,38 +124,24 @@ func (p *PullRequestRepository) GetByID(ctx context.Context, prID string) (*doma
 	return &pr, nil
 }
 
-// getReviewerIDs retrieves all reviewer IDs assigned to a PR.
-func (p *PullRequestRepository) getReviewerIDs(ctx context.Context, prID string) ([]string, error) {
+// getReviewers retrieves all reviewers currently assigned to the PR together with how they were assigned
+// and their latest decisions, and sets them to the PR object.
+func (p *PullRequestRepository) getReviewers(ctx context.Context, pr *domain.PullRequest) error {
 	query := `
-			SELECT user_id
+			SELECT user_id, source, decision, decision_text, decided_at
 			FROM pr_reviewers
-			WHERE pr_id = $1`
+			WHERE pr_id = $1 AND unassigned_at IS NULL`
 
-	rows, err := p.db.QueryContext(ctx, query, prID)
+	rows, err := p.db.QueryContext(ctx, query, pr.ID)
 	if err != nil {
 		p.logger.Error("DB error on pr_reviewers select",
 			zap.Error(err),
-			zap.String("pr_id", prID))
-		return nil, err
+			zap.String("pr_id", pr.ID))
+		return err
 	}
 	defer rows.Close() //nolint:errcheck
 
-	var reviewerIDs []string
-	for rows.Next() {
-		var id string
-		err := rows.Scan(&id)
-		if err != nil {
-			return nil, err
-		}
-
-		reviewerIDs = append(reviewerIDs, id)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return reviewerIDs, nil
+	return scanReviewers(rows, pr)
 }
 
 // GetByIDForUpdate retrieves a PR with a row-level lock within a transaction.
@@ -152,7 +149,7 @@ func (p *PullRequestRepository) getReviewerIDs(ctx context.Context, prID string)
 // Returns ErrNotFound if the PR doesn't exist.
 func (p *PullRequestRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx, prID string) (*domain.PullRequest, error) {
 	query := `
-			SELECT id, name, author_id, status, created_at, merged_at
+			SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id
 			FROM pull_requests
 			WHERE id = $1
 			FOR UPDATE
@@ -160,7 +157,8 @@ func (p *PullRequestRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx
 
 	var pr domain.PullRequest
 	var mergedAt sql.NullTime
-	err := tx.QueryRowContext(ctx, query, prID).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+	var archivedTeamID sql.NullInt64
+	err := tx.QueryRowContext(ctx, query, prID).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, pq.Array(&pr.Labels), &archivedTeamID)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -175,8 +173,14 @@ func (p *PullRequestRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	pr.ArchivedTeamID = archivedTeamID.Int64
 
-	pr.ReviewersIDs, err = p.getReviewerIDsTx(ctx, tx, prID)
+	err = p.getReviewersTx(ctx, tx, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.getDeclinesTx(ctx, tx, &pr)
 	if err != nil {
 		return nil, err
 	}
@@ -184,59 +188,162 @@ func (p *PullRequestRepository) GetByIDForUpdate(ctx context.Context, tx *sql.Tx
 	return &pr, nil
 }
 
-// getReviewerIDsTx retrieves reviewer IDs within a transaction.
-func (p *PullRequestRepository) getReviewerIDsTx(ctx context.Context, tx *sql.Tx, prID string) ([]string, error) {
+// getReviewersTx works like getReviewers within a transaction.
+func (p *PullRequestRepository) getReviewersTx(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest) error {
 	query := `
-			SELECT user_id
+			SELECT user_id, source, decision, decision_text, decided_at
 			FROM pr_reviewers
-			WHERE pr_id = $1`
+			WHERE pr_id = $1 AND unassigned_at IS NULL`
 
-	rows, err := tx.QueryContext(ctx, query, prID)
+	rows, err := tx.QueryContext(ctx, query, pr.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close() //nolint:errcheck
 
+	return scanReviewers(rows, pr)
+}
+
+// scanReviewers reads (user_id, source, decision, decision_text, decided_at) rows of pr_reviewers
+// into the reviewers of the PR.
+func scanReviewers(rows *sql.Rows, pr *domain.PullRequest) error {
 	var reviewerIDs []string
+	sources := make(map[string]domain.ReviewerSource)
+	decisions := make(map[string]domain.ReviewDecision)
 	for rows.Next() {
 		var id string
-		err := rows.Scan(&id)
+		var source domain.ReviewerSource
+		var decision domain.ReviewDecision
+		var decisionType sql.NullString
+		var decidedAt sql.NullTime
+		err := rows.Scan(&id, &source, &decisionType, &decision.Text, &decidedAt)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		reviewerIDs = append(reviewerIDs, id)
+		sources[id] = source
+		if decisionType.Valid {
+			decision.Decision = domain.Decision(decisionType.String)
+			decision.DecidedAt = decidedAt.Time
+			decisions[id] = decision
+		}
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if err := rows.Err(); err != nil {
+		return err
 	}
 
-	return reviewerIDs, nil
+	pr.ReviewersIDs = reviewerIDs
+	pr.ReviewerSources = sources
+	pr.Decisions = decisions
+
+	return nil
 }
 
-// AddReviewer assigns a reviewer to a PR within a transaction.
+// getDeclines retrieves the users that declined to review the PR and sets them to the PR object.
+func (p *PullRequestRepository) getDeclines(ctx context.Context, pr *domain.PullRequest) error {
+	query := `
+			SELECT user_id, reason, declined_at
+			FROM review_declines
+			WHERE pr_id = $1
+			ORDER BY declined_at, user_id`
+
+	rows, err := p.db.QueryContext(ctx, query, pr.ID)
+	if err != nil {
+		p.logger.Error("DB error on review_declines select",
+			zap.Error(err),
+			zap.String("pr_id", pr.ID))
+		return err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanDeclines(rows, pr)
+}
+
+// getDeclinesTx works like getDeclines within a transaction.
+func (p *PullRequestRepository) getDeclinesTx(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest) error {
+	query := `
+			SELECT user_id, reason, declined_at
+			FROM review_declines
+			WHERE pr_id = $1
+			ORDER BY declined_at, user_id`
+
+	rows, err := tx.QueryContext(ctx, query, pr.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanDeclines(rows, pr)
+}
+
+// scanDeclines reads (user_id, reason, declined_at) rows of review_declines into the declines of the PR.
+func scanDeclines(rows *sql.Rows, pr *domain.PullRequest) error {
+	var declines []domain.ReviewDecline
+	for rows.Next() {
+		var decline domain.ReviewDecline
+		if err := rows.Scan(&decline.UserID, &decline.Reason, &decline.DeclinedAt); err != nil {
+			return err
+		}
+
+		declines = append(declines, decline)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	pr.Declines = declines
+
+	return nil
+}
+
+// AddDecline records that the user declined to review the PR within a transaction.
+// Sets decline time of the given object.
+func (p *PullRequestRepository) AddDecline(ctx context.Context, tx *sql.Tx, prID string, decline *domain.ReviewDecline) error {
+	query := `
+			INSERT INTO review_declines (pr_id, user_id, reason)
+			VALUES ($1, $2, $3)
+			RETURNING declined_at`
+
+	err := tx.QueryRowContext(ctx, query, prID, decline.UserID, decline.Reason).Scan(&decline.DeclinedAt)
+	if err != nil {
+		p.logger.Error("DB error on review decline insert",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("user_id", decline.UserID))
+		return err
+	}
+
+	return nil
+}
+
+// AddReviewer assigns a reviewer to a PR within a transaction, recording how the reviewer was chosen.
 // If the reviewer is already assigned, the operation is idempotent (no error on duplicate).
-func (p *PullRequestRepository) AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error {
+// A reviewer unassigned earlier gets a new assignment.
+func (p *PullRequestRepository) AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, source domain.ReviewerSource) error {
 	query := `
-			INSERT INTO pr_reviewers (pr_id, user_id)
-			VALUES ($1, $2)
-			ON CONFLICT (pr_id, user_id) DO NOTHING
+			INSERT INTO pr_reviewers (pr_id, user_id, source)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (pr_id, user_id) WHERE unassigned_at IS NULL DO NOTHING
 			`
 
-	_, err := tx.ExecContext(ctx, query, prID, userID)
+	_, err := tx.ExecContext(ctx, query, prID, userID, source)
 	return err
 }
 
-// RemoveReviewer removes a reviewer from a PR within a transaction.
-// Returns ErrNotAssigned if the user was not assigned as a reviewer.
-func (p *PullRequestRepository) RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error {
+// RemoveReviewer ends the current assignment of a reviewer to a PR within a transaction,
+// recording why it has ended. The assignment stays in the history of the PR.
+// Returns ErrNotAssigned if the user is not assigned as a reviewer.
+func (p *PullRequestRepository) RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, reason domain.UnassignReason) error {
 	query := `
-			DELETE FROM pr_reviewers
-			WHERE pr_id = $1 AND user_id = $2
+			UPDATE pr_reviewers
+			SET unassigned_at = NOW(), unassign_reason = $1
+			WHERE pr_id = $2 AND user_id = $3 AND unassigned_at IS NULL
 			`
 
-	res, err := tx.ExecContext(ctx, query, prID, userID)
+	res, err := tx.ExecContext(ctx, query, reason, prID, userID)
 	if err != nil {
 		return err
 	}
@@ -251,14 +358,40 @@ func (p *PullRequestRepository) RemoveReviewer(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-// GetPRsByReviewer retrieves all pull requests assigned to a specific reviewer.
+// SetDecision records the latest decision of the reviewer on the PR within a transaction,
+// replacing the previous one. Sets decision time of the given object.
+// Returns ErrNotAssigned if the user is not assigned as a reviewer.
+func (p *PullRequestRepository) SetDecision(ctx context.Context, tx *sql.Tx, prID, userID string, decision *domain.ReviewDecision) error {
+	query := `
+			UPDATE pr_reviewers
+			SET decision = $1, decision_text = $2, decided_at = NOW()
+			WHERE pr_id = $3 AND user_id = $4 AND unassigned_at IS NULL
+			RETURNING decided_at`
+
+	err := tx.QueryRowContext(ctx, query, decision.Decision, decision.Text, prID, userID).Scan(&decision.DecidedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotAssigned
+		}
+		p.logger.Error("DB error on review decision update",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("user_id", userID))
+		return err
+	}
+
+	return nil
+}
+
+// GetPRsByReviewer retrieves all pull requests currently assigned to a specific reviewer,
+// together with the reviewer's latest decision on each of them.
 // Returns ErrNotFound if no PRs are found for the reviewer.
 func (p *PullRequestRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
 	query := `
-			SELECT id, name, author_id, status, created_at, merged_at
+			SELECT id, name, author_id, status, created_at, merged_at, labels, r.decision, r.decision_text, r.decided_at
 			FROM pull_requests as pr
 			JOIN pr_reviewers as r ON r.pr_id = pr.id
-			WHERE r.user_id = $1
+			WHERE r.user_id = $1 AND r.unassigned_at IS NULL
 			`
 
 	rows, err := p.db.QueryContext(ctx, query, userID)
@@ -271,7 +404,11 @@ func (p *PullRequestRepository) GetPRsByReviewer(ctx context.Context, userID str
 	for rows.Next() {
 		var pr domain.PullRequest
 		var mergedAt sql.NullTime
-		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+		var decision domain.ReviewDecision
+		var decisionType sql.NullString
+		var decidedAt sql.NullTime
+		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, pq.Array(&pr.Labels),
+			&decisionType, &decision.Text, &decidedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -280,6 +417,13 @@ func (p *PullRequestRepository) GetPRsByReviewer(ctx context.Context, userID str
 			pr.MergedAt = &mergedAt.Time
 		}
 
+		pr.Decisions = make(map[string]domain.ReviewDecision)
+		if decisionType.Valid {
+			decision.Decision = domain.Decision(decisionType.String)
+			decision.DecidedAt = decidedAt.Time
+			pr.Decisions[userID] = decision
+		}
+
 		prs = append(prs, &pr)
 	}
 
@@ -289,3 +433,537 @@ func (p *PullRequestRepository) GetPRsByReviewer(ctx context.Context, userID str
 
 	return prs, nil
 }
+
+// GetAssignments returns all current and ended assignments of reviewers to the PR, oldest first.
+func (p *PullRequestRepository) GetAssignments(ctx context.Context, prID string) ([]domain.ReviewAssignment, error) {
+	query := `
+			SELECT user_id, source, assigned_at, unassigned_at, unassign_reason
+			FROM pr_reviewers
+			WHERE pr_id = $1
+			ORDER BY assigned_at, id`
+
+	rows, err := p.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		p.logger.Error("DB error on assignments select",
+			zap.Error(err),
+			zap.String("pr_id", prID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var assignments []domain.ReviewAssignment
+	for rows.Next() {
+		var assignment domain.ReviewAssignment
+		var unassignedAt sql.NullTime
+		var reason sql.NullString
+		err := rows.Scan(&assignment.UserID, &assignment.Source, &assignment.AssignedAt, &unassignedAt, &reason)
+		if err != nil {
+			return nil, err
+		}
+
+		if unassignedAt.Valid {
+			assignment.UnassignedAt = &unassignedAt.Time
+			assignment.UnassignReason = domain.UnassignReason(reason.String)
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// GetPendingReviews returns current assignments without a decision to OPEN PRs whose author's team
+// has a review SLA, oldest first.
+func (p *PullRequestRepository) GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error) {
+	query := `
+			SELECT r.pr_id, pr.name, r.user_id, t.name, r.assigned_at, r.overdue_at, t.review_sla_hours, t.sla_reassign_hours
+			FROM pr_reviewers as r
+			JOIN pull_requests as pr ON pr.id = r.pr_id
+			JOIN users as u ON u.id = pr.author_id
+			JOIN teams as t ON t.id = u.team_id
+			WHERE r.unassigned_at IS NULL
+				AND r.decision IS NULL
+				AND pr.status = 'OPEN'
+				AND t.review_sla_hours > 0
+			ORDER BY r.assigned_at, r.id
+			`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		p.logger.Error("DB error on pending reviews select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var reviews []domain.PendingReview
+	for rows.Next() {
+		var review domain.PendingReview
+		var overdueAt sql.NullTime
+		err := rows.Scan(&review.PRID, &review.PRName, &review.ReviewerID, &review.TeamName, &review.AssignedAt,
+			&overdueAt, &review.ReviewSLAHours, &review.SLAReassignHours)
+		if err != nil {
+			return nil, err
+		}
+
+		if overdueAt.Valid {
+			review.OverdueAt = &overdueAt.Time
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// MarkOverdue records within a transaction when the current assignment of the reviewer was found overdue.
+// Returns ErrNotFound if the assignment has ended or is already marked.
+func (p *PullRequestRepository) MarkOverdue(ctx context.Context, tx *sql.Tx, prID, userID string, overdueAt time.Time) error {
+	query := `
+			UPDATE pr_reviewers
+			SET overdue_at = $1
+			WHERE pr_id = $2 AND user_id = $3 AND unassigned_at IS NULL AND overdue_at IS NULL`
+
+	res, err := tx.ExecContext(ctx, query, overdueAt, prID, userID)
+	if err != nil {
+		p.logger.Error("DB error on pr_reviewers overdue update",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("user_id", userID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetRemindableAssignments returns current assignments without a decision to OPEN PRs made before
+// assignedBefore, ordered by reviewer and then oldest first.
+// Inactive and currently absent reviewers are skipped.
+func (p *PullRequestRepository) GetRemindableAssignments(ctx context.Context, assignedBefore time.Time) ([]domain.PendingAssignment, error) {
+	query := `
+			SELECT r.pr_id, r.user_id, r.assigned_at, r.reminder_count, r.last_reminded_at
+			FROM pr_reviewers as r
+			JOIN pull_requests as pr ON pr.id = r.pr_id
+			JOIN users as u ON u.id = r.user_id
+			WHERE r.unassigned_at IS NULL
+				AND r.decision IS NULL
+				AND pr.status = 'OPEN'
+				AND r.assigned_at <= $1
+				AND u.is_active = true
+				AND NOT EXISTS (
+					SELECT 1 FROM user_absences as a
+					WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+				)
+			ORDER BY r.user_id, r.assigned_at, r.id
+			`
+
+	rows, err := p.db.QueryContext(ctx, query, assignedBefore)
+	if err != nil {
+		p.logger.Error("DB error on remindable assignments select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var assignments []domain.PendingAssignment
+	for rows.Next() {
+		var a domain.PendingAssignment
+		var lastRemindedAt sql.NullTime
+		err := rows.Scan(&a.PRID, &a.ReviewerID, &a.AssignedAt, &a.RemindersSent, &lastRemindedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastRemindedAt.Valid {
+			a.LastRemindedAt = &lastRemindedAt.Time
+		}
+
+		assignments = append(assignments, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// MarkReminded records within a transaction that one more reminder about the current assignment
+// was sent to the reviewer. remindersSent is the number of reminders sent before, so concurrent
+// schedulers don't remind twice. Returns ErrNotFound if the assignment has ended or another
+// reminder was recorded meanwhile.
+func (p *PullRequestRepository) MarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, remindedAt time.Time) error {
+	query := `
+			UPDATE pr_reviewers
+			SET reminder_count = reminder_count + 1, last_reminded_at = $1
+			WHERE pr_id = $2 AND user_id = $3 AND unassigned_at IS NULL AND reminder_count = $4`
+
+	res, err := tx.ExecContext(ctx, query, remindedAt, prID, userID, remindersSent)
+	if err != nil {
+		p.logger.Error("DB error on pr_reviewers reminder update",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("user_id", userID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarkReminded reverts within a transaction a reminder recorded by MarkReminded that wasn't delivered,
+// restoring the number of reminders sent before and the time of the last one.
+// Returns ErrNotFound if the assignment has ended or another reminder was recorded meanwhile.
+func (p *PullRequestRepository) UnmarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, lastRemindedAt *time.Time) error {
+	query := `
+			UPDATE pr_reviewers
+			SET reminder_count = $1, last_reminded_at = $2
+			WHERE pr_id = $3 AND user_id = $4 AND unassigned_at IS NULL AND reminder_count = $1 + 1`
+
+	res, err := tx.ExecContext(ctx, query, remindersSent, lastRemindedAt, prID, userID)
+	if err != nil {
+		p.logger.Error("DB error on pr_reviewers reminder revert",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("user_id", userID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUnderstaffedPRIDs returns IDs of OPEN pull requests that have fewer reviewers
+// than the author's team allows, oldest first.
+func (p *PullRequestRepository) GetUnderstaffedPRIDs(ctx context.Context) ([]string, error) {
+	query := `
+			SELECT pr.id
+			FROM pull_requests as pr
+			JOIN users as u ON u.id = pr.author_id
+			JOIN teams as t ON t.id = u.team_id
+			WHERE pr.status = 'OPEN'
+				AND (SELECT COUNT(*) FROM pr_reviewers as r WHERE r.pr_id = pr.id AND r.unassigned_at IS NULL) < t.max_reviewers
+			ORDER BY pr.created_at, pr.id
+			`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		p.logger.Error("DB error on understaffed PRs select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanIDs(rows)
+}
+
+// GetUnderstaffedPRIDsByTeam works like GetUnderstaffedPRIDs, but only for PRs
+// whose authors are members of the given team.
+func (p *PullRequestRepository) GetUnderstaffedPRIDsByTeam(ctx context.Context, teamID int64) ([]string, error) {
+	query := `
+			SELECT pr.id
+			FROM pull_requests as pr
+			JOIN users as u ON u.id = pr.author_id
+			JOIN teams as t ON t.id = u.team_id
+			WHERE pr.status = 'OPEN'
+				AND t.id = $1
+				AND (SELECT COUNT(*) FROM pr_reviewers as r WHERE r.pr_id = pr.id AND r.unassigned_at IS NULL) < t.max_reviewers
+			ORDER BY pr.created_at, pr.id
+			`
+
+	rows, err := p.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		p.logger.Error("DB error on understaffed PRs select",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanIDs(rows)
+}
+
+// AddForcedMerge records a merge that bypassed the merge policy within a transaction.
+// Sets ID and creation time of the given object.
+func (p *PullRequestRepository) AddForcedMerge(ctx context.Context, tx *sql.Tx, merge *domain.ForcedMerge) error {
+	query := `
+			INSERT INTO forced_merges (pr_id, forced_by, reason, unmet_conditions)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at`
+
+	err := tx.QueryRowContext(ctx, query, merge.PRID, merge.ForcedBy, merge.Reason, textArray(merge.UnmetConditions)).
+		Scan(&merge.ID, &merge.CreatedAt)
+	if err != nil {
+		p.logger.Error("DB error on forced merge insert",
+			zap.Error(err),
+			zap.String("pr_id", merge.PRID))
+		return err
+	}
+
+	return nil
+}
+
+// GetForcedMerges returns the audit trail of merges that bypassed the merge policy, newest first.
+func (p *PullRequestRepository) GetForcedMerges(ctx context.Context) ([]domain.ForcedMerge, error) {
+	query := `
+			SELECT id, pr_id, forced_by, reason, unmet_conditions, created_at
+			FROM forced_merges
+			ORDER BY created_at DESC, id DESC`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		p.logger.Error("DB error on forced merges select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var merges []domain.ForcedMerge
+	for rows.Next() {
+		var merge domain.ForcedMerge
+		err := rows.Scan(&merge.ID, &merge.PRID, &merge.ForcedBy, &merge.Reason,
+			pq.Array(&merge.UnmetConditions), &merge.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		merges = append(merges, merge)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return merges, nil
+}
+
+// AddEvent appends an event to the timeline of the PR within a transaction.
+// Sets ID and creation time of the given object.
+func (p *PullRequestRepository) AddEvent(ctx context.Context, tx *sql.Tx, event *domain.PREvent) error {
+	query := `
+			INSERT INTO pr_events (pr_id, type, actor, payload)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at`
+
+	payload := event.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, query, event.PRID, event.Type, sql.NullString{String: event.Actor, Valid: event.Actor != ""}, payloadJSON).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		p.logger.Error("DB error on PR event insert",
+			zap.Error(err),
+			zap.String("pr_id", event.PRID),
+			zap.String("type", string(event.Type)))
+		return err
+	}
+
+	return nil
+}
+
+// GetEvents returns the timeline of the PR, oldest event first.
+func (p *PullRequestRepository) GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	query := `
+			SELECT id, pr_id, type, actor, payload, created_at
+			FROM pr_events
+			WHERE pr_id = $1
+			ORDER BY created_at, id`
+
+	rows, err := p.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		p.logger.Error("DB error on PR events select",
+			zap.Error(err),
+			zap.String("pr_id", prID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var events []domain.PREvent
+	for rows.Next() {
+		var event domain.PREvent
+		var actor sql.NullString
+		var payloadJSON []byte
+		err := rows.Scan(&event.ID, &event.PRID, &event.Type, &actor, &payloadJSON, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Actor = actor.String
+		if err = json.Unmarshal(payloadJSON, &event.Payload); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// FlagTeamArchived marks open and draft PRs authored by members of the given team
+// as left by the archived team, within a transaction.
+// Returns IDs of the flagged PRs, sorted.
+func (p *PullRequestRepository) FlagTeamArchived(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error) {
+	query := `
+			UPDATE pull_requests as pr
+			SET archived_team_id = $1
+			FROM users as u
+			WHERE u.id = pr.author_id
+				AND u.team_id = $1
+				AND pr.status IN ('OPEN', 'DRAFT')
+			RETURNING pr.id`
+
+	rows, err := tx.QueryContext(ctx, query, teamID)
+	if err != nil {
+		p.logger.Error("DB error on PR archived team update",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	prIDs, err := scanIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(prIDs)
+
+	return prIDs, nil
+}
+
+// FlagTeamReviewsArchived marks current review assignments of members of the given team
+// with the team, so the team is still referenced after the members are detached from it.
+func (p *PullRequestRepository) FlagTeamReviewsArchived(ctx context.Context, tx *sql.Tx, teamID int64) error {
+	query := `
+			UPDATE pr_reviewers as r
+			SET archived_team_id = $1
+			FROM users as u
+			WHERE u.id = r.user_id
+				AND u.team_id = $1
+				AND r.unassigned_at IS NULL`
+
+	_, err := tx.ExecContext(ctx, query, teamID)
+	if err != nil {
+		p.logger.Error("DB error on review archived team update",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return err
+	}
+
+	return nil
+}
+
+// CountOpenReviewsByTeam returns the number of current review assignments on OPEN PRs that reference
+// the given team: the author or the reviewer is its member, or the PR or the assignment was flagged
+// when the team was archived.
+func (p *PullRequestRepository) CountOpenReviewsByTeam(ctx context.Context, tx *sql.Tx, teamID int64) (int, error) {
+	query := `
+			SELECT COUNT(*)
+			FROM pr_reviewers as r
+			JOIN pull_requests as pr ON pr.id = r.pr_id
+			JOIN users as author ON author.id = pr.author_id
+			JOIN users as reviewer ON reviewer.id = r.user_id
+			WHERE r.unassigned_at IS NULL
+				AND pr.status = 'OPEN'
+				AND (pr.archived_team_id = $1 OR r.archived_team_id = $1 OR author.team_id = $1 OR reviewer.team_id = $1)`
+
+	var count int
+	err := tx.QueryRowContext(ctx, query, teamID).Scan(&count)
+	if err != nil {
+		p.logger.Error("DB error on team open reviews count",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetOrphanedPRs returns open and draft PRs whose author's team was archived while they were open,
+// oldest first. PRs of deleted teams are not flagged anymore.
+func (p *PullRequestRepository) GetOrphanedPRs(ctx context.Context) ([]domain.OrphanedPR, error) {
+	query := `
+			SELECT pr.id, pr.name, pr.author_id, pr.status, t.name
+			FROM pull_requests as pr
+			JOIN teams as t ON t.id = pr.archived_team_id
+			WHERE pr.status IN ('OPEN', 'DRAFT')
+			ORDER BY pr.created_at, pr.id`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		p.logger.Error("DB error on orphaned PRs select", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var prs []domain.OrphanedPR
+	for rows.Next() {
+		var pr domain.OrphanedPR
+		err := rows.Scan(&pr.PRID, &pr.PRName, &pr.AuthorID, &pr.Status, &pr.ArchivedTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		prs = append(prs, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+// scanIDs reads a single string column, e.g. IDs of pull requests
+func scanIDs(rows *sql.Rows) ([]string, error) {
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -9,17 +9,22 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
+var reviewerColumns = []string{"user_id", "source", "decision", "decision_text", "decided_at"}
+
+var declineColumns = []string{"user_id", "reason", "declined_at"}
+
 func TestPullRequestRepository_Create(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
 
-	pr := &domain.PullRequest{ID: "test-pr-id", Name: "My-test-PR_wow", AuthorID: "ramadan", Status: "OPEN"}
+	pr := &domain.PullRequest{ID: "test-pr-id", Name: "My-test-PR_wow", AuthorID: "ramadan", Status: "OPEN", Labels: []string{"sql"}}
 	now := time.Now()
 
 	mock.ExpectBegin()
@@ -27,7 +32,7 @@ func TestPullRequestRepository_Create(t *testing.T) {
 
 	// Correct insert
 	mock.ExpectQuery(`INSERT INTO pull_requests`).
-		WithArgs(pr.ID, pr.Name, pr.AuthorID, pr.Status).
+		WithArgs(pr.ID, pr.Name, pr.AuthorID, pr.Status, pq.Array(pr.Labels)).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
 
 	err := repo.Create(context.Background(), tx, pr)
@@ -37,7 +42,7 @@ func TestPullRequestRepository_Create(t *testing.T) {
 
 	// Case with error
 	mock.ExpectQuery(`INSERT INTO pull_requests`).
-		WithArgs(pr.ID, pr.Name, pr.AuthorID, pr.Status).
+		WithArgs(pr.ID, pr.Name, pr.AuthorID, pr.Status, pq.Array(pr.Labels)).
 		WillReturnError(errors.New("db error"))
 
 	err = repo.Create(context.Background(), tx, pr)
@@ -95,25 +100,33 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 	defer db.Close()
 	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
 	prID := "pr-1"
+	declinedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	// Case: row found, merged_at already not nil, reviewers returned
-	// GetByID executes 2 queries
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).
+	// Case: row found, merged_at already not nil, reviewers and declines returned
+	// GetByID executes 3 queries
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests`).
+		WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "archived_team_id"}).
+			AddRow(prID, "GetByID-PR", "admin-ramadan", "OPEN", time.Now(), time.Now(), "{backend,sql}", nil))
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}).
-			AddRow(prID, "GetByID-PR", "admin-ramadan", "OPEN", time.Now(), time.Now()))
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).AddRow("junior-dev", "TEAM", nil, "", nil).AddRow("middle-dev", "FALLBACK", nil, "", nil))
+	mock.ExpectQuery(`SELECT user_id, reason, declined_at FROM review_declines WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("junior-dev").AddRow("middle-dev"))
+		WillReturnRows(sqlmock.NewRows(declineColumns).AddRow("senior-dev", "no context", declinedAt))
 
 	pr, err := repo.GetByID(context.Background(), prID)
 	require.NoError(t, err)
 	assert.Equal(t, prID, pr.ID)
 	assert.Equal(t, []string{"junior-dev", "middle-dev"}, pr.ReviewersIDs)
+	assert.Equal(t, []domain.ReviewDecline{{UserID: "senior-dev", Reason: "no context", DeclinedAt: declinedAt}}, pr.Declines)
+	assert.Equal(t, []string{"middle-dev"}, pr.ReviewersIDsBySource(domain.SourceFallback))
+	assert.Equal(t, []string{"backend", "sql"}, pr.Labels)
 	assert.True(t, pr.MergedAt != nil)
+	assert.Zero(t, pr.ArchivedTeamID)
 
 	// Case: PR not found
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests`).
 		WithArgs("nil").WillReturnError(sql.ErrNoRows)
 
 	pr, err = repo.GetByID(context.Background(), "nil")
@@ -121,11 +134,11 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 	assert.Nil(t, pr)
 
 	// Error during fetching reviewers
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}).
-			AddRow(prID, "PR", "u1", "OPEN", time.Now(), nil))
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "archived_team_id"}).
+			AddRow(prID, "PR", "u1", "OPEN", time.Now(), nil, "{}", nil))
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
 		WillReturnError(errors.New("error reviewers"))
 
@@ -133,36 +146,45 @@ func TestPullRequestRepository_GetByID(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestPullRequestRepository_getReviewerIDs(t *testing.T) {
+func TestPullRequestRepository_getReviewers(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
 	prID := "pr-test"
+	decidedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	// reviewers correct
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	// reviewers correct, user1 has approved
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user0").AddRow("user1"))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).
+			AddRow("user0", "TEAM", nil, "", nil).
+			AddRow("user1", "FALLBACK", "APPROVED", "LGTM", decidedAt))
 
-	ids, err := repo.getReviewerIDs(context.Background(), prID)
+	pr := &domain.PullRequest{ID: prID}
+	err := repo.getReviewers(context.Background(), pr)
 	require.NoError(t, err)
-	assert.Equal(t, []string{"user0", "user1"}, ids)
+	assert.Equal(t, []string{"user0", "user1"}, pr.ReviewersIDs)
+	assert.Equal(t, map[string]domain.ReviewerSource{"user0": domain.SourceTeam, "user1": domain.SourceFallback}, pr.ReviewerSources)
+	assert.Equal(t, map[string]domain.ReviewDecision{
+		"user1": {Decision: domain.DecisionApproved, Text: "LGTM", DecidedAt: decidedAt},
+	}, pr.Decisions)
 
 	// no reviewers => just empty list
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs("empty").
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns))
 
-	ids, err = repo.getReviewerIDs(context.Background(), "empty")
+	pr = &domain.PullRequest{ID: "empty"}
+	err = repo.getReviewers(context.Background(), pr)
 	require.NoError(t, err)
-	assert.Empty(t, ids)
+	assert.Empty(t, pr.ReviewersIDs)
 
 	// Specific error during rows.Scan
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(nil))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).AddRow(nil, "TEAM", nil, "", nil))
 
-	_, err = repo.getReviewerIDs(context.Background(), prID)
+	err = repo.getReviewers(context.Background(), &domain.PullRequest{ID: prID})
 	assert.Error(t, err)
 }
 
@@ -177,35 +199,52 @@ func TestPRRepo_GetByIDForUpdate(t *testing.T) {
 	tx, _ := db.Begin()
 
 	// PR found and reviewers returned
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests WHERE id = \$1 FOR UPDATE`).
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests WHERE id = \$1 FOR UPDATE`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}).AddRow(prID, "Feature", "user-1", "OPEN", now, nil))
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user-3"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "archived_team_id"}).AddRow(prID, "Feature", "user-1", "OPEN", now, nil, "{}", 3))
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).AddRow("user-3", "TEAM", nil, "", nil))
+	mock.ExpectQuery(`SELECT user_id, reason, declined_at FROM review_declines WHERE pr_id =`).WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows(declineColumns).AddRow("user-2", "overloaded", now))
 
 	pr, err := repo.GetByIDForUpdate(context.Background(), tx, prID)
 	require.NoError(t, err)
 	assert.Equal(t, []string{"user-3"}, pr.ReviewersIDs)
+	assert.Equal(t, []string{"user-2"}, pr.DeclinedIDs())
+	assert.Equal(t, int64(3), pr.ArchivedTeamID)
 
 	// PR not found
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests WHERE id = \$1 FOR UPDATE`).
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests WHERE id = \$1 FOR UPDATE`).
 		WithArgs("not-found").WillReturnError(sql.ErrNoRows)
 	pr, err = repo.GetByIDForUpdate(context.Background(), tx, "not-found")
 	assert.ErrorIs(t, err, domain.ErrNotFound)
 	assert.Nil(t, pr)
 
-	// getReviewerIDsTx error
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests WHERE id = \$1 FOR UPDATE`).
+	// getReviewersTx error
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests WHERE id = \$1 FOR UPDATE`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}).AddRow(prID, "Feature", "user-1", "OPEN", now, nil))
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "archived_team_id"}).AddRow(prID, "Feature", "user-1", "OPEN", now, nil, "{}", nil))
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnError(errors.New("fail getReviewerIDsTx"))
+		WillReturnError(errors.New("fail getReviewersTx"))
+	_, err = repo.GetByIDForUpdate(context.Background(), tx, prID)
+	assert.Error(t, err)
+
+	// getDeclinesTx error
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, archived_team_id FROM pull_requests WHERE id = \$1 FOR UPDATE`).
+		WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "archived_team_id"}).AddRow(prID, "Feature", "user-1", "OPEN", now, nil, "{}", nil))
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
+		WithArgs(prID).
+		WillReturnRows(sqlmock.NewRows(reviewerColumns))
+	mock.ExpectQuery(`FROM review_declines`).
+		WithArgs(prID).
+		WillReturnError(errors.New("fail getDeclinesTx"))
 	_, err = repo.GetByIDForUpdate(context.Background(), tx, prID)
 	assert.Error(t, err)
 }
 
-func TestPullRequestRepository_getReviewerIDsTx(t *testing.T) {
+func TestPullRequestRepository_getReviewersTx(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
@@ -215,29 +254,33 @@ func TestPullRequestRepository_getReviewerIDsTx(t *testing.T) {
 	tx, _ := db.Begin()
 
 	// reviewers correct
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user0").AddRow("user1"))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).AddRow("user0", "TEAM", nil, "", nil).AddRow("user1", "FALLBACK", nil, "", nil))
 
-	ids, err := repo.getReviewerIDsTx(context.Background(), tx, prID)
+	pr := &domain.PullRequest{ID: prID}
+	err := repo.getReviewersTx(context.Background(), tx, pr)
 	require.NoError(t, err)
-	assert.Equal(t, []string{"user0", "user1"}, ids)
+	assert.Equal(t, []string{"user0", "user1"}, pr.ReviewersIDs)
+	assert.Equal(t, map[string]domain.ReviewerSource{"user0": domain.SourceTeam, "user1": domain.SourceFallback}, pr.ReviewerSources)
+	assert.Empty(t, pr.Decisions)
 
 	// no reviewers => just empty list
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs("empty").
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns))
 
-	ids, err = repo.getReviewerIDsTx(context.Background(), tx, "empty")
+	pr = &domain.PullRequest{ID: "empty"}
+	err = repo.getReviewersTx(context.Background(), tx, pr)
 	require.NoError(t, err)
-	assert.Empty(t, ids)
+	assert.Empty(t, pr.ReviewersIDs)
 
 	// Specific error during rows.Scan
-	mock.ExpectQuery(`SELECT user_id FROM pr_reviewers WHERE pr_id =`).
+	mock.ExpectQuery(`SELECT user_id, source, decision, decision_text, decided_at FROM pr_reviewers WHERE pr_id =`).
 		WithArgs(prID).
-		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(nil))
+		WillReturnRows(sqlmock.NewRows(reviewerColumns).AddRow(nil, "TEAM", nil, "", nil))
 
-	_, err = repo.getReviewerIDsTx(context.Background(), tx, prID)
+	err = repo.getReviewersTx(context.Background(), tx, &domain.PullRequest{ID: prID})
 	assert.Error(t, err)
 }
 
@@ -250,14 +293,14 @@ func TestPRRepo_AddReviewer(t *testing.T) {
 	tx, _ := db.Begin()
 
 	// Successful insert
-	mock.ExpectExec(`INSERT INTO pr_reviewers`).WithArgs("pr-1", "user-1").WillReturnResult(sqlmock.NewResult(1, 1))
-	err := repo.AddReviewer(context.Background(), tx, "pr-1", "user-1")
+	mock.ExpectExec(`INSERT INTO pr_reviewers`).WithArgs("pr-1", "user-1", domain.SourceTeam).WillReturnResult(sqlmock.NewResult(1, 1))
+	err := repo.AddReviewer(context.Background(), tx, "pr-1", "user-1", domain.SourceTeam)
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Insert error
-	mock.ExpectExec(`INSERT INTO pr_reviewers`).WithArgs("pr-1", "user-2").WillReturnError(errors.New("fail"))
-	err = repo.AddReviewer(context.Background(), tx, "pr-1", "user-2")
+	mock.ExpectExec(`INSERT INTO pr_reviewers`).WithArgs("pr-1", "user-2", domain.SourceFallback).WillReturnError(errors.New("fail"))
+	err = repo.AddReviewer(context.Background(), tx, "pr-1", "user-2", domain.SourceFallback)
 	assert.Error(t, err)
 }
 
@@ -269,20 +312,113 @@ func TestPRRepo_RemoveReviewer(t *testing.T) {
 	mock.ExpectBegin()
 	tx, _ := db.Begin()
 
-	// successful delete
-	mock.ExpectExec(`DELETE FROM pr_reviewers`).WithArgs("pr-1", "user-0").WillReturnResult(sqlmock.NewResult(1, 1))
-	err := repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-0")
+	// the assignment is ended, not deleted
+	mock.ExpectExec(`UPDATE pr_reviewers SET unassigned_at = NOW\(\), unassign_reason = \$1 WHERE pr_id = \$2 AND user_id = \$3 AND unassigned_at IS NULL`).
+		WithArgs(domain.UnassignReassigned, "pr-1", "user-0").WillReturnResult(sqlmock.NewResult(1, 1))
+	err := repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-0", domain.UnassignReassigned)
 	require.NoError(t, err)
 
 	// No such reviewer
-	mock.ExpectExec(`DELETE FROM pr_reviewers`).WithArgs("pr-1", "user-1").WillReturnResult(sqlmock.NewResult(1, 0))
-	err = repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-1")
+	mock.ExpectExec(`UPDATE pr_reviewers`).WithArgs(domain.UnassignManual, "pr-1", "user-1").WillReturnResult(sqlmock.NewResult(1, 0))
+	err = repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-1", domain.UnassignManual)
+	assert.ErrorIs(t, err, domain.ErrNotAssigned)
+
+	// update error
+	mock.ExpectExec(`UPDATE pr_reviewers`).WithArgs(domain.UnassignClosed, "pr-1", "user-2").WillReturnError(errors.New("fail"))
+	err = repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-2", domain.UnassignClosed)
+	assert.Error(t, err)
+}
+
+func TestPRRepo_SetDecision(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	decidedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`UPDATE pr_reviewers SET decision = \$1, decision_text = \$2, decided_at = NOW\(\) WHERE pr_id = \$3 AND user_id = \$4 AND unassigned_at IS NULL`).
+		WithArgs(domain.DecisionChangesRequested, "fix tests", "pr-1", "u2").
+		WillReturnRows(sqlmock.NewRows([]string{"decided_at"}).AddRow(decidedAt))
+
+	decision := &domain.ReviewDecision{Decision: domain.DecisionChangesRequested, Text: "fix tests"}
+	err := repo.SetDecision(context.Background(), tx, "pr-1", "u2", decision)
+	require.NoError(t, err)
+	assert.Equal(t, decidedAt, decision.DecidedAt)
+
+	// User is not a reviewer of the PR
+	mock.ExpectQuery(`UPDATE pr_reviewers`).
+		WithArgs(domain.DecisionApproved, "", "pr-1", "u9").
+		WillReturnError(sql.ErrNoRows)
+	err = repo.SetDecision(context.Background(), tx, "pr-1", "u9", &domain.ReviewDecision{Decision: domain.DecisionApproved})
 	assert.ErrorIs(t, err, domain.ErrNotAssigned)
 
-	// delete error
-	mock.ExpectExec(`DELETE FROM pr_reviewers`).WithArgs("pr-1", "user-2").WillReturnError(errors.New("fail"))
-	err = repo.RemoveReviewer(context.Background(), tx, "pr-1", "user-2")
+	// Query error
+	mock.ExpectQuery(`UPDATE pr_reviewers`).
+		WithArgs(domain.DecisionApproved, "", "pr-1", "u2").
+		WillReturnError(errors.New("fail"))
+	err = repo.SetDecision(context.Background(), tx, "pr-1", "u2", &domain.ReviewDecision{Decision: domain.DecisionApproved})
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_AddDecline(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	declinedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`INSERT INTO review_declines \(pr_id, user_id, reason\)`).
+		WithArgs("pr-1", "u2", "conflict of interest").
+		WillReturnRows(sqlmock.NewRows([]string{"declined_at"}).AddRow(declinedAt))
+
+	decline := &domain.ReviewDecline{UserID: "u2", Reason: "conflict of interest"}
+	err := repo.AddDecline(context.Background(), tx, "pr-1", decline)
+	require.NoError(t, err)
+	assert.Equal(t, declinedAt, decline.DeclinedAt)
+
+	// Query error
+	mock.ExpectQuery(`INSERT INTO review_declines`).
+		WithArgs("pr-1", "u2", "conflict of interest").
+		WillReturnError(errors.New("fail"))
+	err = repo.AddDecline(context.Background(), tx, "pr-1", decline)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetAssignments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	assignedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	unassignedAt := assignedAt.Add(time.Hour)
+
+	mock.ExpectQuery(`SELECT user_id, source, assigned_at, unassigned_at, unassign_reason FROM pr_reviewers WHERE pr_id = \$1 ORDER BY assigned_at, id`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "source", "assigned_at", "unassigned_at", "unassign_reason"}).
+			AddRow("u2", "TEAM", assignedAt, unassignedAt, "DECLINED").
+			AddRow("u3", "TEAM", unassignedAt, nil, nil))
+
+	assignments, err := repo.GetAssignments(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewAssignment{
+		{UserID: "u2", Source: domain.SourceTeam, AssignedAt: assignedAt, UnassignedAt: &unassignedAt, UnassignReason: domain.UnassignDeclined},
+		{UserID: "u3", Source: domain.SourceTeam, AssignedAt: unassignedAt},
+	}, assignments)
+
+	// Query error
+	mock.ExpectQuery(`FROM pr_reviewers`).WithArgs("pr-1").WillReturnError(errors.New("fail"))
+	assignments, err = repo.GetAssignments(context.Background(), "pr-1")
 	assert.Error(t, err)
+	assert.Nil(t, assignments)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestPRRepo_GetPRsByReviewer(t *testing.T) {
@@ -291,29 +427,429 @@ func TestPRRepo_GetPRsByReviewer(t *testing.T) {
 	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
 	userID := "user-777"
 	now := time.Now()
+	columns := []string{"id", "name", "author_id", "status", "created_at", "merged_at", "labels", "decision", "decision_text", "decided_at"}
 
-	// Several PR returned
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}).
-			AddRow("pr-1", "pr-1", "user-1", "OPEN", now, nil).
-			AddRow("pr-2", "pr-2", "user-3", "MERGED", now, now))
+	// Several PR returned, the second one is approved
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, r.decision, r.decision_text, r.decided_at FROM pull_requests`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("pr-1", "pr-1", "user-1", "OPEN", now, nil, "{}", nil, "", nil).
+			AddRow("pr-2", "pr-2", "user-3", "MERGED", now, now, "{}", "APPROVED", "", now))
 
 	prs, err := repo.GetPRsByReviewer(context.Background(), userID)
 	require.NoError(t, err)
 	assert.Len(t, prs, 2)
 	assert.Equal(t, "pr-1", prs[0].ID)
+	assert.False(t, prs[0].HasDecision(userID))
 	assert.Equal(t, "pr-2", prs[1].ID)
+	assert.Equal(t, domain.DecisionApproved, prs[1].Decisions[userID].Decision)
 
 	// No such PR — QueryContext returns empty rows, not sql.ErrNoRows
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).WithArgs("nil").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "created_at", "merged_at"}))
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, r.decision, r.decision_text, r.decided_at FROM pull_requests`).WithArgs("nil").
+		WillReturnRows(sqlmock.NewRows(columns))
 	prs, err = repo.GetPRsByReviewer(context.Background(), "nil")
 	assert.NoError(t, err)
 	assert.Empty(t, prs)
 
 	// Query error
-	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests`).WithArgs(userID).
+	mock.ExpectQuery(`SELECT id, name, author_id, status, created_at, merged_at, labels, r.decision, r.decision_text, r.decided_at FROM pull_requests`).WithArgs(userID).
 		WillReturnError(errors.New("fail"))
 	_, err = repo.GetPRsByReviewer(context.Background(), userID)
 	assert.Error(t, err)
 }
+
+func TestPRRepo_GetUnderstaffedPRIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectQuery(`SELECT pr.id FROM pull_requests as pr .* WHERE pr.status = 'OPEN' AND \(SELECT COUNT\(\*\) FROM pr_reviewers .*\) < t.max_reviewers ORDER BY pr.created_at, pr.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("pr-1").AddRow("pr-2"))
+	ids, err := repo.GetUnderstaffedPRIDs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, ids)
+
+	// Query error
+	mock.ExpectQuery(`SELECT pr.id FROM pull_requests`).
+		WillReturnError(errors.New("fail"))
+	ids, err = repo.GetUnderstaffedPRIDs(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, ids)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetUnderstaffedPRIDsByTeam(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectQuery(`SELECT pr.id FROM pull_requests as pr .* WHERE pr.status = 'OPEN' AND t.id = \$1 AND`).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("pr-1"))
+	ids, err := repo.GetUnderstaffedPRIDsByTeam(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, ids)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_AddForcedMerge(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	merge := &domain.ForcedMerge{
+		PRID:            "pr-1",
+		ForcedBy:        "lead",
+		Reason:          "hotfix",
+		UnmetConditions: []string{"1 approvals required, 0 given"},
+	}
+
+	mock.ExpectQuery(`INSERT INTO forced_merges \(pr_id, forced_by, reason, unmet_conditions\)`).
+		WithArgs("pr-1", "lead", "hotfix", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, createdAt))
+
+	err := repo.AddForcedMerge(context.Background(), tx, merge)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), merge.ID)
+	assert.Equal(t, createdAt, merge.CreatedAt)
+
+	// Query error
+	mock.ExpectQuery(`INSERT INTO forced_merges`).
+		WillReturnError(errors.New("fail"))
+	err = repo.AddForcedMerge(context.Background(), tx, merge)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetForcedMerges(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, pr_id, forced_by, reason, unmet_conditions, created_at FROM forced_merges ORDER BY created_at DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pr_id", "forced_by", "reason", "unmet_conditions", "created_at"}).
+			AddRow(2, "pr-2", "lead", "hotfix", "{\"changes requested by u1\"}", createdAt).
+			AddRow(1, "pr-1", "lead", "release", "{}", createdAt))
+
+	merges, err := repo.GetForcedMerges(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ForcedMerge{
+		{ID: 2, PRID: "pr-2", ForcedBy: "lead", Reason: "hotfix", UnmetConditions: []string{"changes requested by u1"}, CreatedAt: createdAt},
+		{ID: 1, PRID: "pr-1", ForcedBy: "lead", Reason: "release", UnmetConditions: []string{}, CreatedAt: createdAt},
+	}, merges)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM forced_merges`).
+		WillReturnError(errors.New("qfail"))
+	merges, err = repo.GetForcedMerges(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, merges)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_AddEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	event := &domain.PREvent{
+		PRID:    "pr-1",
+		Type:    domain.EventReviewerAssigned,
+		Actor:   "lead",
+		Payload: map[string]any{"reviewer_id": "u2"},
+	}
+
+	mock.ExpectQuery(`INSERT INTO pr_events \(pr_id, type, actor, payload\)`).
+		WithArgs("pr-1", "REVIEWER_ASSIGNED", "lead", []byte(`{"reviewer_id":"u2"}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, createdAt))
+
+	err := repo.AddEvent(context.Background(), tx, event)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), event.ID)
+	assert.Equal(t, createdAt, event.CreatedAt)
+
+	// Unknown actor and no payload
+	mock.ExpectQuery(`INSERT INTO pr_events`).
+		WithArgs("pr-1", "MERGED", nil, []byte(`{}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(6, createdAt))
+
+	err = repo.AddEvent(context.Background(), tx, &domain.PREvent{PRID: "pr-1", Type: domain.EventMerged})
+	require.NoError(t, err)
+
+	// Query error
+	mock.ExpectQuery(`INSERT INTO pr_events`).
+		WillReturnError(errors.New("fail"))
+	err = repo.AddEvent(context.Background(), tx, event)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetEvents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, pr_id, type, actor, payload, created_at FROM pr_events WHERE pr_id = \$1 ORDER BY created_at, id`).
+		WithArgs("pr-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pr_id", "type", "actor", "payload", "created_at"}).
+			AddRow(1, "pr-1", "CREATED", "u1", []byte(`{"status":"OPEN"}`), createdAt).
+			AddRow(2, "pr-1", "MERGED", nil, []byte(`{"forced":false}`), createdAt))
+
+	events, err := repo.GetEvents(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PREvent{
+		{ID: 1, PRID: "pr-1", Type: domain.EventCreated, Actor: "u1", Payload: map[string]any{"status": "OPEN"}, CreatedAt: createdAt},
+		{ID: 2, PRID: "pr-1", Type: domain.EventMerged, Payload: map[string]any{"forced": false}, CreatedAt: createdAt},
+	}, events)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM pr_events`).
+		WithArgs("pr-1").
+		WillReturnError(errors.New("qfail"))
+	events, err = repo.GetEvents(context.Background(), "pr-1")
+	assert.Error(t, err)
+	assert.Nil(t, events)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetPendingReviews(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	assignedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	overdueAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT r.pr_id, pr.name, r.user_id, t.name, r.assigned_at, r.overdue_at, t.review_sla_hours, t.sla_reassign_hours FROM pr_reviewers as r .* WHERE r.unassigned_at IS NULL AND r.decision IS NULL AND pr.status = 'OPEN' AND t.review_sla_hours > 0 ORDER BY r.assigned_at, r.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"pr_id", "name", "user_id", "team", "assigned_at", "overdue_at", "review_sla_hours", "sla_reassign_hours"}).
+			AddRow("pr-1", "Fix", "u2", "backend", assignedAt, overdueAt, 24, 48).
+			AddRow("pr-2", "Feature", "u3", "backend", assignedAt, nil, 24, 0))
+
+	reviews, err := repo.GetPendingReviews(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PendingReview{
+		{PRID: "pr-1", PRName: "Fix", ReviewerID: "u2", TeamName: "backend", AssignedAt: assignedAt, OverdueAt: &overdueAt, ReviewSLAHours: 24, SLAReassignHours: 48},
+		{PRID: "pr-2", PRName: "Feature", ReviewerID: "u3", TeamName: "backend", AssignedAt: assignedAt, ReviewSLAHours: 24},
+	}, reviews)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM pr_reviewers`).
+		WillReturnError(errors.New("qfail"))
+	reviews, err = repo.GetPendingReviews(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, reviews)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_MarkOverdue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	overdueAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`UPDATE pr_reviewers SET overdue_at = \$1 WHERE pr_id = \$2 AND user_id = \$3 AND unassigned_at IS NULL AND overdue_at IS NULL`).
+		WithArgs(overdueAt, "pr-1", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.MarkOverdue(context.Background(), tx, "pr-1", "u2", overdueAt)
+	require.NoError(t, err)
+
+	// Already marked or unassigned meanwhile
+	mock.ExpectExec(`UPDATE pr_reviewers SET overdue_at`).
+		WithArgs(overdueAt, "pr-1", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.MarkOverdue(context.Background(), tx, "pr-1", "u2", overdueAt)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetRemindableAssignments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	assignedBefore := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	assignedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	remindedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT r.pr_id, r.user_id, r.assigned_at, r.reminder_count, r.last_reminded_at FROM pr_reviewers as r .* WHERE r.unassigned_at IS NULL AND r.decision IS NULL AND pr.status = 'OPEN' AND r.assigned_at <= \$1 AND u.is_active = true AND NOT EXISTS \(.*user_absences.*\) ORDER BY r.user_id, r.assigned_at, r.id`).
+		WithArgs(assignedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"pr_id", "user_id", "assigned_at", "reminder_count", "last_reminded_at"}).
+			AddRow("pr-1", "u2", assignedAt, 1, remindedAt).
+			AddRow("pr-2", "u3", assignedAt, 0, nil))
+
+	assignments, err := repo.GetRemindableAssignments(context.Background(), assignedBefore)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PendingAssignment{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: assignedAt, RemindersSent: 1, LastRemindedAt: &remindedAt},
+		{PRID: "pr-2", ReviewerID: "u3", AssignedAt: assignedAt},
+	}, assignments)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM pr_reviewers`).
+		WithArgs(assignedBefore).
+		WillReturnError(errors.New("qfail"))
+	assignments, err = repo.GetRemindableAssignments(context.Background(), assignedBefore)
+	assert.Error(t, err)
+	assert.Nil(t, assignments)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_MarkReminded(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	remindedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`UPDATE pr_reviewers SET reminder_count = reminder_count \+ 1, last_reminded_at = \$1 WHERE pr_id = \$2 AND user_id = \$3 AND unassigned_at IS NULL AND reminder_count = \$4`).
+		WithArgs(remindedAt, "pr-1", "u2", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.MarkReminded(context.Background(), tx, "pr-1", "u2", 1, remindedAt)
+	require.NoError(t, err)
+
+	// Reminded by another instance or unassigned meanwhile
+	mock.ExpectExec(`UPDATE pr_reviewers SET reminder_count`).
+		WithArgs(remindedAt, "pr-1", "u2", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.MarkReminded(context.Background(), tx, "pr-1", "u2", 1, remindedAt)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_UnmarkReminded(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+	lastRemindedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`UPDATE pr_reviewers SET reminder_count = \$1, last_reminded_at = \$2 WHERE pr_id = \$3 AND user_id = \$4 AND unassigned_at IS NULL AND reminder_count = \$1 \+ 1`).
+		WithArgs(1, &lastRemindedAt, "pr-1", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.UnmarkReminded(context.Background(), tx, "pr-1", "u2", 1, &lastRemindedAt)
+	require.NoError(t, err)
+
+	// Reminded again or unassigned meanwhile
+	mock.ExpectExec(`UPDATE pr_reviewers SET reminder_count`).
+		WithArgs(0, nil, "pr-1", "u2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.UnmarkReminded(context.Background(), tx, "pr-1", "u2", 0, nil)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_FlagTeamArchived(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`UPDATE pull_requests as pr SET archived_team_id = \$1 FROM users as u WHERE u.id = pr.author_id AND u.team_id = \$1 AND pr.status IN \('OPEN', 'DRAFT'\) RETURNING pr.id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("pr-2").AddRow("pr-1"))
+
+	prIDs, err := repo.FlagTeamArchived(context.Background(), tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, prIDs)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_FlagTeamReviewsArchived(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`UPDATE pr_reviewers as r SET archived_team_id = \$1 FROM users as u WHERE u.id = r.user_id AND u.team_id = \$1 AND r.unassigned_at IS NULL`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.FlagTeamReviewsArchived(context.Background(), tx, 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_CountOpenReviewsByTeam(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM pr_reviewers as r .* WHERE r.unassigned_at IS NULL AND pr.status = 'OPEN' AND \(pr.archived_team_id = \$1 OR r.archived_team_id = \$1 OR author.team_id = \$1 OR reviewer.team_id = \$1\)`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountOpenReviewsByTeam(context.Background(), tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Query error
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM pr_reviewers`).
+		WithArgs(int64(1)).
+		WillReturnError(errors.New("qfail"))
+
+	_, err = repo.CountOpenReviewsByTeam(context.Background(), tx, 1)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPRRepo_GetOrphanedPRs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PullRequestRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectQuery(`SELECT pr.id, pr.name, pr.author_id, pr.status, t.name FROM pull_requests as pr JOIN teams as t ON t.id = pr.archived_team_id WHERE pr.status IN \('OPEN', 'DRAFT'\) ORDER BY pr.created_at, pr.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "author_id", "status", "team"}).
+			AddRow("pr-1", "Fix", "u1", "OPEN", "backend").
+			AddRow("pr-2", "WIP", "u2", "DRAFT", "backend"))
+
+	prs, err := repo.GetOrphanedPRs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.OrphanedPR{
+		{PRID: "pr-1", PRName: "Fix", AuthorID: "u1", Status: domain.StatusOpen, ArchivedTeam: "backend"},
+		{PRID: "pr-2", PRName: "WIP", AuthorID: "u2", Status: domain.StatusDraft, ArchivedTeam: "backend"},
+	}, prs)
+
+	// Query error
+	mock.ExpectQuery(`SELECT .* FROM pull_requests`).
+		WillReturnError(errors.New("qfail"))
+	prs, err = repo.GetOrphanedPRs(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, prs)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -18,11 +18,13 @@ func NewStatsRepository(db *sql.DB) *StatsRepository {
 func (r *StatsRepository) GetGeneralStats(ctx context.Context) (*domain.Stats, error) {
 	const query = `
 		SELECT
-			(SELECT COUNT(*) FROM teams)                                  AS total_teams,
+			(SELECT COUNT(*) FROM teams WHERE archived_at IS NULL)        AS total_teams,
 			(SELECT COUNT(*) FROM users)                                  AS total_users,
 			(SELECT COUNT(*) FROM pull_requests)                          AS total_prs,
 			(SELECT COUNT(*) FROM pull_requests WHERE status = 'OPEN')   AS open_prs,
-			(SELECT COUNT(*) FROM pull_requests WHERE status = 'MERGED') AS merged_prs`
+			(SELECT COUNT(*) FROM pull_requests WHERE status = 'MERGED') AS merged_prs,
+			(SELECT COUNT(*) FROM pull_requests WHERE status = 'DRAFT')  AS draft_prs,
+			(SELECT COUNT(*) FROM pull_requests WHERE status = 'CLOSED') AS closed_prs`
 
 	stats := &domain.Stats{}
 	err := r.db.QueryRowContext(ctx, query).Scan(
@@ -31,6 +33,8 @@ func (r *StatsRepository) GetGeneralStats(ctx context.Context) (*domain.Stats, e
 		&stats.TotalPRs,
 		&stats.OpenPRs,
 		&stats.MergedPRs,
+		&stats.DraftPRs,
+		&stats.ClosedPRs,
 	)
 	if err != nil {
 		return nil, err
@@ -43,7 +47,7 @@ func (r *StatsRepository) GetReviewers(ctx context.Context) ([]domain.UserReview
 	query := `
         SELECT u.id, u.username, COUNT(pr.pr_id) as review_count
         FROM users u
-        LEFT JOIN pr_reviewers pr ON u.id = pr.user_id
+        LEFT JOIN pr_reviewers pr ON u.id = pr.user_id AND pr.unassigned_at IS NULL
         GROUP BY u.id, u.username
         HAVING COUNT(pr.pr_id) > 0
         ORDER BY review_count DESC
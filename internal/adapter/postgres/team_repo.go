@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
@@ -23,9 +25,14 @@ func NewTeamRepository(db *sql.DB, logger *zap.Logger) *TeamRepository {
 // Create inserts a new team into the database.
 // Returns ErrTeamExists if a team with the same name already exists.
 func (t *TeamRepository) Create(ctx context.Context, tx *sql.Tx, team *domain.Team) error {
-	query := "INSERT INTO teams (name) VALUES ($1) RETURNING id"
+	query := `
+			INSERT INTO teams (name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested,
+				review_sla_hours, sla_reassign_hours)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`
 
-	err := tx.QueryRowContext(ctx, query, team.Name).Scan(&team.ID)
+	err := tx.QueryRowContext(ctx, query, team.Name, team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers,
+		team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours).Scan(&team.ID)
 	if err != nil {
 		if isUniqueViolationError(err) {
 			return domain.ErrTeamExists
@@ -38,16 +45,48 @@ func (t *TeamRepository) Create(ctx context.Context, tx *sql.Tx, team *domain.Te
 	return nil
 }
 
-// GetByName retrieves a team by name including all team members.
+// Update modifies team settings: the reviewer assignment strategy, reviewers policy, merge policy and review SLA.
 // Returns ErrNotFound if the team doesn't exist.
+func (t *TeamRepository) Update(ctx context.Context, tx *sql.Tx, team *domain.Team) error {
+	query := `
+			UPDATE teams
+			SET assignment_strategy = $1, min_reviewers = $2, max_reviewers = $3,
+				min_approvals = $4, allow_changes_requested = $5,
+				review_sla_hours = $6, sla_reassign_hours = $7
+			WHERE id = $8`
+
+	res, err := tx.ExecContext(ctx, query, team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers,
+		team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours, team.ID)
+	if err != nil {
+		t.logger.Error("DB error on Team update",
+			zap.Error(err),
+			zap.Int64("team_id", team.ID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByName retrieves a team by name including all team members and fallback teams.
+// Returns ErrNotFound if the team doesn't exist or is archived.
 func (t *TeamRepository) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
 	query := `
-			SELECT id, name
+			SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested,
+				review_sla_hours, sla_reassign_hours
 			FROM teams 
-			WHERE name = $1`
+			WHERE name = $1 AND archived_at IS NULL`
 
 	var team domain.Team
-	err := t.db.QueryRowContext(ctx, query, teamName).Scan(&team.ID, &team.Name)
+	err := t.db.QueryRowContext(ctx, query, teamName).Scan(&team.ID, &team.Name, &team.AssignmentStrategy, &team.MinReviewers, &team.MaxReviewers,
+		&team.MinApprovals, &team.AllowChangesRequested, &team.ReviewSLAHours, &team.SLAReassignHours)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -67,9 +106,199 @@ func (t *TeamRepository) GetByName(ctx context.Context, teamName string) (*domai
 
 	team.Members = members
 
+	// Get fallback teams names
+	fallbacks, err := t.GetFallbackTeams(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fallbacks {
+		team.FallbackTeams = append(team.FallbackTeams, f.Name)
+	}
+
+	return &team, nil
+}
+
+// GetByID retrieves team settings by ID. Members are not loaded,
+// use GetByName to get the full team roster.
+// Unlike GetByName, archived teams are returned too: PRs of their former members keep the merge policy.
+// Returns ErrNotFound if the team doesn't exist.
+func (t *TeamRepository) GetByID(ctx context.Context, teamID int64) (*domain.Team, error) {
+	query := `
+			SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested,
+				review_sla_hours, sla_reassign_hours, archived_at
+			FROM teams
+			WHERE id = $1`
+
+	var team domain.Team
+	var archivedAt sql.NullTime
+	err := t.db.QueryRowContext(ctx, query, teamID).Scan(&team.ID, &team.Name, &team.AssignmentStrategy, &team.MinReviewers, &team.MaxReviewers,
+		&team.MinApprovals, &team.AllowChangesRequested, &team.ReviewSLAHours, &team.SLAReassignHours, &archivedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		t.logger.Error("DB error on Team select",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	if archivedAt.Valid {
+		team.ArchivedAt = &archivedAt.Time
+	}
+
 	return &team, nil
 }
 
+// GetByNameForUpdate retrieves team settings by name within a transaction and locks the team row.
+// Unlike GetByName, archived teams are returned too. Members are not loaded.
+// Returns ErrNotFound if the team doesn't exist.
+func (t *TeamRepository) GetByNameForUpdate(ctx context.Context, tx *sql.Tx, teamName string) (*domain.Team, error) {
+	query := `
+			SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested,
+				review_sla_hours, sla_reassign_hours, archived_at
+			FROM teams
+			WHERE name = $1
+			FOR UPDATE`
+
+	var team domain.Team
+	var archivedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, teamName).Scan(&team.ID, &team.Name, &team.AssignmentStrategy, &team.MinReviewers, &team.MaxReviewers,
+		&team.MinApprovals, &team.AllowChangesRequested, &team.ReviewSLAHours, &team.SLAReassignHours, &archivedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		t.logger.Error("DB error on Team select for update",
+			zap.Error(err),
+			zap.String("team_name", teamName))
+		return nil, err
+	}
+	if archivedAt.Valid {
+		team.ArchivedAt = &archivedAt.Time
+	}
+
+	return &team, nil
+}
+
+// Archive marks the team archived within a transaction, hiding it from lookups.
+// Members are not touched.
+// Returns the archiving time, or ErrNotFound if the team doesn't exist or is already archived.
+func (t *TeamRepository) Archive(ctx context.Context, tx *sql.Tx, teamID int64) (time.Time, error) {
+	query := `
+			UPDATE teams
+			SET archived_at = NOW()
+			WHERE id = $1 AND archived_at IS NULL
+			RETURNING archived_at`
+
+	var archivedAt time.Time
+	err := tx.QueryRowContext(ctx, query, teamID).Scan(&archivedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, domain.ErrNotFound
+		}
+		t.logger.Error("DB error on Team archive",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return time.Time{}, err
+	}
+
+	return archivedAt, nil
+}
+
+// Delete removes the team within a transaction, archived or not.
+// Remaining members are detached from it, fallback links to and from it are removed.
+// Returns ErrNotFound if the team doesn't exist.
+func (t *TeamRepository) Delete(ctx context.Context, tx *sql.Tx, teamID int64) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM teams WHERE id = $1", teamID)
+	if err != nil {
+		t.logger.Error("DB error on Team delete",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetFallbackTeams retrieves settings of the fallback teams of the given team in priority order.
+// Archived fallback teams are skipped. Members are not loaded.
+func (t *TeamRepository) GetFallbackTeams(ctx context.Context, teamID int64) ([]*domain.Team, error) {
+	query := `
+			SELECT t.id, t.name, t.assignment_strategy, t.min_reviewers, t.max_reviewers, t.min_approvals, t.allow_changes_requested,
+				t.review_sla_hours, t.sla_reassign_hours
+			FROM team_fallbacks as f
+			JOIN teams as t ON t.id = f.fallback_team_id
+			WHERE f.team_id = $1 AND t.archived_at IS NULL
+			ORDER BY f.position`
+
+	rows, err := t.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		t.logger.Error("DB error on team_fallbacks select",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var teams []*domain.Team
+	for rows.Next() {
+		var team domain.Team
+		err := rows.Scan(&team.ID, &team.Name, &team.AssignmentStrategy, &team.MinReviewers, &team.MaxReviewers,
+			&team.MinApprovals, &team.AllowChangesRequested, &team.ReviewSLAHours, &team.SLAReassignHours)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, &team)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// SetFallbackTeams replaces the fallback teams of the given team within a transaction.
+// The order of fallbackTeamIDs defines their priority.
+func (t *TeamRepository) SetFallbackTeams(ctx context.Context, tx *sql.Tx, teamID int64, fallbackTeamIDs []int64) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM team_fallbacks WHERE team_id = $1", teamID)
+	if err != nil {
+		t.logger.Error("DB error on team_fallbacks delete",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return err
+	}
+
+	query := `
+			INSERT INTO team_fallbacks (team_id, fallback_team_id, position)
+			VALUES ($1, $2, $3)`
+
+	for i, fallbackID := range fallbackTeamIDs {
+		_, err = tx.ExecContext(ctx, query, teamID, fallbackID, i)
+		if err != nil {
+			t.logger.Error("DB error on team_fallbacks insert",
+				zap.Error(err),
+				zap.Int64("team_id", teamID),
+				zap.Int64("fallback_team_id", fallbackID))
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t *TeamRepository) GetTeamNameByID(ctx context.Context, teamID int64) (string, error) {
 	query := `
 			SELECT name
@@ -95,7 +324,7 @@ func (t *TeamRepository) GetTeamNameByID(ctx context.Context, teamID int64) (str
 // getTeamMembers retrieves all members of a team by team ID.
 func (t *TeamRepository) getTeamMembers(ctx context.Context, teamID int64) ([]domain.User, error) {
 	query := `
-			SELECT id, username, is_active, team_id 
+			SELECT id, username, is_active, team_id, max_open_reviews, tags
 			FROM users
 			WHERE team_id = $1
 			`
@@ -109,7 +338,7 @@ func (t *TeamRepository) getTeamMembers(ctx context.Context, teamID int64) ([]do
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamID)
+		err := rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamID, &user.MaxOpenReviews, pq.Array(&user.Tags))
 		if err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
@@ -15,15 +16,20 @@ import (
 	"go.uber.org/zap"
 )
 
+var teamColumns = []string{"id", "name", "assignment_strategy", "min_reviewers", "max_reviewers", "min_approvals", "allow_changes_requested", "review_sla_hours", "sla_reassign_hours"}
+
 func TestTeamRepository_Create(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &TeamRepository{db: db, logger: zap.NewNop()}
 
 	team := &domain.Team{
-		ID:      0,
-		Name:    "team-1",
-		Members: nil,
+		ID:                 0,
+		Name:               "team-1",
+		AssignmentStrategy: domain.StrategyRandom,
+		MinReviewers:       1,
+		MaxReviewers:       3,
+		Members:            nil,
 	}
 
 	mock.ExpectBegin()
@@ -31,7 +37,7 @@ func TestTeamRepository_Create(t *testing.T) {
 
 	// Correct insert
 	mock.ExpectQuery(`INSERT INTO teams`).
-		WithArgs(team.Name).
+		WithArgs(team.Name, team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 	err := repo.Create(context.Background(), tx, team)
@@ -40,7 +46,7 @@ func TestTeamRepository_Create(t *testing.T) {
 
 	// Case with error
 	mock.ExpectQuery(`INSERT INTO teams`).
-		WithArgs(team.Name).
+		WithArgs(team.Name, team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours).
 		WillReturnError(errors.New("db error"))
 
 	err = repo.Create(context.Background(), tx, team)
@@ -50,7 +56,7 @@ func TestTeamRepository_Create(t *testing.T) {
 	uniqErr := &pq.Error{Code: pgerrcode.UniqueViolation}
 
 	mock.ExpectQuery(`INSERT INTO teams`).
-		WithArgs(team.Name).
+		WithArgs(team.Name, team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours).
 		WillReturnError(uniqErr)
 
 	err = repo.Create(context.Background(), tx, team)
@@ -70,55 +76,317 @@ func TestTeamRepository_GetByName(t *testing.T) {
 	teamName := "team-1"
 
 	// Team found
-	mock.ExpectQuery(`SELECT id, name FROM teams`).
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours FROM teams WHERE name = \$1 AND archived_at IS NULL`).
 		WithArgs(teamName).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(teamID, teamName))
+		WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(teamID, teamName, "ROUND_ROBIN", 1, 3, 0, false, 0, 0))
 
 	// Two members
-	mock.ExpectQuery(`SELECT id, username, is_active, team_id FROM users`).
+	mock.ExpectQuery(`SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users`).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id", "max_open_reviews", "tags"}).
+			AddRow("user-1", "A", true, teamID, 0, "{go,sql}").
+			AddRow("user-2", "B", false, teamID, 3, "{}"))
+
+	// One fallback team
+	mock.ExpectQuery(`SELECT t.id, t.name, t.assignment_strategy, t.min_reviewers, t.max_reviewers, t.min_approvals, t.allow_changes_requested, t.review_sla_hours, t.sla_reassign_hours FROM team_fallbacks`).
 		WithArgs(teamID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id"}).
-			AddRow("user-1", "A", true, teamID).
-			AddRow("user-2", "B", false, teamID))
+		WillReturnRows(sqlmock.NewRows(teamColumns).
+			AddRow(43, "team-2", "RANDOM", 0, 2, 0, false, 0, 0))
 
 	result, err := repo.GetByName(context.Background(), teamName)
 	require.NoError(t, err)
 	assert.Equal(t, teamID, result.ID)
 	assert.Equal(t, "team-1", result.Name)
+	assert.Equal(t, domain.StrategyRoundRobin, result.AssignmentStrategy)
+	assert.Equal(t, 1, result.MinReviewers)
+	assert.Equal(t, 3, result.MaxReviewers)
 	assert.Len(t, result.Members, 2)
 	assert.Equal(t, "user-1", result.Members[0].ID)
 	assert.Equal(t, "B", result.Members[1].Name)
+	assert.Equal(t, []string{"team-2"}, result.FallbackTeams)
 
 	// Team not found
-	mock.ExpectQuery(`SELECT id, name FROM teams`).WithArgs("missing-team").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours FROM teams`).WithArgs("missing-team").WillReturnError(sql.ErrNoRows)
 	res, err := repo.GetByName(context.Background(), "missing-team")
 	assert.ErrorIs(t, err, domain.ErrNotFound)
 	assert.Nil(t, res)
 
 	// Query error
-	mock.ExpectQuery(`SELECT id, name FROM teams`).WithArgs("fail-team").WillReturnError(errors.New("DB fail"))
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours FROM teams`).WithArgs("fail-team").WillReturnError(errors.New("DB fail"))
 	res, err = repo.GetByName(context.Background(), "fail-team")
 	assert.Error(t, err)
 	assert.Nil(t, res)
 
 	// Error in getTeamMembers
-	mock.ExpectQuery(`SELECT id, name FROM teams`).WithArgs("error-mem").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(teamID, "error-mem"))
-	mock.ExpectQuery(`SELECT id, username, is_active, team_id FROM users`).WithArgs(teamID).WillReturnError(errors.New("user query error"))
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours FROM teams`).WithArgs("error-mem").WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(teamID, "error-mem", "RANDOM", 0, 2, 0, false, 0, 0))
+	mock.ExpectQuery(`SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users`).WithArgs(teamID).WillReturnError(errors.New("user query error"))
 	res, err = repo.GetByName(context.Background(), "error-mem")
 	assert.Error(t, err)
 	assert.Nil(t, res)
 
 	// No members in the team
-	mock.ExpectQuery(`SELECT id, name FROM teams`).
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours FROM teams`).
 		WithArgs("lonely-team").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(100, "lonely-team"))
-	mock.ExpectQuery(`SELECT id, username, is_active, team_id FROM users`).
+		WillReturnRows(sqlmock.NewRows(teamColumns).AddRow(100, "lonely-team", "RANDOM", 0, 2, 0, false, 0, 0))
+	mock.ExpectQuery(`SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users`).
+		WithArgs(int64(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id", "max_open_reviews", "tags"}))
+	mock.ExpectQuery(`FROM team_fallbacks`).
 		WithArgs(int64(100)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id"}))
+		WillReturnRows(sqlmock.NewRows(teamColumns))
 	res, err = repo.GetByName(context.Background(), "lonely-team")
 	require.NoError(t, err)
 	assert.Equal(t, int64(100), res.ID)
 	assert.Empty(t, res.Members)
+	assert.Empty(t, res.FallbackTeams)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_GetByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+
+	teamID := int64(7)
+
+	// Team found, members are not loaded
+	mock.ExpectQuery(`SELECT id, name, assignment_strategy, min_reviewers, max_reviewers, min_approvals, allow_changes_requested, review_sla_hours, sla_reassign_hours, archived_at FROM teams WHERE id = \$1`).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows(append(teamColumns, "archived_at")).AddRow(teamID, "team-7", "LEAST_LOADED", 0, 1, 1, true, 24, 48, nil))
+
+	team, err := repo.GetByID(context.Background(), teamID)
+	require.NoError(t, err)
+	assert.Equal(t, "team-7", team.Name)
+	assert.Equal(t, domain.StrategyLeastLoaded, team.AssignmentStrategy)
+	assert.Equal(t, 1, team.MaxReviewers)
+	assert.Equal(t, 1, team.MinApprovals)
+	assert.True(t, team.AllowChangesRequested)
+	assert.Equal(t, 24, team.ReviewSLAHours)
+	assert.Equal(t, 48, team.SLAReassignHours)
+	assert.Empty(t, team.Members)
+	assert.Nil(t, team.ArchivedAt)
+
+	// Archived team is found, its PRs keep the merge policy
+	archivedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM teams WHERE id = \$1`).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows(append(teamColumns, "archived_at")).AddRow(9, "team-9", "RANDOM", 0, 2, 2, false, 0, 0, archivedAt))
+
+	team, err = repo.GetByID(context.Background(), 9)
+	require.NoError(t, err)
+	assert.Equal(t, 2, team.MinApprovals)
+	assert.Equal(t, &archivedAt, team.ArchivedAt)
+
+	// Team not found
+	mock.ExpectQuery(`FROM teams WHERE id = \$1`).
+		WithArgs(int64(8)).
+		WillReturnError(sql.ErrNoRows)
+
+	team, err = repo.GetByID(context.Background(), 8)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, team)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_GetFallbackTeams(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+
+	teamID := int64(1)
+
+	// Fallback teams in priority order
+	mock.ExpectQuery(`SELECT t.id, t.name, t.assignment_strategy, t.min_reviewers, t.max_reviewers, t.min_approvals, t.allow_changes_requested, t.review_sla_hours, t.sla_reassign_hours FROM team_fallbacks as f JOIN teams as t ON t.id = f.fallback_team_id WHERE f.team_id = \$1 AND t.archived_at IS NULL ORDER BY f.position`).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows(teamColumns).
+			AddRow(3, "platform", "LEAST_LOADED", 0, 3, 0, false, 0, 0).
+			AddRow(2, "backend", "RANDOM", 0, 2, 0, false, 0, 0))
+
+	teams, err := repo.GetFallbackTeams(context.Background(), teamID)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "platform", teams[0].Name)
+	assert.Equal(t, domain.StrategyLeastLoaded, teams[0].AssignmentStrategy)
+	assert.Equal(t, int64(2), teams[1].ID)
+
+	// Query error
+	mock.ExpectQuery(`FROM team_fallbacks`).
+		WithArgs(teamID).
+		WillReturnError(errors.New("qfail"))
+
+	teams, err = repo.GetFallbackTeams(context.Background(), teamID)
+	assert.Error(t, err)
+	assert.Nil(t, teams)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_SetFallbackTeams(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	// Old fallbacks are replaced, order is kept in position
+	mock.ExpectExec(`DELETE FROM team_fallbacks WHERE team_id = \$1`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO team_fallbacks`).
+		WithArgs(int64(1), int64(3), 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO team_fallbacks`).
+		WithArgs(int64(1), int64(2), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetFallbackTeams(context.Background(), tx, 1, []int64{3, 2})
+	require.NoError(t, err)
+
+	// Insert error
+	mock.ExpectExec(`DELETE FROM team_fallbacks`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO team_fallbacks`).
+		WithArgs(int64(1), int64(3), 0).
+		WillReturnError(errors.New("insert error"))
+
+	err = repo.SetFallbackTeams(context.Background(), tx, 1, []int64{3})
+	assert.Error(t, err)
+
+	mock.ExpectCommit()
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_Update(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+
+	team := &domain.Team{ID: 3, Name: "team-3", AssignmentStrategy: domain.StrategyRoundRobin, MinReviewers: 1, MaxReviewers: 3, MinApprovals: 2,
+		ReviewSLAHours: 24, SLAReassignHours: 48}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	// Successful update
+	mock.ExpectExec(`UPDATE teams`).
+		WithArgs(team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours, team.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.Update(context.Background(), tx, team)
+	require.NoError(t, err)
+
+	// No such team
+	mock.ExpectExec(`UPDATE teams`).
+		WithArgs(team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours, team.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.Update(context.Background(), tx, team)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Query error
+	mock.ExpectExec(`UPDATE teams`).
+		WithArgs(team.AssignmentStrategy, team.MinReviewers, team.MaxReviewers, team.MinApprovals, team.AllowChangesRequested, team.ReviewSLAHours, team.SLAReassignHours, team.ID).
+		WillReturnError(errors.New("update error"))
+	err = repo.Update(context.Background(), tx, team)
+	assert.Error(t, err)
+
+	mock.ExpectCommit()
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_GetByNameForUpdate(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+	archivedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := append(teamColumns, "archived_at")
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	// Archived teams are returned too
+	mock.ExpectQuery(`SELECT .*, archived_at FROM teams WHERE name = \$1 FOR UPDATE`).
+		WithArgs("team-1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "team-1", "RANDOM", 0, 2, 0, false, 0, 0, archivedAt))
+
+	team, err := repo.GetByNameForUpdate(context.Background(), tx, "team-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), team.ID)
+	assert.Equal(t, &archivedAt, team.ArchivedAt)
+
+	// Team in use
+	mock.ExpectQuery(`FROM teams WHERE name = \$1 FOR UPDATE`).
+		WithArgs("team-2").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "team-2", "RANDOM", 0, 2, 0, false, 0, 0, nil))
+
+	team, err = repo.GetByNameForUpdate(context.Background(), tx, "team-2")
+	require.NoError(t, err)
+	assert.Nil(t, team.ArchivedAt)
+
+	// Team not found
+	mock.ExpectQuery(`FROM teams WHERE name = \$1 FOR UPDATE`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	team, err = repo.GetByNameForUpdate(context.Background(), tx, "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, team)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_Archive(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+	archivedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectQuery(`UPDATE teams SET archived_at = NOW\(\) WHERE id = \$1 AND archived_at IS NULL RETURNING archived_at`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(archivedAt))
+
+	result, err := repo.Archive(context.Background(), tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, archivedAt, result)
+
+	// Already archived
+	mock.ExpectQuery(`UPDATE teams SET archived_at`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}))
+
+	_, err = repo.Archive(context.Background(), tx, 1)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTeamRepository_Delete(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &TeamRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	mock.ExpectExec(`DELETE FROM teams WHERE id = \$1`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := repo.Delete(context.Background(), tx, 1)
+	require.NoError(t, err)
+
+	// No such team
+	mock.ExpectExec(`DELETE FROM teams`).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.Delete(context.Background(), tx, 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
@@ -23,9 +25,9 @@ func NewUserRepository(db *sql.DB, logger *zap.Logger) *UserRepository {
 // Create inserts a new user into the database.
 func (u *UserRepository) Create(ctx context.Context, tx *sql.Tx, user *domain.User) error {
 	query := `
-			INSERT INTO users (id, username, is_active, team_id)
-			VALUES ($1, $2, $3, $4)`
-	_, err := tx.ExecContext(ctx, query, user.ID, user.Name, user.IsActive, user.TeamID)
+			INSERT INTO users (id, username, is_active, team_id, max_open_reviews, tags)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.ExecContext(ctx, query, user.ID, user.Name, user.IsActive, nullableID(user.TeamID), user.MaxOpenReviews, textArray(user.Tags))
 
 	if err != nil {
 		u.logger.Error("DB error on User insert",
@@ -41,9 +43,9 @@ func (u *UserRepository) Create(ctx context.Context, tx *sql.Tx, user *domain.Us
 func (u *UserRepository) Update(ctx context.Context, tx *sql.Tx, user *domain.User) error {
 	query := `
 			UPDATE users
-			SET username = $1, is_active = $2, team_id = $3
-			WHERE id = $4`
-	res, err := tx.ExecContext(ctx, query, user.Name, user.IsActive, user.TeamID, user.ID)
+			SET username = $1, is_active = $2, team_id = $3, max_open_reviews = $4, tags = $5
+			WHERE id = $6`
+	res, err := tx.ExecContext(ctx, query, user.Name, user.IsActive, nullableID(user.TeamID), user.MaxOpenReviews, textArray(user.Tags), user.ID)
 	if err != nil {
 		u.logger.Error("DB error on User update",
 			zap.Error(err),
@@ -66,12 +68,13 @@ func (u *UserRepository) Update(ctx context.Context, tx *sql.Tx, user *domain.Us
 // Returns ErrNotFound if the user doesn't exist.
 func (u *UserRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-			SELECT id, username, is_active, team_id
+			SELECT id, username, is_active, team_id, max_open_reviews, tags
 			FROM users
 			WHERE id = $1`
 
 	var user domain.User
-	err := u.db.QueryRowContext(ctx, query, userID).Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamID)
+	var teamID sql.NullInt64
+	err := u.db.QueryRowContext(ctx, query, userID).Scan(&user.ID, &user.Name, &user.IsActive, &teamID, &user.MaxOpenReviews, pq.Array(&user.Tags))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -81,41 +84,198 @@ func (u *UserRepository) GetByID(ctx context.Context, userID string) (*domain.Us
 			zap.String("user_id", userID))
 		return nil, err
 	}
+	user.TeamID = teamID.Int64 // users removed from their team have none
 
 	return &user, nil
 }
 
-// GetActiveTeamMembersIDs returns IDs of all active users in a team, excluding the specified user.
-// This is useful for selecting reviewer candidates (excluding the PR author).
-func (u *UserRepository) GetActiveTeamMembersIDs(ctx context.Context, teamID int64, excludeUserID string) ([]string, error) {
+// DetachTeamMembers removes all members from the team within a transaction and deactivates them,
+// so they are not picked as reviewers until they join a team again.
+// Returns IDs of the detached users, sorted.
+func (u *UserRepository) DetachTeamMembers(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error) {
 	query := `
-			SELECT id
-			FROM users
+			UPDATE users
+			SET team_id = NULL, is_active = false
 			WHERE team_id = $1
-				AND is_active = true
-				AND id != $2
+			RETURNING id`
+
+	rows, err := tx.QueryContext(ctx, query, teamID)
+	if err != nil {
+		u.logger.Error("DB error on team members detach",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	userIDs, err := scanIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(userIDs)
+
+	return userIDs, nil
+}
+
+// GetReviewCandidates returns all active users in a team, excluding the specified user,
+// together with the number of OPEN pull requests each of them currently reviews, their review capacity and tags.
+// Users with an absence covering the current time are skipped.
+// Candidates and their load are fetched in a single query.
+func (u *UserRepository) GetReviewCandidates(ctx context.Context, teamID int64, excludeUserID string) ([]domain.ReviewCandidate, error) {
+	query := `
+			SELECT u.id, COUNT(pr.id) AS open_reviews, u.max_open_reviews, u.tags
+			FROM users as u
+			LEFT JOIN pr_reviewers as r ON r.user_id = u.id AND r.unassigned_at IS NULL
+			LEFT JOIN pull_requests as pr ON pr.id = r.pr_id AND pr.status = 'OPEN'
+			WHERE u.team_id = $1
+				AND u.is_active = true
+				AND u.id != $2
+				AND NOT EXISTS (
+					SELECT 1 FROM user_absences as a
+					WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+				)
+			GROUP BY u.id
 			`
 
 	rows, err := u.db.QueryContext(ctx, query, teamID, excludeUserID)
 	if err != nil {
+		u.logger.Error("DB error on review candidates select",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanReviewCandidates(rows)
+}
+
+// GetReviewCandidatesByIDs works like GetReviewCandidates, but takes candidates among
+// the given users regardless of their team.
+// Unknown, inactive and absent users are skipped.
+func (u *UserRepository) GetReviewCandidatesByIDs(ctx context.Context, userIDs []string, excludeUserID string) ([]domain.ReviewCandidate, error) {
+	query := `
+			SELECT u.id, COUNT(pr.id) AS open_reviews, u.max_open_reviews, u.tags
+			FROM users as u
+			LEFT JOIN pr_reviewers as r ON r.user_id = u.id AND r.unassigned_at IS NULL
+			LEFT JOIN pull_requests as pr ON pr.id = r.pr_id AND pr.status = 'OPEN'
+			WHERE u.id = ANY($1)
+				AND u.is_active = true
+				AND u.id != $2
+				AND NOT EXISTS (
+					SELECT 1 FROM user_absences as a
+					WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+				)
+			GROUP BY u.id
+			`
+
+	rows, err := u.db.QueryContext(ctx, query, pq.Array(userIDs), excludeUserID)
+	if err != nil {
+		u.logger.Error("DB error on review candidates select",
+			zap.Error(err),
+			zap.Strings("user_ids", userIDs))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanReviewCandidates(rows)
+}
+
+// scanReviewCandidates reads candidates selected as (id, open_reviews, max_open_reviews, tags)
+func scanReviewCandidates(rows *sql.Rows) ([]domain.ReviewCandidate, error) {
+	var candidates []domain.ReviewCandidate
+	for rows.Next() {
+		var candidate domain.ReviewCandidate
+		err := rows.Scan(&candidate.UserID, &candidate.OpenReviews, &candidate.MaxOpenReviews, pq.Array(&candidate.Tags))
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// GetTeamReviewPool returns all members of a team, including inactive and absent ones,
+// together with their review load, capacity, tags and availability.
+// Used to explain reviewer selection.
+func (u *UserRepository) GetTeamReviewPool(ctx context.Context, teamID int64) ([]domain.ReviewPoolMember, error) {
+	query := `
+			SELECT u.id, COUNT(pr.id) AS open_reviews, u.max_open_reviews, u.tags, u.is_active,
+				EXISTS (
+					SELECT 1 FROM user_absences as a
+					WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+				) AS absent
+			FROM users as u
+			LEFT JOIN pr_reviewers as r ON r.user_id = u.id AND r.unassigned_at IS NULL
+			LEFT JOIN pull_requests as pr ON pr.id = r.pr_id AND pr.status = 'OPEN'
+			WHERE u.team_id = $1
+			GROUP BY u.id
+			ORDER BY u.id
+			`
+
+	rows, err := u.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		u.logger.Error("DB error on review pool select",
+			zap.Error(err),
+			zap.Int64("team_id", teamID))
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanReviewPool(rows)
+}
+
+// GetReviewPoolByIDs works like GetTeamReviewPool, but takes the given users regardless of their team.
+// Unknown users are skipped.
+func (u *UserRepository) GetReviewPoolByIDs(ctx context.Context, userIDs []string) ([]domain.ReviewPoolMember, error) {
+	query := `
+			SELECT u.id, COUNT(pr.id) AS open_reviews, u.max_open_reviews, u.tags, u.is_active,
+				EXISTS (
+					SELECT 1 FROM user_absences as a
+					WHERE a.user_id = u.id AND a.starts_at <= NOW() AND a.ends_at > NOW()
+				) AS absent
+			FROM users as u
+			LEFT JOIN pr_reviewers as r ON r.user_id = u.id AND r.unassigned_at IS NULL
+			LEFT JOIN pull_requests as pr ON pr.id = r.pr_id AND pr.status = 'OPEN'
+			WHERE u.id = ANY($1)
+			GROUP BY u.id
+			ORDER BY u.id
+			`
+
+	rows, err := u.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		u.logger.Error("DB error on review pool select",
+			zap.Error(err),
+			zap.Strings("user_ids", userIDs))
 		return nil, err
 	}
 	defer rows.Close() //nolint:errcheck
 
-	var memberIDs []string
+	return scanReviewPool(rows)
+}
+
+// scanReviewPool reads pool members selected as (id, open_reviews, max_open_reviews, tags, is_active, absent)
+func scanReviewPool(rows *sql.Rows) ([]domain.ReviewPoolMember, error) {
+	var members []domain.ReviewPoolMember
 	for rows.Next() {
-		var userID string
-		err := rows.Scan(&userID)
+		var member domain.ReviewPoolMember
+		err := rows.Scan(&member.UserID, &member.OpenReviews, &member.MaxOpenReviews, pq.Array(&member.Tags),
+			&member.IsActive, &member.Absent)
 		if err != nil {
 			return nil, err
 		}
 
-		memberIDs = append(memberIDs, userID)
+		members = append(members, member)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return memberIDs, nil
+	return members, nil
 }
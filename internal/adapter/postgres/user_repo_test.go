@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -17,20 +18,20 @@ func TestUserRepository_Create(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &UserRepository{db: db, logger: zap.NewNop()}
-	user := &domain.User{ID: "user-1", Name: "Bob", IsActive: true, TeamID: int64(1)}
+	user := &domain.User{ID: "user-1", Name: "Bob", IsActive: true, TeamID: int64(1), MaxOpenReviews: 3, Tags: []string{"go"}}
 
 	mock.ExpectBegin()
 	tx, _ := db.Begin()
 
 	// Success
-	mock.ExpectExec("INSERT INTO users").WithArgs(user.ID, user.Name, user.IsActive, user.TeamID).
+	mock.ExpectExec("INSERT INTO users").WithArgs(user.ID, user.Name, user.IsActive, user.TeamID, user.MaxOpenReviews, pq.Array(user.Tags)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	err := repo.Create(context.Background(), tx, user)
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// insert error
-	mock.ExpectExec("INSERT INTO users").WithArgs(user.ID, user.Name, user.IsActive, user.TeamID).
+	mock.ExpectExec("INSERT INTO users").WithArgs(user.ID, user.Name, user.IsActive, user.TeamID, user.MaxOpenReviews, pq.Array(user.Tags)).
 		WillReturnError(errors.New("insert error"))
 	err = repo.Create(context.Background(), tx, user)
 
@@ -49,20 +50,28 @@ func TestUserRepository_Update(t *testing.T) {
 	tx, _ := db.Begin()
 
 	// Success
-	mock.ExpectExec("UPDATE users").WithArgs(user.Name, user.IsActive, user.TeamID, user.ID).
+	mock.ExpectExec("UPDATE users").WithArgs(user.Name, user.IsActive, user.TeamID, user.MaxOpenReviews, pq.Array([]string{}), user.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	err := repo.Update(context.Background(), tx, user)
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Update error
-	mock.ExpectExec("UPDATE users").WithArgs(user.Name, user.IsActive, user.TeamID, user.ID).
+	mock.ExpectExec("UPDATE users").WithArgs(user.Name, user.IsActive, user.TeamID, user.MaxOpenReviews, pq.Array([]string{}), user.ID).
 		WillReturnError(errors.New("update error"))
 	err = repo.Update(context.Background(), tx, user)
+	assert.Error(t, err)
+
+	// User removed from the team
+	removed := &domain.User{ID: "user-1", Name: "Bob", IsActive: true}
+	mock.ExpectExec("UPDATE users").WithArgs(removed.Name, removed.IsActive, nil, removed.MaxOpenReviews, pq.Array([]string{}), removed.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	err = repo.Update(context.Background(), tx, removed)
+	require.NoError(t, err)
 
 	mock.ExpectCommit()
 	require.NoError(t, tx.Commit())
-	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUserRepository_GetByID(t *testing.T) {
@@ -74,68 +83,173 @@ func TestUserRepository_GetByID(t *testing.T) {
 	teamID := int64(1)
 
 	// user found
-	mock.ExpectQuery("SELECT id, username, is_active, team_id FROM user" +
+	mock.ExpectQuery("SELECT id, username, is_active, team_id, max_open_reviews, tags FROM user" +
 		"").WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id"}).
-			AddRow(userID, "Bob", true, teamID))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id", "max_open_reviews", "tags"}).
+			AddRow(userID, "Bob", true, teamID, 5, "{go,frontend}"))
 	user, err := repo.GetByID(context.Background(), userID)
 	require.NoError(t, err)
 	assert.Equal(t, "Bob", user.Name)
 	assert.Equal(t, userID, user.ID)
 	assert.Equal(t, true, user.IsActive)
 	assert.Equal(t, teamID, user.TeamID)
+	assert.Equal(t, 5, user.MaxOpenReviews)
+	assert.Equal(t, []string{"go", "frontend"}, user.Tags)
+
+	// User without a team
+	mock.ExpectQuery("SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users").WithArgs("user-2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "is_active", "team_id", "max_open_reviews", "tags"}).
+			AddRow("user-2", "Alice", true, nil, 0, "{}"))
+	user, err = repo.GetByID(context.Background(), "user-2")
+	require.NoError(t, err)
+	assert.Zero(t, user.TeamID)
 
 	// User not found
-	mock.ExpectQuery("SELECT id, username, is_active, team_id FROM users").WithArgs("user-1").
+	mock.ExpectQuery("SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users").WithArgs("user-1").
 		WillReturnError(sql.ErrNoRows)
 	res, err := repo.GetByID(context.Background(), "user-1")
 	assert.ErrorIs(t, err, domain.ErrNotFound)
 	assert.Nil(t, res)
 
 	// Другая ошибка
-	mock.ExpectQuery("SELECT id, username, is_active, team_id FROM users").WithArgs("user-500").
+	mock.ExpectQuery("SELECT id, username, is_active, team_id, max_open_reviews, tags FROM users").WithArgs("user-500").
 		WillReturnError(errors.New("db failed"))
 	res, err = repo.GetByID(context.Background(), "user-500")
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
 
-func TestUserRepository_GetActiveTeamMembersIDs(t *testing.T) {
+func TestUserRepository_GetReviewCandidates(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := &UserRepository{db: db, logger: zap.NewNop()}
 	teamID := int64(1)
 	exclude := "user-3"
 
-	// Two members
-	mock.ExpectQuery(`SELECT id FROM users WHERE team_id = \$1 AND is_active = true AND id != \$2`).
+	// Candidates with their open reviews count, absent users are filtered out by the query
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags FROM users .* NOT EXISTS \( SELECT 1 FROM user_absences`).
 		WithArgs(teamID, exclude).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-1").AddRow("user-2"))
-	ids, err := repo.GetActiveTeamMembersIDs(context.Background(), teamID, exclude)
+		WillReturnRows(sqlmock.NewRows([]string{"id", "open_reviews", "max_open_reviews", "tags"}).AddRow("user-1", 2, 2, "{sql}").AddRow("user-2", 0, 0, "{}"))
+	candidates, err := repo.GetReviewCandidates(context.Background(), teamID, exclude)
 	require.NoError(t, err)
-	assert.Equal(t, []string{"user-1", "user-2"}, ids)
+	assert.Equal(t, []domain.ReviewCandidate{
+		{UserID: "user-1", OpenReviews: 2, MaxOpenReviews: 2, Tags: []string{"sql"}},
+		{UserID: "user-2", OpenReviews: 0, MaxOpenReviews: 0, Tags: []string{}},
+	}, candidates)
 
-	// Empty members list
-	mock.ExpectQuery(`SELECT id FROM users WHERE team_id = \$1 AND is_active = true AND id != \$2`).
+	// No candidates
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags FROM users`).
 		WithArgs(teamID, exclude).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}))
-	ids, err = repo.GetActiveTeamMembersIDs(context.Background(), teamID, exclude)
+		WillReturnRows(sqlmock.NewRows([]string{"id", "open_reviews", "max_open_reviews", "tags"}))
+	candidates, err = repo.GetReviewCandidates(context.Background(), teamID, exclude)
 	require.NoError(t, err)
-	assert.Empty(t, ids)
+	assert.Empty(t, candidates)
 
-	// Scan error
-	mock.ExpectQuery(`SELECT id FROM users WHERE team_id = \$1 AND is_active = true AND id != \$2`).
+	// Query error
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags FROM users`).
 		WithArgs(teamID, exclude).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(nil))
-	ids, err = repo.GetActiveTeamMembersIDs(context.Background(), teamID, exclude)
+		WillReturnError(errors.New("qfail"))
+	candidates, err = repo.GetReviewCandidates(context.Background(), teamID, exclude)
 	assert.Error(t, err)
-	assert.Nil(t, ids)
+	assert.Nil(t, candidates)
+}
+
+func TestUserRepository_GetReviewCandidatesByIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &UserRepository{db: db, logger: zap.NewNop()}
+	userIDs := []string{"user-1", "user-2", "user-3"}
+	exclude := "user-3"
+
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags FROM users .* WHERE u.id = ANY\(\$1\)`).
+		WithArgs(pq.Array(userIDs), exclude).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "open_reviews", "max_open_reviews", "tags"}).AddRow("user-1", 1, 0, "{}"))
+	candidates, err := repo.GetReviewCandidatesByIDs(context.Background(), userIDs, exclude)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewCandidate{{UserID: "user-1", OpenReviews: 1, Tags: []string{}}}, candidates)
 
 	// Query error
-	mock.ExpectQuery(`SELECT id FROM users WHERE team_id = \$1 AND is_active = true AND id != \$2`).
-		WithArgs(teamID, exclude).
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags FROM users`).
+		WithArgs(pq.Array(userIDs), exclude).
+		WillReturnError(errors.New("qfail"))
+	candidates, err = repo.GetReviewCandidatesByIDs(context.Background(), userIDs, exclude)
+	assert.Error(t, err)
+	assert.Nil(t, candidates)
+}
+
+func TestUserRepository_GetTeamReviewPool(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &UserRepository{db: db, logger: zap.NewNop()}
+	columns := []string{"id", "open_reviews", "max_open_reviews", "tags", "is_active", "absent"}
+
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, u.max_open_reviews, u.tags, u.is_active, EXISTS \(.*user_absences.*\) AS absent FROM users .* WHERE u.team_id = \$1`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("user-1", 2, 2, "{go}", true, false).
+			AddRow("user-2", 0, 0, "{}", false, true))
+	members, err := repo.GetTeamReviewPool(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "user-1", OpenReviews: 2, MaxOpenReviews: 2, Tags: []string{"go"}}, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "user-2", Tags: []string{}}, Absent: true},
+	}, members)
+
+	// Query error
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews`).
+		WithArgs(int64(1)).
 		WillReturnError(errors.New("qfail"))
-	ids, err = repo.GetActiveTeamMembersIDs(context.Background(), teamID, exclude)
+	members, err = repo.GetTeamReviewPool(context.Background(), 1)
 	assert.Error(t, err)
-	assert.Nil(t, ids)
+	assert.Nil(t, members)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetReviewPoolByIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &UserRepository{db: db, logger: zap.NewNop()}
+	userIDs := []string{"user-1", "ghost"}
+
+	mock.ExpectQuery(`SELECT u.id, COUNT\(pr.id\) AS open_reviews, .* AS absent FROM users .* WHERE u.id = ANY\(\$1\)`).
+		WithArgs(pq.Array(userIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "open_reviews", "max_open_reviews", "tags", "is_active", "absent"}).
+			AddRow("user-1", 1, 0, "{}", true, false))
+	members, err := repo.GetReviewPoolByIDs(context.Background(), userIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "user-1", OpenReviews: 1, Tags: []string{}}, IsActive: true},
+	}, members)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_DetachTeamMembers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &UserRepository{db: db, logger: zap.NewNop()}
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	// IDs are sorted
+	mock.ExpectQuery(`UPDATE users SET team_id = NULL, is_active = false WHERE team_id = \$1 RETURNING id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u2").AddRow("u1"))
+
+	userIDs, err := repo.DetachTeamMembers(context.Background(), tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, userIDs)
+
+	// Query error
+	mock.ExpectQuery(`UPDATE users`).
+		WithArgs(int64(1)).
+		WillReturnError(errors.New("qfail"))
+
+	userIDs, err = repo.DetachTeamMembers(context.Background(), tx, 1)
+	assert.Error(t, err)
+	assert.Nil(t, userIDs)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"errors"
 
 	"github.com/jackc/pgerrcode"
@@ -16,3 +17,17 @@ func isUniqueViolationError(err error) bool {
 	}
 	return false
 }
+
+// textArray prepares a slice to be stored into a NOT NULL TEXT[] column:
+// pq.Array stores nil slices as NULL, so nil becomes an empty array
+func textArray(values []string) interface{} {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
+
+// nullableID prepares an ID to be stored into a nullable reference column: 0 becomes NULL
+func nullableID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
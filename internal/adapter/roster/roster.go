@@ -0,0 +1,237 @@
+package roster
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of a roster file
+type Format string
+
+const (
+	FormatYAML = Format("yaml")
+	FormatCSV  = Format("csv")
+)
+
+// Team is the full roster of a team read from a file
+type Team struct {
+	Name    string
+	Members []domain.RosterMember
+}
+
+// FormatFromPath detects the format of the file by its extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unknown roster format of %q, expected .yaml, .yml or .csv", path)
+	}
+}
+
+// Parse reads team rosters in the given format and validates them.
+// Members are active unless the file says otherwise. Review capacity and tags that are not given
+// are left unset, so the sync keeps the stored values (see domain.RosterMember).
+//
+// Returns:
+//   - []Team: rosters in the order of the file
+//   - error: domain.ErrInvalidRoster if the file is malformed or the rosters are inconsistent
+func Parse(r io.Reader, format Format) ([]Team, error) {
+	var (
+		teams []Team
+		err   error
+	)
+
+	switch format {
+	case FormatYAML:
+		teams, err = parseYAML(r)
+	case FormatCSV:
+		teams, err = parseCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown roster format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validate(teams); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// yamlFile is the layout of a YAML roster file, member fields follow the TeamMember of the API
+type yamlFile struct {
+	Teams []struct {
+		Name    string `yaml:"team_name"`
+		Members []struct {
+			UserID         string   `yaml:"user_id"`
+			Username       string   `yaml:"username"`
+			IsActive       *bool    `yaml:"is_active"`        // nil means active
+			MaxOpenReviews *int     `yaml:"max_open_reviews"` // nil keeps the stored limit
+			Tags           []string `yaml:"tags"`             // nil keeps the stored tags, [] clears them
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+func parseYAML(r io.Reader) ([]Team, error) {
+	var file yamlFile
+
+	decoder := yaml.NewDecoder(r)
+	// Misspelled fields would silently reset members' data
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRoster, err)
+	}
+
+	teams := make([]Team, len(file.Teams))
+	for i, t := range file.Teams {
+		teams[i] = Team{Name: t.Name, Members: make([]domain.RosterMember, len(t.Members))}
+		for j, m := range t.Members {
+			teams[i].Members[j] = domain.RosterMember{
+				ID:             m.UserID,
+				Name:           m.Username,
+				IsActive:       m.IsActive == nil || *m.IsActive,
+				MaxOpenReviews: m.MaxOpenReviews,
+				Tags:           m.Tags,
+			}
+		}
+	}
+
+	return teams, nil
+}
+
+// CSV columns; team_name, user_id and username are required, tags are separated by ";".
+// An empty max_open_reviews keeps the stored limit, as does a missing column. An empty tags cell clears
+// the tags of the user, while without the tags column the stored tags are kept.
+const (
+	columnTeamName       = "team_name"
+	columnUserID         = "user_id"
+	columnUsername       = "username"
+	columnIsActive       = "is_active"
+	columnMaxOpenReviews = "max_open_reviews"
+	columnTags           = "tags"
+)
+
+func parseCSV(r io.Reader) ([]Team, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRoster, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{columnTeamName, columnUserID, columnUsername} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", domain.ErrInvalidRoster, required)
+		}
+	}
+
+	var teams []Team
+	teamIdx := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRoster, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		member := domain.RosterMember{
+			ID:       field(columnUserID),
+			Name:     field(columnUsername),
+			IsActive: true,
+		}
+		if value := field(columnIsActive); value != "" {
+			member.IsActive, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid %s %q", domain.ErrInvalidRoster, line, columnIsActive, value)
+			}
+		}
+		if value := field(columnMaxOpenReviews); value != "" {
+			maxOpenReviews, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid %s %q", domain.ErrInvalidRoster, line, columnMaxOpenReviews, value)
+			}
+			member.MaxOpenReviews = &maxOpenReviews
+		}
+		if _, ok := columns[columnTags]; ok {
+			member.Tags = []string{}
+			if value := field(columnTags); value != "" {
+				member.Tags = strings.Split(value, ";")
+			}
+		}
+
+		// Rows of a team don't have to be adjacent
+		name := field(columnTeamName)
+		i, ok := teamIdx[name]
+		if !ok {
+			i = len(teams)
+			teamIdx[name] = i
+			teams = append(teams, Team{Name: name})
+		}
+		teams[i].Members = append(teams[i].Members, member)
+	}
+
+	return teams, nil
+}
+
+// validate checks what the API would reject, and that every user is listed once in the whole file:
+// a user listed in two teams would end up in the one imported last.
+func validate(teams []Team) error {
+	seenTeams := make(map[string]struct{}, len(teams))
+	userTeam := make(map[string]string)
+
+	for _, team := range teams {
+		if team.Name == "" {
+			return fmt.Errorf("%w: team without team_name", domain.ErrInvalidRoster)
+		}
+		if _, ok := seenTeams[team.Name]; ok {
+			return fmt.Errorf("%w: team %s is listed twice", domain.ErrInvalidRoster, team.Name)
+		}
+		seenTeams[team.Name] = struct{}{}
+
+		for _, member := range team.Members {
+			if member.ID == "" || member.Name == "" {
+				return fmt.Errorf("%w: team %s: member without user_id or username", domain.ErrInvalidRoster, team.Name)
+			}
+			if member.MaxOpenReviews != nil && *member.MaxOpenReviews < 0 {
+				return fmt.Errorf("%w: team %s: negative max_open_reviews of %s", domain.ErrInvalidRoster, team.Name, member.ID)
+			}
+			if other, ok := userTeam[member.ID]; ok {
+				return fmt.Errorf("%w: %s is listed in teams %s and %s", domain.ErrInvalidRoster, member.ID, other, team.Name)
+			}
+			userTeam[member.ID] = team.Name
+		}
+	}
+
+	return nil
+}
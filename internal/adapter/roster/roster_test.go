@@ -0,0 +1,152 @@
+package roster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestParse_YAML(t *testing.T) {
+	file := `
+teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+        max_open_reviews: 3
+        tags: [go, sql]
+      - user_id: u2
+        username: Bob
+        is_active: false
+      - user_id: u3
+        username: Carol
+        max_open_reviews: 0
+        tags: []
+  - team_name: docs
+    members: []
+`
+	teams, err := Parse(strings.NewReader(file), FormatYAML)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Team{
+		{Name: "backend", Members: []domain.RosterMember{
+			{ID: "u1", Name: "Alice", IsActive: true, MaxOpenReviews: intPtr(3), Tags: []string{"go", "sql"}},
+			// review capacity and tags are not given
+			{ID: "u2", Name: "Bob", IsActive: false},
+			{ID: "u3", Name: "Carol", IsActive: true, MaxOpenReviews: intPtr(0), Tags: []string{}},
+		}},
+		{Name: "docs", Members: []domain.RosterMember{}},
+	}, teams)
+}
+
+func TestParse_YAML_UnknownField(t *testing.T) {
+	file := `
+teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+        active: false
+`
+	_, err := Parse(strings.NewReader(file), FormatYAML)
+	assert.ErrorIs(t, err, domain.ErrInvalidRoster)
+}
+
+func TestParse_CSV(t *testing.T) {
+	file := `team_name,user_id,username,is_active,tags
+backend,u1,Alice,,go;sql
+frontend,u3,Carol,true,
+backend,u2,Bob,false,
+`
+	teams, err := Parse(strings.NewReader(file), FormatCSV)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Team{
+		{Name: "backend", Members: []domain.RosterMember{
+			{ID: "u1", Name: "Alice", IsActive: true, Tags: []string{"go", "sql"}},
+			{ID: "u2", Name: "Bob", IsActive: false, Tags: []string{}},
+		}},
+		{Name: "frontend", Members: []domain.RosterMember{
+			{ID: "u3", Name: "Carol", IsActive: true, Tags: []string{}},
+		}},
+	}, teams)
+}
+
+func TestParse_CSV_OptionalColumns(t *testing.T) {
+	file := `team_name,user_id,username,max_open_reviews
+backend,u1,Alice,3
+backend,u2,Bob,
+`
+	teams, err := Parse(strings.NewReader(file), FormatCSV)
+
+	require.NoError(t, err)
+	// without the tags column and with an empty limit the stored values are kept
+	assert.Equal(t, []Team{
+		{Name: "backend", Members: []domain.RosterMember{
+			{ID: "u1", Name: "Alice", IsActive: true, MaxOpenReviews: intPtr(3)},
+			{ID: "u2", Name: "Bob", IsActive: true},
+		}},
+	}, teams)
+}
+
+func TestParse_CSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"missing column", "team_name,user_id\nbackend,u1\n"},
+		{"invalid is_active", "team_name,user_id,username,is_active\nbackend,u1,Alice,maybe\n"},
+		{"invalid max_open_reviews", "team_name,user_id,username,max_open_reviews\nbackend,u1,Alice,-\n"},
+		{"wrong number of fields", "team_name,user_id,username\nbackend,u1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.file), FormatCSV)
+			assert.ErrorIs(t, err, domain.ErrInvalidRoster)
+		})
+	}
+}
+
+func TestParse_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"empty team name", ",u1,Alice,\n"},
+		{"empty username", "backend,u1,,\n"},
+		{"user in two teams", "backend,u1,Alice,\nfrontend,u1,Alice,\n"},
+		{"user listed twice", "backend,u1,Alice,\nbackend,u1,Alice,\n"},
+		{"negative max_open_reviews", "backend,u1,Alice,-1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := "team_name,user_id,username,max_open_reviews\n" + tt.file
+
+			_, err := Parse(strings.NewReader(file), FormatCSV)
+			assert.ErrorIs(t, err, domain.ErrInvalidRoster)
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	format, err := FormatFromPath("teams.YML")
+	require.NoError(t, err)
+	assert.Equal(t, FormatYAML, format)
+
+	format, err = FormatFromPath("exports/teams.csv")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = FormatFromPath("teams.json")
+	assert.Error(t, err)
+}
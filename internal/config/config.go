@@ -1,13 +1,17 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/gabrielsoaressantos/env/v8"
 	"github.com/joho/godotenv"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 )
 
 // DBConfig contains PostgreSQL database connection settings
@@ -25,10 +29,57 @@ type ServerConfig struct {
 	Port int    `env:"SERVER_PORT,notEmpty"`
 }
 
+// WorkerConfig holds settings of background jobs
+type WorkerConfig struct {
+	// AbsenceCheckInterval defines how often open reviews of users whose absences have started are reassigned
+	AbsenceCheckInterval time.Duration `env:"ABSENCE_CHECK_INTERVAL" envDefault:"1m"`
+	// SLACheckInterval defines how often reviews are checked against the review SLA of teams
+	SLACheckInterval time.Duration `env:"SLA_CHECK_INTERVAL" envDefault:"5m"`
+	// ReminderCheckInterval defines how often reviewers are reminded about their pending reviews
+	ReminderCheckInterval time.Duration `env:"REMINDER_CHECK_INTERVAL" envDefault:"5m"`
+	// ReminderAfter defines the age of a pending review before the first reminder; 0 disables reminders
+	ReminderAfter time.Duration `env:"REMINDER_AFTER" envDefault:"24h"`
+	// ReminderMaxInterval caps the interval between reminders, which doubles after every reminder
+	ReminderMaxInterval time.Duration `env:"REMINDER_MAX_INTERVAL" envDefault:"72h"`
+}
+
+// SLAConfig holds the working hours review SLAs are measured in, see domain.WorkingHours
+type SLAConfig struct {
+	// WorkdayStartHour and WorkdayEndHour bound the working day, Monday to Friday
+	WorkdayStartHour int `env:"WORKDAY_START_HOUR" envDefault:"9"`
+	WorkdayEndHour   int `env:"WORKDAY_END_HOUR" envDefault:"18"`
+	// Timezone is an IANA name of the timezone of the working hours, e.g. "Europe/Moscow"
+	Timezone string `env:"WORKDAY_TIMEZONE" envDefault:"UTC"`
+}
+
+// WorkingHours returns the validated working hours.
+func (c SLAConfig) WorkingHours() (domain.WorkingHours, error) {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return domain.WorkingHours{}, fmt.Errorf("invalid WORKDAY_TIMEZONE: %w", err)
+	}
+
+	hours := domain.WorkingHours{Start: c.WorkdayStartHour, End: c.WorkdayEndHour, Location: loc}
+	if err = hours.Validate(); err != nil {
+		return domain.WorkingHours{}, fmt.Errorf("invalid WORKDAY_START_HOUR or WORKDAY_END_HOUR: %w", err)
+	}
+
+	return hours, nil
+}
+
+// AdminConfig holds settings of the maintenance endpoints
+type AdminConfig struct {
+	// Token is expected as "Authorization: Bearer <token>" by /admin endpoints; if empty, they are disabled
+	Token string `env:"ADMIN_TOKEN"`
+}
+
 // Config contains all application config
 type Config struct {
 	DBConfig
 	ServerConfig
+	WorkerConfig
+	SLAConfig
+	AdminConfig
 }
 
 const filePath = "./.env"
@@ -36,6 +87,8 @@ const filePath = "./.env"
 var (
 	cfg  *Config
 	once sync.Once
+
+	envOnce sync.Once
 )
 
 // LoadConfig loads configuration from .env file or environment variables.
@@ -44,6 +97,68 @@ var (
 // Panics if required environment variables are missing or contain invalid values.
 func LoadConfig() *Config {
 	once.Do(func() {
+		loadEnvFile()
+
+		// Parse environment variables into Config struct
+		cfg = &Config{}
+		if err := env.ParseNested(cfg); err != nil {
+			log.Fatalf("error parsing environment variables: %v", err)
+		}
+		if err := cfg.validate(); err != nil {
+			log.Fatalf("invalid configuration: %v", err)
+		}
+	})
+
+	return cfg
+}
+
+// validate checks the values that can be parsed but can't be used
+func (c *Config) validate() error {
+	if err := c.WorkerConfig.validate(); err != nil {
+		return err
+	}
+	if _, err := c.SLAConfig.WorkingHours(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validate checks that the check intervals are positive, as tickers of the jobs require
+func (c WorkerConfig) validate() error {
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"ABSENCE_CHECK_INTERVAL", c.AbsenceCheckInterval},
+		{"SLA_CHECK_INTERVAL", c.SLACheckInterval},
+		{"REMINDER_CHECK_INTERVAL", c.ReminderCheckInterval},
+	}
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", interval.name, interval.value)
+		}
+	}
+
+	return nil
+}
+
+// LoadDBConfig loads only the database settings, for tools that don't run the HTTP server.
+// Like LoadConfig, it reads .env file if it exists and panics on missing or invalid variables.
+func LoadDBConfig() DBConfig {
+	loadEnvFile()
+
+	var dbCfg DBConfig
+	if err := env.Parse(&dbCfg); err != nil {
+		log.Fatalf("error parsing environment variables: %v", err)
+	}
+
+	return dbCfg
+}
+
+// loadEnvFile loads variables from .env file once, if it exists
+func loadEnvFile() {
+	envOnce.Do(func() {
 		absFP, err := filepath.Abs(filePath)
 		if err != nil {
 			log.Fatalf("failed to resolve path %q: %v", filePath, err)
@@ -64,13 +179,5 @@ func LoadConfig() *Config {
 			// Some other error occurred
 			log.Fatalf("error checking file %q: %v", absFP, err)
 		}
-
-		// Parse environment variables into Config struct
-		cfg = &Config{}
-		if err = env.ParseNested(cfg); err != nil {
-			log.Fatalf("error parsing environment variables: %v", err)
-		}
 	})
-
-	return cfg
 }
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Config {
+	return &Config{
+		WorkerConfig: WorkerConfig{
+			AbsenceCheckInterval:  time.Minute,
+			SLACheckInterval:      5 * time.Minute,
+			ReminderCheckInterval: 5 * time.Minute,
+		},
+		SLAConfig: SLAConfig{WorkdayStartHour: 9, WorkdayEndHour: 18, Timezone: "UTC"},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, validConfig().validate())
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{name: "zero absence check interval", modify: func(c *Config) { c.AbsenceCheckInterval = 0 }},
+		{name: "negative SLA check interval", modify: func(c *Config) { c.SLACheckInterval = -time.Minute }},
+		{name: "zero reminder check interval", modify: func(c *Config) { c.ReminderCheckInterval = 0 }},
+		{name: "working day ends before it starts", modify: func(c *Config) { c.WorkdayStartHour, c.WorkdayEndHour = 18, 9 }},
+		{name: "unknown timezone", modify: func(c *Config) { c.Timezone = "Mars/Olympus" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			assert.Error(t, cfg.validate())
+		})
+	}
+}
+
+func TestSLAConfig_WorkingHours(t *testing.T) {
+	hours, err := SLAConfig{WorkdayStartHour: 10, WorkdayEndHour: 19, Timezone: "Europe/Moscow"}.WorkingHours()
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, hours.Start)
+	assert.Equal(t, 19, hours.End)
+	assert.Equal(t, "Europe/Moscow", hours.Location.String())
+}
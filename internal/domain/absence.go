@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Absence is an out-of-office period of a user.
+// While an absence covers the current time the user is not selected as a reviewer,
+// regardless of his IsActive flag.
+type Absence struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time // exclusive
+	// ReassignReviews requests handing over open reviews of the user to other reviewers
+	// when the absence starts.
+	ReassignReviews bool
+	ReassignedAt    *time.Time // when open reviews were handed over, nil if not yet
+}
+
+// AbsenceReassignment describes how open reviews of an absent user were handed over
+// when the absence started.
+type AbsenceReassignment struct {
+	Absence Absence
+	Report  ReassignmentReport
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "slices"
+
+// ExclusionReason explains why a user can't be assigned as a reviewer of a PR
+type ExclusionReason string
+
+const (
+	ExcludedAuthor          = ExclusionReason("AUTHOR")
+	ExcludedInactive        = ExclusionReason("INACTIVE")
+	ExcludedAbsent          = ExclusionReason("ABSENT")
+	ExcludedAlreadyAssigned = ExclusionReason("ALREADY_ASSIGNED")
+	ExcludedAtCapacity      = ExclusionReason("AT_CAPACITY")
+	ExcludedDeclined        = ExclusionReason("DECLINED")
+)
+
+// ReviewPoolMember is a user considered as a reviewer of a PR, together with his availability.
+type ReviewPoolMember struct {
+	ReviewCandidate
+	IsActive bool
+	Absent   bool // an absence of the user covers the current time
+}
+
+// ExclusionReason returns why the member can't review the PR, or an empty reason if he can.
+func (m ReviewPoolMember) ExclusionReason(pr *PullRequest) ExclusionReason {
+	switch {
+	case m.UserID == pr.AuthorID:
+		return ExcludedAuthor
+	case !m.IsActive:
+		return ExcludedInactive
+	case m.Absent:
+		return ExcludedAbsent
+	case slices.Contains(pr.ReviewersIDs, m.UserID):
+		return ExcludedAlreadyAssigned
+	case pr.HasDeclined(m.UserID):
+		return ExcludedDeclined
+	case m.AtCapacity():
+		return ExcludedAtCapacity
+	default:
+		return ""
+	}
+}
+
+// ExcludedCandidate is a user of the candidate pool that can't be assigned
+type ExcludedCandidate struct {
+	UserID string
+	Source ReviewerSource // pool the user was considered in
+	Reason ExclusionReason
+}
+
+// AssignmentPreview explains reviewer selection for a PR without assigning anyone.
+type AssignmentPreview struct {
+	Team   *Team // team of the author, defines the strategy and the reviewers policy
+	Amount int   // number of reviewers to select
+	// Candidates are users that can be assigned, in the order of pools: code owners,
+	// the author's team, fallback teams. A user is listed once, in the first pool he belongs to.
+	Candidates []ReviewCandidate
+	Excluded   []ExcludedCandidate
+	Reviewers  []ReviewCandidate // reviewers that would be assigned
+	// Shortfall is the number of reviewers missing to meet Team.MinReviewers, counting the already assigned ones.
+	// PR creation fails with ErrNotEnoughReviewers while it is positive.
+	Shortfall int
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// CodeOwners holds path-based ownership rules of a repository written in CODEOWNERS syntax:
+// each line is a gitignore-style path pattern followed by its owners.
+// An owner is either a user ("@user_id") or a team ("@org/team_name").
+type CodeOwners struct {
+	Repository string
+	Content    string           // raw CODEOWNERS file
+	Rules      []CodeOwnersRule // rules parsed from Content; for each file the last matching rule wins
+	UpdatedAt  time.Time
+}
+
+// CodeOwnersRule assigns owners to the files matching Pattern.
+// A rule without owners removes ownership set by previous rules.
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string
+}
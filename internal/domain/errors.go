@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrTeamExists  = errors.New("team already exists")
@@ -9,4 +12,26 @@ var (
 	ErrNotAssigned = errors.New("reviewer not assigned")
 	ErrNoCandidate = errors.New("no candidate available")
 	ErrNotFound    = errors.New("resource not found")
+
+	ErrNotEnoughReviewers     = errors.New("not enough reviewers available")
+	ErrInvalidReviewersPolicy = errors.New("invalid reviewers policy")
+	ErrInvalidStrategy        = errors.New("invalid assignment strategy")
+	ErrInvalidFallbackTeam    = errors.New("invalid fallback team")
+	ErrInvalidCodeOwners      = errors.New("invalid CODEOWNERS")
+	ErrInvalidAbsence         = errors.New("invalid absence period")
+	ErrInvalidCandidate       = errors.New("user cannot review this pull request")
+	ErrTooManyReviewers       = errors.New("too many reviewers")
+	ErrInvalidMergePolicy     = errors.New("invalid merge policy")
+	ErrInvalidReviewSLA       = errors.New("invalid review SLA")
+	ErrMergeBlocked           = errors.New("merge blocked by the merge policy")
+	ErrInvalidTransition      = errors.New("invalid pull request status transition")
+	ErrPRNotOpen              = errors.New("pull request is not open for review")
+	ErrNotTeamMember          = errors.New("user is not a member of the team")
+	ErrMemberOfOtherTeam      = errors.New("user is a member of another team")
+	ErrInvalidRoster          = errors.New("invalid team roster")
+	ErrTeamInUse              = errors.New("team is referenced by open reviews")
+
+	// ErrAllAtCapacity is a special case of ErrNoCandidate: there are candidates,
+	// but all of them have reached their review capacity.
+	ErrAllAtCapacity = fmt.Errorf("%w: all candidates reached their review capacity", ErrNoCandidate)
 )
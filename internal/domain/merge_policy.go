@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnmetMergeConditions lists the conditions of the team merge policy the PR doesn't meet,
+// empty if the PR can be merged. Only the latest decisions of current reviewers count.
+func (t *Team) UnmetMergeConditions(pr *PullRequest) []string {
+	var unmet []string
+
+	approvals := 0
+	for _, id := range pr.ReviewersIDs {
+		if pr.Decisions[id].Decision == DecisionApproved {
+			approvals++
+		}
+	}
+	if approvals < t.MinApprovals {
+		unmet = append(unmet, fmt.Sprintf("%d approvals required, %d given", t.MinApprovals, approvals))
+	}
+
+	if !t.AllowChangesRequested {
+		for _, id := range pr.ReviewersIDs {
+			if pr.Decisions[id].Decision == DecisionChangesRequested {
+				unmet = append(unmet, fmt.Sprintf("changes requested by %s", id))
+			}
+		}
+	}
+
+	return unmet
+}
+
+// ForcedMerge is an audit record of a merge that bypassed the merge policy
+type ForcedMerge struct {
+	ID              int64
+	PRID            string
+	ForcedBy        string   // who forced the merge, as told by the admin
+	Reason          string   // why the policy was bypassed
+	UnmetConditions []string // conditions that were not met at the moment of the merge
+	CreatedAt       time.Time
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PREventType tells what happened to a pull request
+type PREventType string
+
+const (
+	EventCreated            = PREventType("CREATED")              // the PR was created
+	EventReviewerAssigned   = PREventType("REVIEWER_ASSIGNED")    // a reviewer was assigned, automatically or by hand
+	EventReviewerReassigned = PREventType("REVIEWER_REASSIGNED")  // a reviewer was replaced, or removed as the PR is over the team policy
+	EventReviewerRemoved    = PREventType("REVIEWER_REMOVED")     // a reviewer was removed without replacement
+	EventMerged             = PREventType("MERGED")               // the PR was merged, possibly bypassing the merge policy
+	EventStatusChanged      = PREventType("STATUS_CHANGED")       // the PR moved between DRAFT, OPEN and CLOSED
+	EventReviewOverdue      = PREventType("REVIEW_OVERDUE")       // a reviewer exceeded the review SLA of the team
+	EventAuthorTeamArchived = PREventType("AUTHOR_TEAM_ARCHIVED") // the team of the author was archived while the PR was open
+)
+
+// ActorSystem is the actor of events caused by the service itself, e.g. by background jobs
+const ActorSystem = "system"
+
+// PREvent is an entry of the append-only timeline of a pull request
+type PREvent struct {
+	ID        int64
+	PRID      string
+	Type      PREventType
+	Actor     string         // who caused the event, empty if unknown
+	Payload   map[string]any // details of the event, depending on its type
+	CreatedAt time.Time
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor, who is recorded in the events caused within ctx.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, empty if there is none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -1,26 +1,109 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
 
 // PRStatus represents the state of a pull request
 type PRStatus string
 
 const (
-	StatusOpen   = PRStatus("OPEN")
-	StatusMerged = PRStatus("MERGED")
+	StatusDraft  = PRStatus("DRAFT")  // not ready for review, has no reviewers
+	StatusOpen   = PRStatus("OPEN")   // under review
+	StatusMerged = PRStatus("MERGED") // final
+	StatusClosed = PRStatus("CLOSED") // closed without merging, has no reviewers; can be reopened
+)
+
+// prTransitions lists the statuses a PR can move to from each status
+var prTransitions = map[PRStatus][]PRStatus{
+	StatusDraft:  {StatusOpen, StatusClosed},
+	StatusOpen:   {StatusMerged, StatusClosed},
+	StatusClosed: {StatusOpen},
+}
+
+// ReviewerSource tells how a reviewer was assigned to a PR
+type ReviewerSource string
+
+const (
+	SourceTeam       = ReviewerSource("TEAM")        // member of the author's team
+	SourceFallback   = ReviewerSource("FALLBACK")    // member of one of the fallback teams of the author's team
+	SourceCodeOwners = ReviewerSource("CODE_OWNERS") // owner of the changed files according to CODEOWNERS rules
+	SourceManual     = ReviewerSource("MANUAL")      // added by hand, can be from any team
 )
 
 // PullRequest represents a code review request.
-// A PR can have up to MaxReviewersAmount assigned reviewers.
+// A PR can have up to Team.MaxReviewers assigned reviewers of the author's team.
 type PullRequest struct {
-	ID           string
-	Name         string
-	AuthorID     string
-	Status       PRStatus
-	ReviewersIDs []string
-	CreatedAt    time.Time
-	MergedAt     *time.Time // nil if PR is not merged yet
+	ID              string
+	Name            string
+	AuthorID        string
+	Status          PRStatus
+	ReviewersIDs    []string
+	ReviewerSources map[string]ReviewerSource // reviewer ID -> how the reviewer was assigned
+	Decisions       map[string]ReviewDecision // reviewer ID -> latest decision, pending reviewers are absent
+	Declines        []ReviewDecline           // users that declined to review the PR, never assigned to it again
+	CreatedAt       time.Time
+	MergedAt        *time.Time // nil if PR is not merged yet
+	Labels          []string   // reviewers with matching tags are preferred
+	ArchivedTeamID  int64      // team of the author when it was archived, 0 if it wasn't while the PR was open
+
+	// Repository and ChangedFiles are used to route the PR to code owners on creation.
+	// They are optional and not stored.
+	Repository   string
+	ChangedFiles []string
+}
+
+// ReviewersIDsBySource returns reviewers that were assigned the given way,
+// in the order of ReviewersIDs.
+func (pr *PullRequest) ReviewersIDsBySource(source ReviewerSource) []string {
+	var ids []string
+	for _, id := range pr.ReviewersIDs {
+		if pr.ReviewerSources[id] == source {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
-// MaxReviewersAmount defines the maximum number of reviewers that can be assigned to a PR.
-const MaxReviewersAmount = 2
+// HasDecision reports whether the reviewer has submitted a decision on the PR.
+func (pr *PullRequest) HasDecision(reviewerID string) bool {
+	_, ok := pr.Decisions[reviewerID]
+	return ok
+}
+
+// HasDeclined reports whether the user has declined to review the PR.
+func (pr *PullRequest) HasDeclined(userID string) bool {
+	return slices.ContainsFunc(pr.Declines, func(d ReviewDecline) bool { return d.UserID == userID })
+}
+
+// DeclinedIDs returns users that declined to review the PR, in the order of their declines.
+func (pr *PullRequest) DeclinedIDs() []string {
+	ids := make([]string, len(pr.Declines))
+	for i, d := range pr.Declines {
+		ids[i] = d.UserID
+	}
+	return ids
+}
+
+// CheckTransition returns ErrInvalidTransition if the PR can't move from its current status to the given one.
+func (pr *PullRequest) CheckTransition(to PRStatus) error {
+	if !slices.Contains(prTransitions[pr.Status], to) {
+		return fmt.Errorf("%w: %s PR cannot become %s", ErrInvalidTransition, pr.Status, to)
+	}
+	return nil
+}
+
+// CheckReviewable returns an error if reviewers of the PR can't be changed or submit decisions:
+// ErrPRMerged for merged PRs, ErrPRNotOpen for drafts and closed PRs.
+func (pr *PullRequest) CheckReviewable() error {
+	switch pr.Status {
+	case StatusMerged:
+		return ErrPRMerged
+	case StatusDraft, StatusClosed:
+		return fmt.Errorf("%w: PR is %s", ErrPRNotOpen, pr.Status)
+	default:
+		return nil
+	}
+}
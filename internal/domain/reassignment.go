@@ -0,0 +1,32 @@
+package domain
+
+// ReviewReassignment describes an open review handed over from one reviewer to another
+type ReviewReassignment struct {
+	PRID          string
+	OldReviewerID string
+	// NewReviewerID is empty if the old reviewer was removed without replacement
+	// (the PR had more reviewers than its team policy allows)
+	NewReviewerID string
+}
+
+// UnreassignedReview is an open review that could not be handed over,
+// the reviewer stays assigned to the PR
+type UnreassignedReview struct {
+	PRID       string
+	ReviewerID string
+	Reason     string
+}
+
+// ReassignmentReport describes how open reviews of a user, or of users leaving together,
+// were handed over to other reviewers
+type ReassignmentReport struct {
+	Reassigned    []ReviewReassignment
+	NotReassigned []UnreassignedReview
+}
+
+// ReviewerTopUp is a reviewer assigned to fill a missing reviewer place of an open PR
+type ReviewerTopUp struct {
+	PRID       string
+	ReviewerID string
+	Source     ReviewerSource
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// UnassignReason tells why an assignment of a reviewer to a PR has ended
+type UnassignReason string
+
+const (
+	UnassignReassigned  = UnassignReason("REASSIGNED")  // replaced by another reviewer, e.g. on reassign or absence
+	UnassignDeclined    = UnassignReason("DECLINED")    // the reviewer declined the review
+	UnassignDeactivated = UnassignReason("DEACTIVATED") // the reviewer was deactivated
+	UnassignManual      = UnassignReason("MANUAL")      // removed by hand
+	UnassignClosed      = UnassignReason("CLOSED")      // the PR was closed without merging
+	UnassignOverdue     = UnassignReason("OVERDUE")     // replaced as the review was overdue for too long
+	UnassignLeftTeam    = UnassignReason("LEFT_TEAM")   // the reviewer left the team or moved to another one
+)
+
+// ReviewAssignment is a current or ended assignment of a reviewer to a PR.
+// Ended assignments are kept, so they form the assignment history of the PR.
+type ReviewAssignment struct {
+	UserID         string
+	Source         ReviewerSource
+	AssignedAt     time.Time
+	UnassignedAt   *time.Time     // nil while the assignment is current
+	UnassignReason UnassignReason // empty while the assignment is current
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Decision is the outcome of a review submitted by a reviewer
+type Decision string
+
+const (
+	DecisionApproved         = Decision("APPROVED")
+	DecisionChangesRequested = Decision("CHANGES_REQUESTED")
+	DecisionCommented        = Decision("COMMENTED")
+)
+
+// ReviewDecision is the latest decision of a reviewer on a PR.
+// Submitting a new decision replaces the previous one.
+type ReviewDecision struct {
+	Decision  Decision
+	Text      string // optional comment of the reviewer
+	DecidedAt time.Time
+}
+
+// ReviewStatus filters reviews by whether the reviewer has submitted a decision
+type ReviewStatus string
+
+const (
+	ReviewStatusAny     = ReviewStatus("")
+	ReviewStatusPending = ReviewStatus("PENDING")
+	ReviewStatusDecided = ReviewStatus("DECIDED")
+)
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// ReviewDecline records that a reviewer refused to review a PR, e.g. for lack of context
+// or a conflict of interest. A user that declined a PR is never assigned to it again.
+type ReviewDecline struct {
+	UserID     string
+	Reason     string
+	DeclinedAt time.Time
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// PendingAssignment is a current assignment of a reviewer without a decision to an OPEN PR,
+// together with the reminders the reviewer has been sent about it
+type PendingAssignment struct {
+	PRID           string
+	ReviewerID     string
+	AssignedAt     time.Time
+	RemindersSent  int
+	LastRemindedAt *time.Time // nil if no reminder was sent yet
+}
+
+// ReminderPolicy defines when reviewers are reminded about pending assignments
+type ReminderPolicy struct {
+	After       time.Duration // age of the assignment before the first reminder
+	MaxInterval time.Duration // upper bound of the interval between reminders, at least After
+}
+
+// NextReminderAt returns when the reviewer is to be reminded about the assignment next.
+// The first reminder is due After the assignment, then the interval doubles with every
+// reminder sent, up to MaxInterval.
+func (p ReminderPolicy) NextReminderAt(a PendingAssignment) time.Time {
+	if a.RemindersSent == 0 || a.LastRemindedAt == nil {
+		return a.AssignedAt.Add(p.After)
+	}
+
+	maxInterval := max(p.MaxInterval, p.After)
+	interval := p.After
+	for i := 1; i < a.RemindersSent && interval < maxInterval; i++ {
+		interval *= 2
+	}
+
+	return a.LastRemindedAt.Add(min(interval, maxInterval))
+}
+
+// ReviewReminder is a single message reminding the reviewer about the PRs waiting for their review
+type ReviewReminder struct {
+	ReviewerID string
+	Items      []ReminderItem
+}
+
+// ReminderItem is a PR in the reminder
+type ReminderItem struct {
+	PR         *PullRequest
+	AssignedAt time.Time
+	Reminder   int // number of the reminder about this assignment, 1 for the first one
+}
+
+// ReminderReport is the outcome of a single run of the reminder scheduler
+type ReminderReport struct {
+	Sent   []ReviewReminder
+	Failed []UnsentReminder // reminders the notifier failed to deliver, retried on the next run
+}
+
+// UnsentReminder describes why the reminder to the reviewer wasn't delivered
+type UnsentReminder struct {
+	ReviewerID string
+	Reason     string
+}
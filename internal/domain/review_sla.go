@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// PendingReview is a current assignment of a reviewer without a decision to an OPEN PR,
+// together with the review SLA of the author's team
+type PendingReview struct {
+	PRID             string
+	PRName           string
+	ReviewerID       string
+	TeamName         string // team of the PR author, defining the SLA
+	AssignedAt       time.Time
+	OverdueAt        *time.Time // when the review was found overdue, nil if it was not yet
+	ReviewSLAHours   int
+	SLAReassignHours int
+}
+
+// Waiting returns the working time the reviewer has had the review for.
+func (r PendingReview) Waiting(now time.Time, hours WorkingHours) time.Duration {
+	return hours.Duration(r.AssignedAt, now)
+}
+
+// IsOverdue reports whether the reviewer exceeded the review SLA.
+func (r PendingReview) IsOverdue(now time.Time, hours WorkingHours) bool {
+	return r.ReviewSLAHours > 0 && r.Waiting(now, hours) >= time.Duration(r.ReviewSLAHours)*time.Hour
+}
+
+// IsDueForReassign reports whether the review has been overdue long enough to be handed over.
+func (r PendingReview) IsDueForReassign(now time.Time, hours WorkingHours) bool {
+	return r.SLAReassignHours > 0 && r.Waiting(now, hours) >= time.Duration(r.SLAReassignHours)*time.Hour
+}
+
+// OverdueReview is a pending review whose reviewer exceeded the review SLA
+type OverdueReview struct {
+	PendingReview
+	Waiting time.Duration // working time the reviewer has had the review for
+}
+
+// WorkingHours is the business calendar review SLAs are measured in:
+// from Start to End o'clock in Location, Monday to Friday. Holidays are not taken into account.
+type WorkingHours struct {
+	Start    int // hour the working day starts, 0-23
+	End      int // hour the working day ends, after Start and at most 24
+	Location *time.Location
+}
+
+// DefaultWorkingHours is a 9 to 18 working day in UTC
+var DefaultWorkingHours = WorkingHours{Start: 9, End: 18, Location: time.UTC}
+
+// Validate checks that the working day is not empty and fits into a day.
+func (h WorkingHours) Validate() error {
+	if h.Start < 0 || h.End > 24 || h.Start >= h.End {
+		return fmt.Errorf("working hours %d-%d must be within 0-24 and end after they start", h.Start, h.End)
+	}
+	return nil
+}
+
+// Duration returns the time between from and to that falls on working hours.
+func (h WorkingHours) Duration(from, to time.Time) time.Duration {
+	loc := h.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	from, to = from.In(loc), to.In(loc)
+
+	var total time.Duration
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), h.Start, 0, 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), h.End, 0, 0, 0, loc)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if start.Before(end) {
+			total += end.Sub(start)
+		}
+	}
+
+	return total
+}
+
+// SLAReport is the outcome of a single run of the review SLA check
+type SLAReport struct {
+	Overdue       []PendingReview // reviews found overdue in this run
+	Reassigned    []ReviewReassignment
+	NotReassigned []UnreassignedReview // overdue reviews that kept their reviewer, e.g. as there is no replacement
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingHours_Duration(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	hours := WorkingHours{Start: 9, End: 18, Location: moscow}
+
+	// 2025-03-07 is a Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, moscow)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected time.Duration
+	}{
+		{name: "within a working day", from: at(7, 10, 0), to: at(7, 12, 30), expected: 150 * time.Minute},
+		{name: "before the working day", from: at(7, 6, 0), to: at(7, 9, 30), expected: 30 * time.Minute},
+		{name: "evening to next morning", from: at(6, 17, 0), to: at(7, 10, 0), expected: 2 * time.Hour},
+		{name: "over the weekend", from: at(7, 17, 0), to: at(10, 10, 0), expected: 2 * time.Hour},
+		{name: "during the weekend", from: at(8, 10, 0), to: at(9, 20, 0), expected: 0},
+		{name: "full week", from: at(10, 0, 0), to: at(17, 0, 0), expected: 5 * 9 * time.Hour},
+		// 9:00 Moscow is 6:00 UTC
+		{name: "other timezone", from: at(7, 9, 0).UTC(), to: time.Date(2025, 3, 7, 7, 0, 0, 0, time.UTC), expected: time.Hour},
+		{name: "to before from", from: at(7, 12, 0), to: at(7, 10, 0), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hours.Duration(tt.from, tt.to))
+		})
+	}
+}
+
+func TestWorkingHours_Validate(t *testing.T) {
+	assert.NoError(t, DefaultWorkingHours.Validate())
+	assert.NoError(t, WorkingHours{Start: 0, End: 24}.Validate())
+	assert.Error(t, WorkingHours{Start: 18, End: 9}.Validate())
+	assert.Error(t, WorkingHours{Start: 9, End: 9}.Validate())
+	assert.Error(t, WorkingHours{Start: -1, End: 18}.Validate())
+	assert.Error(t, WorkingHours{Start: 9, End: 25}.Validate())
+}
+
+func TestPendingReview_IsOverdue(t *testing.T) {
+	// assigned on Friday at 17:00 UTC
+	review := PendingReview{AssignedAt: time.Date(2025, 3, 7, 17, 0, 0, 0, time.UTC), ReviewSLAHours: 4, SLAReassignHours: 8}
+
+	monday := time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC)
+	assert.Equal(t, 3*time.Hour, review.Waiting(monday, DefaultWorkingHours))
+	assert.False(t, review.IsOverdue(monday, DefaultWorkingHours))
+
+	monday = monday.Add(time.Hour)
+	assert.True(t, review.IsOverdue(monday, DefaultWorkingHours))
+	assert.False(t, review.IsDueForReassign(monday, DefaultWorkingHours))
+
+	noSLA := PendingReview{AssignedAt: review.AssignedAt}
+	assert.False(t, noSLA.IsOverdue(monday.AddDate(0, 1, 0), DefaultWorkingHours))
+}
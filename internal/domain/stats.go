@@ -12,5 +12,7 @@ type Stats struct {
 	TotalPRs   int
 	OpenPRs    int
 	MergedPRs  int
+	DraftPRs   int
+	ClosedPRs  int
 	Reviewers  []UserReviewStats
 }
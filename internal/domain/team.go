@@ -1,8 +1,68 @@
 package domain
 
+import "time"
+
+// AssignmentStrategy defines how reviewers are picked among the candidates of a team
+type AssignmentStrategy string
+
+const (
+	StrategyRandom      = AssignmentStrategy("RANDOM")
+	StrategyRoundRobin  = AssignmentStrategy("ROUND_ROBIN")
+	StrategyLeastLoaded = AssignmentStrategy("LEAST_LOADED")
+)
+
+// IsValid reports whether the strategy is one of the known strategies.
+func (s AssignmentStrategy) IsValid() bool {
+	switch s {
+	case StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Default bounds of the number of reviewers assigned to a PR,
+// used when the team doesn't configure its own policy.
+const (
+	DefaultMinReviewers = 0
+	DefaultMaxReviewers = 2
+)
+
 // Team represents a development team with its members.
 type Team struct {
-	ID      int64
-	Name    string
-	Members []User
+	ID                 int64
+	Name               string
+	AssignmentStrategy AssignmentStrategy // strategy used to select reviewers for PRs of team members
+	MinReviewers       int                // PR creation fails if fewer reviewers can be assigned
+	MaxReviewers       int                // maximum number of reviewers assigned to a PR
+	FallbackTeams      []string           // teams providing reviewers when the team lacks candidates, in priority order
+	Members            []User
+
+	// Merge policy of PRs authored by team members
+	MinApprovals          int  // PR can't be merged with fewer APPROVED decisions
+	AllowChangesRequested bool // PR can be merged while a reviewer requests changes
+
+	// Review SLA of PRs authored by team members, in working hours (see WorkingHours)
+	ReviewSLAHours   int // reviewers without a decision are overdue after it; 0 means no SLA
+	SLAReassignHours int // overdue reviews are reassigned after it; 0 means they are never reassigned
+
+	ArchivedAt *time.Time // archived teams are hidden from lookups, nil if the team is in use
+}
+
+// HasValidReviewersPolicy reports whether the reviewer count bounds of the team are consistent.
+func (t *Team) HasValidReviewersPolicy() bool {
+	return t.MinReviewers >= 0 && t.MaxReviewers >= 1 && t.MinReviewers <= t.MaxReviewers
+}
+
+// HasValidReviewSLA reports whether reviews can be reassigned only after they are overdue.
+func (t *Team) HasValidReviewSLA() bool {
+	if t.ReviewSLAHours < 0 || t.SLAReassignHours < 0 {
+		return false
+	}
+	return t.SLAReassignHours == 0 || (t.ReviewSLAHours > 0 && t.SLAReassignHours >= t.ReviewSLAHours)
+}
+
+// HasValidMergePolicy reports whether the required approvals can be given by the reviewers of a PR.
+func (t *Team) HasValidMergePolicy() bool {
+	return t.MinApprovals >= 0 && t.MinApprovals <= t.MaxReviewers
 }
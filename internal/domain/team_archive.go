@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TeamArchive is the outcome of archiving a team
+type TeamArchive struct {
+	TeamName        string
+	ArchivedAt      time.Time
+	DetachedMembers []string            // former members, now without a team and inactive
+	FlaggedPRs      []string            // open and draft PRs of the former members
+	Reassignment    *ReassignmentReport // open reviews of the former members
+}
+
+// OrphanedPR is an open or draft PR whose author's team was archived while the PR was open
+type OrphanedPR struct {
+	PRID         string
+	PRName       string
+	AuthorID     string
+	Status       PRStatus
+	ArchivedTeam string // name of the archived team
+}
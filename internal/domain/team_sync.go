@@ -0,0 +1,42 @@
+package domain
+
+// RosterMember is an entry of the full desired roster of a team.
+// Review capacity and tags are optional: if they are missing, the stored values of an existing user
+// are kept, and a new user gets no limit and no tags.
+type RosterMember struct {
+	ID             string
+	Name           string
+	IsActive       bool
+	MaxOpenReviews *int     // nil keeps the stored limit
+	Tags           []string // nil keeps the stored tags, an empty list clears them
+}
+
+// Apply returns the user with the data of the roster entry.
+func (m RosterMember) Apply(user User) User {
+	user.ID = m.ID
+	user.Name = m.Name
+	user.IsActive = m.IsActive
+	if m.MaxOpenReviews != nil {
+		user.MaxOpenReviews = *m.MaxOpenReviews
+	}
+	if m.Tags != nil {
+		user.Tags = NormalizeTags(m.Tags)
+	}
+	return user
+}
+
+// RosterDiff describes the changes a team roster sync made, each list holds user IDs
+type RosterDiff struct {
+	TeamCreated bool     // the team didn't exist and was created with default settings
+	Created     []string // new users
+	Joined      []string // existing users without a team added to the team
+	Updated     []string // members whose name, activity, or listed review capacity or tags changed
+	Removed     []string // members missing from the roster, detached from the team
+	// Reassignment reports open reviews of the removed and deactivated members, nil unless they were handed over
+	Reassignment *ReassignmentReport
+}
+
+// HasChanges reports whether the sync changed anything.
+func (d *RosterDiff) HasChanges() bool {
+	return d.TeamCreated || len(d.Created)+len(d.Joined)+len(d.Updated)+len(d.Removed) > 0
+}
@@ -1,10 +1,54 @@
 package domain
 
+import (
+	"slices"
+	"strings"
+)
+
 // User represents a team member who can author or review pull requests
 type User struct {
 	ID       string
 	Name     string
-	TeamID   int64
+	TeamID   int64 // 0 if the user was removed from their team
 	TeamName string
 	IsActive bool // only active users can be assigned as reviewers
+	// MaxOpenReviews limits the number of OPEN PRs the user reviews at the same time.
+	// 0 means no limit.
+	MaxOpenReviews int
+	Tags           []string // skills of the user, matched against PR labels
+}
+
+// ReviewCandidate is a team member who can be assigned as a reviewer,
+// together with the data needed to rank candidates against each other.
+type ReviewCandidate struct {
+	UserID         string
+	OpenReviews    int            // number of OPEN pull requests the user currently reviews
+	MaxOpenReviews int            // review capacity of the user, 0 means no limit
+	Source         ReviewerSource // team pool the candidate was taken from
+	Tags           []string       // skills of the user
+}
+
+// AtCapacity reports whether the candidate cannot take any more open reviews.
+func (c ReviewCandidate) AtCapacity() bool {
+	return c.MaxOpenReviews > 0 && c.OpenReviews >= c.MaxOpenReviews
+}
+
+// HasAnyTag reports whether the candidate has at least one of the given labels among his tags.
+func (c ReviewCandidate) HasAnyTag(labels []string) bool {
+	return slices.ContainsFunc(c.Tags, func(tag string) bool {
+		return slices.Contains(labels, tag)
+	})
+}
+
+// NormalizeTags lowercases and trims tags (or PR labels), dropping empty ones and duplicates.
+// The order of the first occurrences is kept.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 )
@@ -10,8 +11,15 @@ import (
 // TeamRepository defines operations for managing teams
 type TeamRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, team *domain.Team) error
+	Update(ctx context.Context, tx *sql.Tx, team *domain.Team) error
 	GetByName(ctx context.Context, teamName string) (*domain.Team, error)
+	GetByID(ctx context.Context, teamID int64) (*domain.Team, error)
+	GetByNameForUpdate(ctx context.Context, tx *sql.Tx, teamName string) (*domain.Team, error)
 	GetTeamNameByID(ctx context.Context, teamID int64) (string, error)
+	GetFallbackTeams(ctx context.Context, teamID int64) ([]*domain.Team, error)
+	SetFallbackTeams(ctx context.Context, tx *sql.Tx, teamID int64, fallbackTeamIDs []int64) error
+	Archive(ctx context.Context, tx *sql.Tx, teamID int64) (time.Time, error)
+	Delete(ctx context.Context, tx *sql.Tx, teamID int64) error
 }
 
 // UserRepository defines operations for managing users
@@ -19,7 +27,11 @@ type UserRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	Update(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
-	GetActiveTeamMembersIDs(ctx context.Context, teamID int64, excludeUserID string) ([]string, error)
+	GetReviewCandidates(ctx context.Context, teamID int64, excludeUserID string) ([]domain.ReviewCandidate, error)
+	GetReviewCandidatesByIDs(ctx context.Context, userIDs []string, excludeUserID string) ([]domain.ReviewCandidate, error)
+	GetTeamReviewPool(ctx context.Context, teamID int64) ([]domain.ReviewPoolMember, error)
+	GetReviewPoolByIDs(ctx context.Context, userIDs []string) ([]domain.ReviewPoolMember, error)
+	DetachTeamMembers(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error)
 }
 
 // PullRequestRepository defines operations for managing pull requests and reviewers
@@ -28,9 +40,42 @@ type PullRequestRepository interface {
 	Update(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest) error
 	GetByID(ctx context.Context, prID string) (*domain.PullRequest, error)
 	GetByIDForUpdate(ctx context.Context, tx *sql.Tx, prID string) (*domain.PullRequest, error)
-	AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error
-	RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error
+	AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, source domain.ReviewerSource) error
+	RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, reason domain.UnassignReason) error
+	SetDecision(ctx context.Context, tx *sql.Tx, prID, userID string, decision *domain.ReviewDecision) error
+	AddDecline(ctx context.Context, tx *sql.Tx, prID string, decline *domain.ReviewDecline) error
 	GetPRsByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error)
+	GetAssignments(ctx context.Context, prID string) ([]domain.ReviewAssignment, error)
+	GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error)
+	MarkOverdue(ctx context.Context, tx *sql.Tx, prID, userID string, overdueAt time.Time) error
+	GetRemindableAssignments(ctx context.Context, assignedBefore time.Time) ([]domain.PendingAssignment, error)
+	MarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, remindedAt time.Time) error
+	UnmarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, lastRemindedAt *time.Time) error
+	GetUnderstaffedPRIDs(ctx context.Context) ([]string, error)
+	GetUnderstaffedPRIDsByTeam(ctx context.Context, teamID int64) ([]string, error)
+	AddForcedMerge(ctx context.Context, tx *sql.Tx, merge *domain.ForcedMerge) error
+	GetForcedMerges(ctx context.Context) ([]domain.ForcedMerge, error)
+	AddEvent(ctx context.Context, tx *sql.Tx, event *domain.PREvent) error
+	GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+	FlagTeamArchived(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error)
+	FlagTeamReviewsArchived(ctx context.Context, tx *sql.Tx, teamID int64) error
+	CountOpenReviewsByTeam(ctx context.Context, tx *sql.Tx, teamID int64) (int, error)
+	GetOrphanedPRs(ctx context.Context) ([]domain.OrphanedPR, error)
+}
+
+// CodeOwnersRepository defines operations for managing CODEOWNERS files of repositories
+type CodeOwnersRepository interface {
+	Upsert(ctx context.Context, tx *sql.Tx, codeOwners *domain.CodeOwners) error
+	GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error)
+}
+
+// AbsenceRepository defines operations for managing out-of-office periods of users
+type AbsenceRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, absence *domain.Absence) error
+	Delete(ctx context.Context, tx *sql.Tx, userID string, absenceID int64) error
+	GetByUser(ctx context.Context, userID string) ([]domain.Absence, error)
+	GetStartedToReassign(ctx context.Context, now time.Time) ([]domain.Absence, error)
+	MarkReassigned(ctx context.Context, tx *sql.Tx, absenceID int64, reassignedAt time.Time) error
 }
 
 type StatsRepository interface {
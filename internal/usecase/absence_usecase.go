@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/repository"
+)
+
+type AbsenceUseCase struct {
+	absenceRepo repository.AbsenceRepository
+	userRepo    repository.UserRepository
+	reassigner  openReviewsReassigner
+	db          *sql.DB
+}
+
+func NewAbsenceUseCase(
+	absenceRepo repository.AbsenceRepository,
+	userRepo repository.UserRepository,
+	reassigner openReviewsReassigner,
+	db *sql.DB) *AbsenceUseCase {
+	return &AbsenceUseCase{
+		absenceRepo: absenceRepo,
+		userRepo:    userRepo,
+		reassigner:  reassigner,
+		db:          db,
+	}
+}
+
+// AddAbsence schedules an out-of-office period of the user. While the absence covers
+// the current time the user is not selected as a reviewer.
+// If reassignReviews is set, open reviews of the user are handed over to other reviewers
+// when the absence starts (see ReassignStartedAbsences).
+//
+// Returns:
+//   - *domain.Absence: created absence with its ID
+//   - error: domain.ErrNotFound if user doesn't exist, domain.ErrInvalidAbsence if the period
+//     is empty or has already ended, or any database error
+func (u *AbsenceUseCase) AddAbsence(ctx context.Context, userID string, startsAt, endsAt time.Time, reassignReviews bool) (*domain.Absence, error) {
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalidAbsence)
+	}
+	if !endsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: the period has already ended", domain.ErrInvalidAbsence)
+	}
+
+	// Check that user exists
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	absence := &domain.Absence{
+		UserID:          userID,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		ReassignReviews: reassignReviews,
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.absenceRepo.Create(ctx, tx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// GetAbsences returns current and upcoming absences of the user.
+//
+// Returns:
+//   - []domain.Absence: absences ordered by their start (empty if there are none)
+//   - error: domain.ErrNotFound if user doesn't exist, or any database error
+func (u *AbsenceUseCase) GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return u.absenceRepo.GetByUser(ctx, userID)
+}
+
+// RemoveAbsence deletes an absence of the user, e.g. a cancelled vacation or one that ended earlier.
+// Reviews handed over when the absence started are not given back.
+//
+// Returns:
+//   - []domain.Absence: remaining current and upcoming absences of the user
+//   - error: domain.ErrNotFound if the user has no absence with such ID, or any database error
+func (u *AbsenceUseCase) RemoveAbsence(ctx context.Context, userID string, absenceID int64) ([]domain.Absence, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.absenceRepo.Delete(ctx, tx, userID, absenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return u.absenceRepo.GetByUser(ctx, userID)
+}
+
+// ReassignStartedAbsences hands over open reviews of users whose absences have started and
+// request reassignment. Each review is reassigned as by PRUseCase.ReassignReviewer;
+// if there is no replacement the absent user stays the reviewer.
+// Every absence is processed once, in its own transaction. An absence that fails is left
+// for the next run and doesn't hold up the others.
+//
+// Returns:
+//   - []domain.AbsenceReassignment: processed absences with their reassignment reports
+//   - error: errors of the absences that failed, joined, or any database error on reading the absences;
+//     absences processed anyway are included in the result
+func (u *AbsenceUseCase) ReassignStartedAbsences(ctx context.Context) ([]domain.AbsenceReassignment, error) {
+	absences, err := u.absenceRepo.GetStartedToReassign(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.AbsenceReassignment
+	var errs []error
+	for _, absence := range absences {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		reassignment, err := u.reassignAbsentReviewer(ctx, absence)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("absence %d of user %s: %w", absence.ID, absence.UserID, err))
+			continue
+		}
+		result = append(result, *reassignment)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// reassignAbsentReviewer hands over open reviews of the absent user and marks the absence as processed.
+func (u *AbsenceUseCase) reassignAbsentReviewer(ctx context.Context, absence domain.Absence) (*domain.AbsenceReassignment, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	report, err := u.reassigner.reassignOpenReviewsTx(ctx, tx, absence.UserID, domain.UnassignReassigned)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = u.absenceRepo.MarkReassigned(ctx, tx, absence.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	absence.ReassignedAt = &now
+
+	return &domain.AbsenceReassignment{Absence: absence, Report: *report}, nil
+}
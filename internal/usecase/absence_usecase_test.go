@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestAbsenceUseCase_AddAbsence_Success(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(7 * 24 * time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+	dbMock.ExpectBegin()
+	mockAbsenceRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(a *domain.Absence) bool {
+		return a.UserID == "u1" && a.StartsAt.Equal(startsAt) && a.EndsAt.Equal(endsAt) && a.ReassignReviews
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.Absence).ID = 1
+	}).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, mockUserRepo, new(ReviewAssignerMock), db)
+	absence, err := uc.AddAbsence(ctx, "u1", startsAt, endsAt, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), absence.ID)
+	assert.Nil(t, absence.ReassignedAt)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockAbsenceRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAbsenceUseCase_AddAbsence_Invalid(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+	now := time.Now()
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, mockUserRepo, new(ReviewAssignerMock), nil)
+
+	// Empty period
+	absence, err := uc.AddAbsence(ctx, "u1", now.Add(time.Hour), now.Add(time.Hour), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidAbsence)
+	assert.Nil(t, absence)
+
+	// Period in the past
+	absence, err = uc.AddAbsence(ctx, "u1", now.Add(-48*time.Hour), now.Add(-24*time.Hour), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidAbsence)
+	assert.Nil(t, absence)
+
+	// Unknown user
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+	absence, err = uc.AddAbsence(ctx, "ghost", now, now.Add(time.Hour), false)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, absence)
+
+	mockAbsenceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAbsenceUseCase_GetAbsences(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	absences := []domain.Absence{{ID: 1, UserID: "u1"}}
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+	mockAbsenceRepo.On("GetByUser", ctx, "u1").Return(absences, nil)
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, mockUserRepo, new(ReviewAssignerMock), nil)
+
+	result, err := uc.GetAbsences(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, absences, result)
+
+	result, err = uc.GetAbsences(ctx, "ghost")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+}
+
+func TestAbsenceUseCase_RemoveAbsence(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockAbsenceRepo.On("Delete", ctx, mock.Anything, "u1", int64(1)).Return(nil)
+	dbMock.ExpectCommit()
+	mockAbsenceRepo.On("GetByUser", ctx, "u1").Return([]domain.Absence{{ID: 2, UserID: "u1"}}, nil)
+
+	dbMock.ExpectBegin()
+	mockAbsenceRepo.On("Delete", ctx, mock.Anything, "u1", int64(5)).Return(domain.ErrNotFound)
+	dbMock.ExpectRollback()
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, new(UserRepoMock), new(ReviewAssignerMock), db)
+
+	remaining, err := uc.RemoveAbsence(ctx, "u1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Absence{{ID: 2, UserID: "u1"}}, remaining)
+
+	remaining, err = uc.RemoveAbsence(ctx, "u1", 5)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, remaining)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestAbsenceUseCase_ReassignStartedAbsences(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+	mockReassigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	absence := domain.Absence{ID: 1, UserID: "u1", ReassignReviews: true}
+	report := &domain.ReassignmentReport{
+		Reassigned:    []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"}},
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-2", Reason: domain.ErrAllAtCapacity.Error()}},
+	}
+
+	mockAbsenceRepo.On("GetStartedToReassign", ctx, mock.Anything).Return([]domain.Absence{absence}, nil)
+	dbMock.ExpectBegin()
+	mockReassigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u1", domain.UnassignReassigned).Return(report, nil)
+	mockAbsenceRepo.On("MarkReassigned", ctx, mock.Anything, int64(1), mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, new(UserRepoMock), mockReassigner, db)
+	result, err := uc.ReassignStartedAbsences(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, *report, result[0].Report)
+	assert.NotNil(t, result[0].Absence.ReassignedAt)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockAbsenceRepo.AssertExpectations(t)
+	mockReassigner.AssertExpectations(t)
+}
+
+func TestAbsenceUseCase_ReassignStartedAbsences_Error(t *testing.T) {
+	mockAbsenceRepo := new(AbsenceRepoMock)
+	mockReassigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mockAbsenceRepo.On("GetStartedToReassign", ctx, mock.Anything).Return([]domain.Absence{
+		{ID: 1, UserID: "u1", ReassignReviews: true},
+		{ID: 2, UserID: "u2", ReassignReviews: true},
+	}, nil)
+	dbMock.ExpectBegin()
+	mockReassigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u1", domain.UnassignReassigned).Return(nil, errors.New("db error"))
+	dbMock.ExpectRollback()
+	dbMock.ExpectBegin()
+	mockReassigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u2", domain.UnassignReassigned).Return(&domain.ReassignmentReport{}, nil)
+	mockAbsenceRepo.On("MarkReassigned", ctx, mock.Anything, int64(2), mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewAbsenceUseCase(mockAbsenceRepo, new(UserRepoMock), mockReassigner, db)
+	result, err := uc.ReassignStartedAbsences(ctx)
+
+	// the failed absence is not marked as processed and will be retried, the next one is processed anyway
+	assert.EqualError(t, err, "absence 1 of user u1: db error")
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(2), result[0].Absence.ID)
+	mockAbsenceRepo.AssertNotCalled(t, "MarkReassigned", mock.Anything, mock.Anything, int64(1), mock.Anything)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
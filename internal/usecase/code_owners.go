@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// parseCodeOwners parses a CODEOWNERS file.
+// Empty lines and comments (starting with '#') are skipped, every other line is
+// a path pattern followed by zero or more owners separated by whitespace.
+//
+// Returns:
+//   - []domain.CodeOwnersRule: rules in the file order
+//   - error: domain.ErrInvalidCodeOwners with the line number if a pattern or an owner is malformed
+func parseCodeOwners(content string) ([]domain.CodeOwnersRule, error) {
+	var rules []domain.CodeOwnersRule
+
+	for i, line := range strings.Split(content, "\n") {
+		// drop inline comments
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern, owners := fields[0], fields[1:]
+		if err := validateCodeOwnersPattern(pattern); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCodeOwners, i+1, err)
+		}
+
+		for _, owner := range owners {
+			if _, _, ok := parseCodeOwner(owner); !ok {
+				return nil, fmt.Errorf("%w: line %d: owner %q must be @user_id or @org/team_name",
+					domain.ErrInvalidCodeOwners, i+1, owner)
+			}
+		}
+
+		rules = append(rules, domain.CodeOwnersRule{Pattern: pattern, Owners: owners})
+	}
+
+	return rules, nil
+}
+
+// validateCodeOwnersPattern checks that the pattern is supported by the matcher
+func validateCodeOwnersPattern(pattern string) error {
+	if strings.HasPrefix(pattern, "!") {
+		return fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("malformed pattern %q", pattern)
+		}
+	}
+
+	return nil
+}
+
+// parseCodeOwner splits an owner reference into its name and kind.
+// "@u1" references the user with ID u1, "@org/backend" references the team "backend".
+func parseCodeOwner(owner string) (name string, isTeam bool, ok bool) {
+	ref, found := strings.CutPrefix(owner, "@")
+	if !found || ref == "" {
+		return "", false, false
+	}
+
+	org, team, isTeam := strings.Cut(ref, "/")
+	if !isTeam {
+		return ref, false, true
+	}
+
+	if org == "" || team == "" || strings.Contains(team, "/") {
+		return "", false, false
+	}
+
+	return team, true, true
+}
+
+// matchCodeOwners returns the owners of the given files: for each file the owners of the
+// last matching rule are taken, as in CODEOWNERS.
+//
+// Returns:
+//   - []string: unique owner references in the order of their first appearance (empty if no rule matches)
+func matchCodeOwners(rules []domain.CodeOwnersRule, files []string) []string {
+	var owners []string
+
+	for _, file := range files {
+		file = strings.TrimPrefix(path.Clean("/"+file), "/")
+
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !matchCodeOwnersPattern(rules[i].Pattern, file) {
+				continue
+			}
+
+			for _, owner := range rules[i].Owners {
+				if !slices.Contains(owners, owner) {
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+
+	return owners
+}
+
+// matchCodeOwnersPattern reports whether the file path matches a gitignore-style pattern:
+//   - a pattern without a slash (except a trailing one) matches at any depth, otherwise it is
+//     relative to the repository root
+//   - a pattern matching a directory matches everything inside it; a trailing slash
+//     makes the pattern match directories only
+//   - '*' and '?' do not cross '/', '**' matches any number of directories
+func matchCodeOwnersPattern(pattern, file string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	patternSegments := strings.Split(pattern, "/")
+	fileSegments := strings.Split(file, "/")
+
+	if anchored {
+		return matchSegments(patternSegments, fileSegments, dirOnly)
+	}
+
+	for i := range fileSegments {
+		if matchSegments(patternSegments, fileSegments[i:], dirOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments.
+// Segments left in the file after the whole pattern is matched are contents of the matched directory.
+func matchSegments(pattern, file []string, dirOnly bool) bool {
+	if len(pattern) == 0 {
+		return len(file) > 0 || !dirOnly
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(file); i++ {
+			if matchSegments(pattern[1:], file[i:], dirOnly) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(file) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], file[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(pattern[1:], file[1:], dirOnly)
+}
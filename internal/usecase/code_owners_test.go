@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `
+# Default owners
+*            @u1
+
+/docs/       @avito/docs @u2  # inline comment
+*.go         @avito/backend
+/vendor/
+`
+
+	rules, err := parseCodeOwners(content)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnersRule{
+		{Pattern: "*", Owners: []string{"@u1"}},
+		{Pattern: "/docs/", Owners: []string{"@avito/docs", "@u2"}},
+		{Pattern: "*.go", Owners: []string{"@avito/backend"}},
+		{Pattern: "/vendor/", Owners: []string{}},
+	}, rules)
+
+	// Empty file has no rules
+	rules, err = parseCodeOwners("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestParseCodeOwners_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"email owner":       "*.go dev@example.com",
+		"owner without @":   "*.go u1",
+		"nested team":       "*.go @org/team/sub",
+		"negated pattern":   "!*.go @u1",
+		"malformed pattern": "[a-.go @u1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseCodeOwners("# header\n" + content)
+			assert.ErrorIs(t, err, domain.ErrInvalidCodeOwners)
+			assert.ErrorContains(t, err, "line 2")
+		})
+	}
+}
+
+func TestParseCodeOwner(t *testing.T) {
+	name, isTeam, ok := parseCodeOwner("@u1")
+	assert.True(t, ok)
+	assert.False(t, isTeam)
+	assert.Equal(t, "u1", name)
+
+	name, isTeam, ok = parseCodeOwner("@avito/backend")
+	assert.True(t, ok)
+	assert.True(t, isTeam)
+	assert.Equal(t, "backend", name)
+
+	_, _, ok = parseCodeOwner("@")
+	assert.False(t, ok)
+}
+
+func TestMatchCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"*", "main.go", true},
+		{"*", "internal/usecase/pr.go", true},
+		{"*.go", "internal/usecase/pr.go", true},
+		{"*.go", "README.md", false},
+		{"/docs/", "docs/api.md", true},
+		{"/docs/", "internal/docs/api.md", false},
+		{"/docs/", "docs", false}, // trailing slash matches directories only
+		{"docs/", "internal/docs/api.md", true},
+		{"docs", "docs", true},
+		{"internal/usecase", "internal/usecase/pr.go", true},
+		{"internal/usecase", "cmd/internal/usecase/pr.go", false},
+		{"internal/*.go", "internal/main.go", true},
+		{"internal/*.go", "internal/usecase/pr.go", false},
+		{"**/migrations", "db/postgres/migrations/001.sql", true},
+		{"internal/**/pr.go", "internal/pr.go", true},
+		{"internal/**/pr.go", "internal/adapter/http/pr.go", true},
+		{"/api/**", "api/openapi.yml", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchCodeOwnersPattern(tt.pattern, tt.file), "%s ~ %s", tt.pattern, tt.file)
+	}
+}
+
+func TestMatchCodeOwners_LastMatchingRuleWins(t *testing.T) {
+	rules := []domain.CodeOwnersRule{
+		{Pattern: "*", Owners: []string{"@u1"}},
+		{Pattern: "*.go", Owners: []string{"@avito/backend", "@u2"}},
+		{Pattern: "/internal/usecase/", Owners: []string{"@u3"}},
+		{Pattern: "/vendor/"},
+	}
+
+	owners := matchCodeOwners(rules, []string{
+		"internal/adapter/http/router.go",
+		"/internal/usecase/pr_usecase.go",
+		"cmd/server/main.go",
+		"vendor/lib/lib.go",
+	})
+	assert.Equal(t, []string{"@avito/backend", "@u2", "@u3"}, owners)
+
+	owners = matchCodeOwners(rules, []string{"README.md"})
+	assert.Equal(t, []string{"@u1"}, owners)
+
+	assert.Empty(t, matchCodeOwners(nil, []string{"README.md"}))
+}
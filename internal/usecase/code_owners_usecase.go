@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/repository"
+)
+
+type CodeOwnersUseCase struct {
+	codeOwnersRepo repository.CodeOwnersRepository
+	db             *sql.DB
+}
+
+func NewCodeOwnersUseCase(codeOwnersRepo repository.CodeOwnersRepository, db *sql.DB) *CodeOwnersUseCase {
+	return &CodeOwnersUseCase{
+		codeOwnersRepo: codeOwnersRepo,
+		db:             db,
+	}
+}
+
+// SetCodeOwners validates and stores the CODEOWNERS file of the repository,
+// replacing the previous one. Owners are not required to exist in the service:
+// unknown users and teams are skipped when reviewers are assigned.
+//
+// Returns:
+//   - *domain.CodeOwners: stored file with parsed rules
+//   - error: domain.ErrInvalidCodeOwners if the file can't be parsed, or any database error
+func (u *CodeOwnersUseCase) SetCodeOwners(ctx context.Context, repository, content string) (*domain.CodeOwners, error) {
+	rules, err := parseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners := &domain.CodeOwners{
+		Repository: repository,
+		Content:    content,
+		Rules:      rules,
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.codeOwnersRepo.Upsert(ctx, tx, codeOwners)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return codeOwners, nil
+}
+
+// GetCodeOwners returns the CODEOWNERS file of the repository.
+//
+// Returns:
+//   - *domain.CodeOwners: stored file with parsed rules
+//   - error: domain.ErrNotFound if the repository has no CODEOWNERS, or any database error
+func (u *CodeOwnersUseCase) GetCodeOwners(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	codeOwners, err := u.codeOwnersRepo.GetByRepository(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners.Rules, err = parseCodeOwners(codeOwners.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return codeOwners, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestCodeOwnersUseCase_SetCodeOwners_Success(t *testing.T) {
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	content := "*.go @avito/backend\n/docs/ @u1\n"
+
+	dbMock.ExpectBegin()
+	mockCodeOwnersRepo.On("Upsert", ctx, mock.Anything, mock.MatchedBy(func(co *domain.CodeOwners) bool {
+		return co.Repository == "avito/service" && co.Content == content
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewCodeOwnersUseCase(mockCodeOwnersRepo, db)
+	result, err := uc.SetCodeOwners(ctx, "avito/service", content)
+
+	require.NoError(t, err)
+	assert.Len(t, result.Rules, 2)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockCodeOwnersRepo.AssertExpectations(t)
+}
+
+func TestCodeOwnersUseCase_SetCodeOwners_Invalid(t *testing.T) {
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	uc := NewCodeOwnersUseCase(mockCodeOwnersRepo, db)
+	result, err := uc.SetCodeOwners(context.Background(), "avito/service", "*.go dev@example.com")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCodeOwners)
+	assert.Nil(t, result)
+	mockCodeOwnersRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCodeOwnersUseCase_GetCodeOwners(t *testing.T) {
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+	ctx := context.Background()
+
+	mockCodeOwnersRepo.On("GetByRepository", ctx, "avito/service").
+		Return(&domain.CodeOwners{Repository: "avito/service", Content: "* @u1"}, nil)
+	mockCodeOwnersRepo.On("GetByRepository", ctx, "unknown").Return(nil, domain.ErrNotFound)
+
+	uc := NewCodeOwnersUseCase(mockCodeOwnersRepo, nil)
+
+	result, err := uc.GetCodeOwners(ctx, "avito/service")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnersRule{{Pattern: "*", Owners: []string{"@u1"}}}, result.Rules)
+
+	result, err = uc.GetCodeOwners(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// GetEvents returns the timeline of the PR: its creation, reviewer changes, status changes and merge.
+//
+// Returns:
+//   - []domain.PREvent: events ordered by their time (empty if there are none)
+//   - error: domain.ErrNotFound if PR doesn't exist, or any database error
+func (u *PRUseCase) GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	// Check that PR exists
+	if _, err := u.prRepo.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	return u.prRepo.GetEvents(ctx, prID)
+}
+
+// GetOrphanedPRs returns open and draft PRs whose author's team was archived while they were open
+// (see TeamUseCase.ArchiveTeam). Their authors are without a team, so missing reviewers can't be
+// assigned automatically: the PRs are expected to be handed over or closed by hand.
+//
+// Returns:
+//   - []domain.OrphanedPR: PRs ordered by creation time (empty if there are none)
+//   - error: any database error
+func (u *PRUseCase) GetOrphanedPRs(ctx context.Context) ([]domain.OrphanedPR, error) {
+	return u.prRepo.GetOrphanedPRs(ctx)
+}
+
+// addEventTx appends an event to the timeline of the PR within the given transaction, so the event
+// is recorded only together with the change it describes.
+// The actor is taken from ctx (see domain.WithActor); defaultActor is recorded if ctx carries none.
+func (u *PRUseCase) addEventTx(ctx context.Context, tx *sql.Tx, prID string, eventType domain.PREventType, defaultActor string, payload map[string]any) error {
+	actor := domain.ActorFromContext(ctx)
+	if actor == "" {
+		actor = defaultActor
+	}
+
+	return u.prRepo.AddEvent(ctx, tx, &domain.PREvent{
+		PRID:    prID,
+		Type:    eventType,
+		Actor:   actor,
+		Payload: payload,
+	})
+}
+
+// addStatusChangedEventTx records the move of the PR from one status to another
+func (u *PRUseCase) addStatusChangedEventTx(ctx context.Context, tx *sql.Tx, prID string, from, to domain.PRStatus) error {
+	return u.addEventTx(ctx, tx, prID, domain.EventStatusChanged, "", map[string]any{
+		"from": string(from),
+		"to":   string(to),
+	})
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// MarkReadyForReview moves the DRAFT pull request to OPEN and assigns reviewers
+// as on PR creation, except that code owners are not known anymore.
+//
+// Returns:
+//   - *domain.PullRequest: PR with status OPEN and assigned reviewers
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrInvalidTransition if PR is not a draft,
+//     domain.ErrNotEnoughReviewers if fewer than team.MinReviewers reviewers can be assigned,
+//     or any database error
+func (u *PRUseCase) MarkReadyForReview(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return u.openPR(ctx, prID, domain.StatusDraft)
+}
+
+// ReopenPR moves the CLOSED pull request back to OPEN. Reviewers released on closing
+// are not restored: reviewers are assigned anew as on PR creation, except that code owners
+// are not known anymore.
+//
+// Returns:
+//   - *domain.PullRequest: PR with status OPEN and assigned reviewers
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrInvalidTransition if PR is not closed,
+//     domain.ErrNotEnoughReviewers if fewer than team.MinReviewers reviewers can be assigned,
+//     or any database error
+func (u *PRUseCase) ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return u.openPR(ctx, prID, domain.StatusClosed)
+}
+
+// openPR moves the PR from the given status to OPEN, assigning reviewers in the same transaction.
+func (u *PRUseCase) openPR(ctx context.Context, prID string, from domain.PRStatus) (*domain.PullRequest, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the PR row, so concurrent transitions are serialized
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		return nil, err // err can be domain.ErrNotFound
+	}
+
+	if pr.Status != from {
+		return nil, fmt.Errorf("%w: PR is %s, not %s", domain.ErrInvalidTransition, pr.Status, from)
+	}
+	if err = pr.CheckTransition(domain.StatusOpen); err != nil {
+		return nil, err
+	}
+
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := u.selectReviewersForReview(ctx, team, pr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status = domain.StatusOpen
+	err = u.prRepo.Update(ctx, tx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.addStatusChangedEventTx(ctx, tx, prID, from, domain.StatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.assignReviewersTx(ctx, tx, pr, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// ClosePR closes the pull request without merging and releases its reviewers,
+// together with their decisions. Their assignments stay in the history of the PR. This operation is idempotent - if PR is already closed,
+// it returns the PR without modifications.
+//
+// Returns:
+//   - *domain.PullRequest: PR with status CLOSED and no reviewers
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrInvalidTransition if PR is merged,
+//     or any database error
+func (u *PRUseCase) ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the PR row, so concurrent transitions are serialized
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		return nil, err // err can be domain.ErrNotFound
+	}
+
+	if pr.Status == domain.StatusClosed {
+		return pr, nil
+	}
+	if err = pr.CheckTransition(domain.StatusClosed); err != nil {
+		return nil, err
+	}
+
+	// Release reviewers, so the PR doesn't stay in their queues
+	for _, reviewerID := range pr.ReviewersIDs {
+		err = u.prRepo.RemoveReviewer(ctx, tx, prID, reviewerID, domain.UnassignClosed)
+		if err != nil {
+			return nil, err
+		}
+		err = u.addEventTx(ctx, tx, prID, domain.EventReviewerRemoved, "", map[string]any{
+			"reviewer_id": reviewerID,
+			"reason":      string(domain.UnassignClosed),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	from := pr.Status
+	pr.Status = domain.StatusClosed
+	err = u.prRepo.Update(ctx, tx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.addStatusChangedEventTx(ctx, tx, prID, from, domain.StatusClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// update PR model
+	pr.ReviewersIDs = nil
+	pr.ReviewerSources = nil
+	pr.Decisions = nil
+
+	return pr, nil
+}
@@ -3,7 +3,10 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
@@ -11,39 +14,77 @@ import (
 )
 
 type PRUseCase struct {
-	userRepo repository.UserRepository
-	prRepo   repository.PullRequestRepository
-	db       *sql.DB
+	userRepo       repository.UserRepository
+	prRepo         repository.PullRequestRepository
+	teamRepo       repository.TeamRepository
+	codeOwnersRepo repository.CodeOwnersRepository
+	selectors      map[domain.AssignmentStrategy]ReviewerSelector
+	workingHours   domain.WorkingHours // calendar of the review SLA
+	db             *sql.DB
 }
 
 func NewPRUseCase(
 	userRepo repository.UserRepository,
 	prRepo repository.PullRequestRepository,
+	teamRepo repository.TeamRepository,
+	codeOwnersRepo repository.CodeOwnersRepository,
+	workingHours domain.WorkingHours,
 	db *sql.DB) *PRUseCase {
 	return &PRUseCase{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		db:       db,
+		userRepo:       userRepo,
+		prRepo:         prRepo,
+		teamRepo:       teamRepo,
+		codeOwnersRepo: codeOwnersRepo,
+		selectors:      newSelectors(),
+		workingHours:   workingHours,
+		db:             db,
 	}
 }
 
 // CreatePRAndSetReviewers creates a new pull request with the given details and automatically
-// assigns up to 2 random reviewers from the author's team (excluding the author).
-// PR is created with status OPEN.
+// assigns up to team.MaxReviewers reviewers from the author's team (excluding the author and users at
+// their review capacity), selected with the team's assignment strategy.
+// If the PR carries its repository and changed files, owners of these files according to the
+// repository CODEOWNERS are preferred; they can belong to any team.
+// If the team is too small, missing reviewers are taken from its fallback teams.
+// Within each group candidates with tags matching the PR labels are preferred.
+// PR is created with status OPEN, or DRAFT if pr.Status is DRAFT; drafts get no reviewers
+// until they are marked ready for review (see MarkReadyForReview).
 //
 // Returns:
 //   - *domain.PullRequest: created PR with assigned reviewers in ReviewersIDs field
-//   - error: domain.ErrNotFound if author doesn't exist, domain.ErrPRExists if PR ID already exists,
+//   - error: domain.ErrNotFound if author doesn't exist or has no team, domain.ErrPRExists if PR ID already exists,
+//     domain.ErrNotEnoughReviewers if fewer than team.MinReviewers reviewers can be assigned,
 //     or any database error
 func (u *PRUseCase) CreatePRAndSetReviewers(ctx context.Context, pr domain.PullRequest) (*domain.PullRequest, error) {
-	// set PR status to open
-	pr.Status = domain.StatusOpen
+	// set PR status to open, unless it is a draft
+	if pr.Status != domain.StatusDraft {
+		pr.Status = domain.StatusOpen
+	}
+	pr.Labels = domain.NormalizeTags(pr.Labels)
 
-	// Select reviewers
-	reviewers, err := u.getReviewersToAssign(ctx, pr.AuthorID)
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
 	if err != nil { // err can be domain.ErrNotFound if author or his team do not exist
 		return nil, err
 	}
+	if team.ID == 0 {
+		return nil, fmt.Errorf("%w: author %s has no team", domain.ErrNotFound, pr.AuthorID)
+	}
+
+	var reviewers []domain.ReviewCandidate
+	if pr.Status == domain.StatusOpen {
+		// Code owners of the changed files are preferred
+		owners, err := u.getCodeOwnerCandidates(ctx, &pr)
+		if err != nil {
+			return nil, err
+		}
+
+		// Select reviewers
+		reviewers, err = u.selectReviewersForReview(ctx, team, &pr, owners)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Start transaction
 	tx, err := u.db.Begin()
@@ -58,12 +99,20 @@ func (u *PRUseCase) CreatePRAndSetReviewers(ctx context.Context, pr domain.PullR
 		return nil, err
 	}
 
+	// The author is the actor of the creation, unless the request tells otherwise
+	err = u.addEventTx(ctx, tx, pr.ID, domain.EventCreated, pr.AuthorID, map[string]any{
+		"name":      pr.Name,
+		"author_id": pr.AuthorID,
+		"status":    string(pr.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Assign each reviewer in repo
-	for _, revID := range reviewers {
-		err = u.prRepo.AddReviewer(ctx, tx, pr.ID, revID)
-		if err != nil {
-			return nil, err
-		}
+	err = u.assignReviewersTx(ctx, tx, &pr, reviewers)
+	if err != nil {
+		return nil, err
 	}
 
 	// Commit changes
@@ -71,19 +120,93 @@ func (u *PRUseCase) CreatePRAndSetReviewers(ctx context.Context, pr domain.PullR
 		return nil, err
 	}
 
+	return &pr, nil
+}
+
+// selectReviewersForReview selects up to team.MaxReviewers reviewers for a PR entering review.
+// If everyone is at capacity, the PR gets no reviewers unless the team requires some.
+//
+// Returns:
+//   - []domain.ReviewCandidate: reviewers to be assigned (can be empty)
+//   - error: domain.ErrNotEnoughReviewers if fewer than team.MinReviewers reviewers can be assigned,
+//     or any database error
+func (u *PRUseCase) selectReviewersForReview(ctx context.Context, team *domain.Team, pr *domain.PullRequest, owners []domain.ReviewCandidate) ([]domain.ReviewCandidate, error) {
+	reviewers, err := u.getReviewersToAssign(ctx, team, pr, team.MaxReviewers, owners, nil)
+	if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+		return nil, err
+	}
+
+	if len(reviewers) < team.MinReviewers {
+		return nil, fmt.Errorf("%w: team %q requires at least %d reviewers, only %d available",
+			domain.ErrNotEnoughReviewers, team.Name, team.MinReviewers, len(reviewers))
+	}
+
+	return reviewers, nil
+}
+
+// assignReviewersTx assigns the reviewers to the PR within the given transaction, records the assignments
+// in the PR timeline and adds them to the PR model.
+func (u *PRUseCase) assignReviewersTx(ctx context.Context, tx *sql.Tx, pr *domain.PullRequest, reviewers []domain.ReviewCandidate) error {
+	if pr.ReviewerSources == nil {
+		pr.ReviewerSources = make(map[string]domain.ReviewerSource, len(reviewers))
+	}
+
+	for _, rev := range reviewers {
+		err := u.prRepo.AddReviewer(ctx, tx, pr.ID, rev.UserID, rev.Source)
+		if err != nil {
+			return err
+		}
+		err = u.addEventTx(ctx, tx, pr.ID, domain.EventReviewerAssigned, "", map[string]any{
+			"reviewer_id": rev.UserID,
+			"source":      string(rev.Source),
+		})
+		if err != nil {
+			return err
+		}
+		pr.ReviewerSources[rev.UserID] = rev.Source
+	}
+
 	// Update PR model with assigned reviewers
-	pr.ReviewersIDs = reviewers
+	pr.ReviewersIDs = append(pr.ReviewersIDs, reviewerIDs(reviewers)...)
 
-	return &pr, nil
+	return nil
 }
 
 // MergePR marks the given pull request as MERGED. This operation is idempotent -
 // if PR is already merged, it returns the PR without modifications.
+// An open PR can be merged only if it meets the merge policy of the author's team:
+// enough APPROVED decisions and, unless the team allows it, no CHANGES_REQUESTED decisions.
+// PRs of former members of an archived team keep its policy; PRs of authors without a team are blocked.
 //
 // Returns:
 //   - *domain.PullRequest: PR with status MERGED and mergedAt timestamp set
-//   - error: domain.ErrNotFound if PR doesn't exist, or any database error
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrInvalidTransition if PR is a draft
+//     or closed, domain.ErrMergeBlocked (listing unmet conditions) if PR doesn't meet the merge policy,
+//     or any database error
 func (u *PRUseCase) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return u.mergePR(ctx, prID, nil)
+}
+
+// ForceMergePR merges the given pull request bypassing the merge policy of the author's team.
+// The merge is recorded in the audit trail together with the conditions that were not met.
+// Like MergePR it is idempotent: an already merged PR is returned as is and nothing is recorded.
+//
+// Returns:
+//   - *domain.PullRequest: PR with status MERGED and mergedAt timestamp set
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrInvalidTransition if PR is a draft
+//     or closed, or any database error
+func (u *PRUseCase) ForceMergePR(ctx context.Context, prID, forcedBy, reason string) (*domain.PullRequest, error) {
+	return u.mergePR(ctx, prID, &domain.ForcedMerge{PRID: prID, ForcedBy: forcedBy, Reason: reason})
+}
+
+// GetForcedMerges returns the audit trail of merges that bypassed the merge policy, newest first.
+func (u *PRUseCase) GetForcedMerges(ctx context.Context) ([]domain.ForcedMerge, error) {
+	return u.prRepo.GetForcedMerges(ctx)
+}
+
+// mergePR merges the PR, checking the merge policy unless forced is set.
+// The merge and, if forced, its audit record are recorded in the same transaction.
+func (u *PRUseCase) mergePR(ctx context.Context, prID string, forced *domain.ForcedMerge) (*domain.PullRequest, error) {
 	tx, err := u.db.Begin()
 	if err != nil {
 		return nil, err
@@ -99,7 +222,20 @@ func (u *PRUseCase) MergePR(ctx context.Context, prID string) (*domain.PullReque
 	// Set PR status as Merged.
 	// Operation in idempotent - even if it is already merged, keep it so
 	// But update in DB only if it is not merged yet
-	if pr.Status == domain.StatusOpen {
+	if pr.Status != domain.StatusMerged {
+		// Drafts and closed PRs can't be merged
+		if err = pr.CheckTransition(domain.StatusMerged); err != nil {
+			return nil, err
+		}
+
+		unmet, err := u.unmetMergeConditions(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		if forced == nil && len(unmet) > 0 {
+			return nil, fmt.Errorf("%w: %s", domain.ErrMergeBlocked, strings.Join(unmet, "; "))
+		}
+
 		pr.Status = domain.StatusMerged
 		now := time.Now()
 		pr.MergedAt = &now
@@ -109,6 +245,24 @@ func (u *PRUseCase) MergePR(ctx context.Context, prID string) (*domain.PullReque
 		if err != nil {
 			return nil, err
 		}
+
+		payload := map[string]any{"forced": forced != nil}
+		actor := ""
+		if forced != nil {
+			forced.UnmetConditions = unmet
+			err = u.prRepo.AddForcedMerge(ctx, tx, forced)
+			if err != nil {
+				return nil, err
+			}
+			payload["reason"] = forced.Reason
+			payload["unmet_conditions"] = unmet
+			actor = forced.ForcedBy
+		}
+
+		err = u.addEventTx(ctx, tx, pr.ID, domain.EventMerged, actor, payload)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Commit changes
@@ -119,16 +273,53 @@ func (u *PRUseCase) MergePR(ctx context.Context, prID string) (*domain.PullReque
 	return pr, nil
 }
 
-// ReassignReviewer replaces an existing reviewer with a new random reviewer from the same team.
-// The new reviewer must be active, not the PR author, and not already assigned to the PR.
+// unmetMergeConditions lists the conditions of the merge policy the PR doesn't meet, see domain.Team.UnmetMergeConditions.
+// The policy is the one of the author's team. PRs flagged when the team of the author was archived keep
+// the policy of the archived team. An author removed from their team has no policy to check the PR against,
+// so the PR can't be merged until the author joins a team, unless it is force merged.
+func (u *PRUseCase) unmetMergeConditions(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	author, err := u.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamID := author.TeamID
+	if teamID == 0 {
+		teamID = pr.ArchivedTeamID
+	}
+	if teamID == 0 {
+		return []string{fmt.Sprintf("author %s has no team to take the merge policy from", pr.AuthorID)}, nil
+	}
+
+	team, err := u.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return team.UnmetMergeConditions(pr), nil
+}
+
+// ReassignReviewer replaces an existing reviewer with a new reviewer from the same team
+// (or its fallback teams, if the team has no suitable candidates), selected with the team's assignment strategy.
+// Candidates with tags matching the PR labels are preferred.
+// The new reviewer must be active, not the PR author, not already assigned to the PR
+// and below his review capacity.
+// If the PR has more reviewers than the team policy allows (team.MaxReviewers was lowered
+// after PR creation), the old reviewer is removed without replacement.
+//
+// If newReviewerID is not empty, this user becomes the reviewer instead of a selected one,
+// provided he passes the same rules and belongs to the author's team or one of its fallback teams.
+// An explicitly chosen reviewer replaces the old one even if the PR is over the team policy.
 //
 // Returns:
 //   - *domain.PullRequest: PR with updated list of assigned reviewers
-//   - string: user_id of the newly assigned reviewer
-//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrNotAssigned if oldReviewerID
-//     is not assigned to the PR, domain.ErrPRMerged if PR is already merged,
-//     domain.ErrNoCandidate if no suitable replacement found in the team, or any database error
-func (u *PRUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+//   - string: user_id of the newly assigned reviewer, empty if the reviewer was not replaced
+//   - error: domain.ErrNotFound if PR or the chosen user doesn't exist, domain.ErrNotAssigned if oldReviewerID
+//     is not assigned to the PR, domain.ErrPRMerged if PR is already merged, domain.ErrPRNotOpen
+//     if PR is a draft or closed, domain.ErrNoCandidate if no suitable replacement found in the team (domain.ErrAllAtCapacity
+//     if all candidates are at capacity), domain.ErrInvalidCandidate if the chosen user
+//     can't review the PR, or any database error
+func (u *PRUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (*domain.PullRequest, string, error) {
 	// Start transaction first to lock the PR row for the duration of the check-then-modify sequence.
 	// Without this, two concurrent ReassignReviewer calls on the same PR could both pass the
 	// status/assignment checks and then both modify the reviewer list, corrupting state.
@@ -138,6 +329,25 @@ func (u *PRUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 	}
 	defer tx.Rollback() //nolint:errcheck
 
+	pr, newReviewerID, err := u.reassignReviewerTx(ctx, tx, prID, oldReviewerID, newReviewerID, domain.UnassignReassigned, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Commit changes
+	if err = tx.Commit(); err != nil {
+		return nil, "", err
+	}
+
+	return pr, newReviewerID, nil
+}
+
+// reassignReviewerTx implements ReassignReviewer within the given transaction.
+// requestedID is the explicitly chosen reviewer, empty to select one with the team's strategy.
+// reason is recorded in the ended assignment of the old reviewer and in the PR timeline.
+// pending holds changes made earlier in the same transaction, which are not visible
+// to the candidate queries yet; it is updated with the new reviewer. Can be nil.
+func (u *PRUseCase) reassignReviewerTx(ctx context.Context, tx *sql.Tx, prID, oldReviewerID, requestedID string, reason domain.UnassignReason, pending *pendingChanges) (*domain.PullRequest, string, error) {
 	// Get PR with a row-level lock (SELECT ... FOR UPDATE).
 	// This serializes concurrent reassign operations on the same PR.
 	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
@@ -152,46 +362,366 @@ func (u *PRUseCase) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 		return nil, "", domain.ErrNotAssigned
 	}
 
-	// - PR with status "MERGED" cannot be modified
-	if pr.Status == domain.StatusMerged {
-		return nil, "", domain.ErrPRMerged
+	// - only reviewers of OPEN PRs can be changed
+	if err = pr.CheckReviewable(); err != nil {
+		return nil, "", err
 	}
 
-	// Get candidates, excluding all current reviewers.
 	// Note: these reads happen outside the transaction (userRepo has no tx variants),
 	// but the PR row lock above ensures the PR state is consistent.
-	reviewers, err := u.getReviewersToAssign(ctx, pr.AuthorID, pr.ReviewersIDs...)
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
 	if err != nil { // err can be domain.ErrNotFound if author or his team do not exist
 		return nil, "", err
 	}
 
-	if len(reviewers) == 0 {
-		return nil, "", domain.ErrNoCandidate
-	}
+	var newReviewer domain.ReviewCandidate
+	if requestedID != "" {
+		// The chosen reviewer must pass the same rules as a selected one
+		newReviewer, err = u.checkRequestedReviewer(ctx, team, pr, requestedID, pending)
+		if err != nil {
+			return nil, "", err
+		}
+	} else if len(pr.ReviewersIDs)-1 < team.MaxReviewers {
+		// Replace the old reviewer only if it keeps the PR within the team policy
+		// Get candidates, excluding all current reviewers (including the old one).
+		var reviewers []domain.ReviewCandidate
+		reviewers, err = u.getReviewersToAssign(ctx, team, pr, 1, nil, pending)
+		if err != nil { // err can be domain.ErrAllAtCapacity
+			return nil, "", err
+		}
 
-	// select first reviewer, since slice is already shuffled
-	newReviewerID := reviewers[0]
+		if len(reviewers) == 0 {
+			return nil, "", domain.ErrNoCandidate
+		}
+
+		newReviewer = reviewers[0]
+	}
+	newReviewerID := newReviewer.UserID
 
 	// Remove old reviewer
-	err = u.prRepo.RemoveReviewer(ctx, tx, prID, oldReviewerID)
+	err = u.prRepo.RemoveReviewer(ctx, tx, prID, oldReviewerID, reason)
 	if err != nil {
 		return nil, "", err
 	}
 
 	// Assign new reviewer
-	err = u.prRepo.AddReviewer(ctx, tx, prID, newReviewerID)
+	if newReviewerID != "" {
+		err = u.prRepo.AddReviewer(ctx, tx, prID, newReviewerID, newReviewer.Source)
+		if err != nil {
+			return nil, "", err
+		}
+		pending.assigned(newReviewerID)
+	}
+
+	payload := map[string]any{
+		"old_reviewer_id": oldReviewerID,
+		"reason":          string(reason),
+	}
+	if newReviewerID != "" {
+		payload["new_reviewer_id"] = newReviewerID
+		payload["source"] = string(newReviewer.Source)
+	}
+	// A reviewer declining the review is the actor of their replacement
+	actor := ""
+	if reason == domain.UnassignDeclined {
+		actor = oldReviewerID
+	}
+	err = u.addEventTx(ctx, tx, prID, domain.EventReviewerReassigned, actor, payload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// update PR model
+	pr.ReviewersIDs = append(pr.ReviewersIDs[:oldIdx], pr.ReviewersIDs[oldIdx+1:]...)
+	delete(pr.ReviewerSources, oldReviewerID)
+	delete(pr.Decisions, oldReviewerID)
+	if newReviewerID != "" {
+		pr.ReviewersIDs = append(pr.ReviewersIDs, newReviewerID)
+		if pr.ReviewerSources == nil {
+			pr.ReviewerSources = make(map[string]domain.ReviewerSource)
+		}
+		pr.ReviewerSources[newReviewerID] = newReviewer.Source
+	}
+
+	return pr, newReviewerID, nil
+}
+
+// AddReviewer assigns the user as an additional reviewer of the PR by hand.
+// The user can be from any team, but must be active, not absent, not the author,
+// not already assigned and below his review capacity. The PR can't get more reviewers
+// than the author's team allows.
+//
+// Returns:
+//   - *domain.PullRequest: PR with updated list of assigned reviewers
+//   - error: domain.ErrNotFound if PR or user doesn't exist, domain.ErrPRMerged if PR is already merged,
+//     domain.ErrPRNotOpen if PR is a draft or closed, domain.ErrInvalidCandidate if the user can't review
+//     the PR, domain.ErrTooManyReviewers if the PR already has the maximum number of reviewers,
+//     or any database error
+func (u *PRUseCase) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the PR row, so concurrent changes of the reviewer list are serialized
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		return nil, err // err can be domain.ErrNotFound
+	}
+
+	if err = pr.CheckReviewable(); err != nil {
+		return nil, err
+	}
+
+	if _, err = u.checkReviewerAvailable(ctx, pr, userID, nil); err != nil {
+		return nil, err
+	}
+
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.ReviewersIDs) >= team.MaxReviewers {
+		return nil, fmt.Errorf("%w: team %q allows at most %d reviewers",
+			domain.ErrTooManyReviewers, team.Name, team.MaxReviewers)
+	}
+
+	err = u.assignReviewersTx(ctx, tx, pr, []domain.ReviewCandidate{{UserID: userID, Source: domain.SourceManual}})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// RemoveReviewer unassigns the reviewer from the PR without replacement.
+//
+// Returns:
+//   - *domain.PullRequest: PR with updated list of assigned reviewers
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrNotAssigned if the user
+//     is not assigned to the PR, domain.ErrPRMerged if PR is already merged, domain.ErrPRNotOpen
+//     if PR is a draft or closed, or any database error
+func (u *PRUseCase) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the PR row, so concurrent changes of the reviewer list are serialized
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		return nil, err // err can be domain.ErrNotFound
+	}
+
+	idx := slices.Index(pr.ReviewersIDs, userID)
+	if idx == -1 {
+		return nil, domain.ErrNotAssigned
+	}
+
+	if err = pr.CheckReviewable(); err != nil {
+		return nil, err
+	}
+
+	err = u.prRepo.RemoveReviewer(ctx, tx, prID, userID, domain.UnassignManual)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.addEventTx(ctx, tx, prID, domain.EventReviewerRemoved, "", map[string]any{
+		"reviewer_id": userID,
+		"reason":      string(domain.UnassignManual),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// update PR model
+	pr.ReviewersIDs = slices.Delete(pr.ReviewersIDs, idx, idx+1)
+	delete(pr.ReviewerSources, userID)
+	delete(pr.Decisions, userID)
+
+	return pr, nil
+}
+
+// SubmitReview records the decision of the reviewer on the PR. A reviewer can submit
+// several decisions (e.g. approve after requesting changes), only the latest one is kept.
+//
+// Returns:
+//   - *domain.PullRequest: PR with updated decisions of its reviewers
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrNotAssigned if the user
+//     is not assigned to the PR, domain.ErrPRMerged if PR is already merged, domain.ErrPRNotOpen
+//     if PR is a draft or closed, or any database error
+func (u *PRUseCase) SubmitReview(ctx context.Context, prID, userID string, decision domain.Decision, text string) (*domain.PullRequest, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the PR row, so the decision is not recorded on a PR being merged
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		return nil, err // err can be domain.ErrNotFound
+	}
+
+	if !slices.Contains(pr.ReviewersIDs, userID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	if err = pr.CheckReviewable(); err != nil {
+		return nil, err
+	}
+
+	reviewDecision := domain.ReviewDecision{Decision: decision, Text: text}
+	err = u.prRepo.SetDecision(ctx, tx, prID, userID, &reviewDecision)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// update PR model
+	if pr.Decisions == nil {
+		pr.Decisions = make(map[string]domain.ReviewDecision)
+	}
+	pr.Decisions[userID] = reviewDecision
+
+	return pr, nil
+}
+
+// DeclineReview lets the reviewer refuse the review of the PR with a reason (no context, overloaded,
+// conflict of interest, ...). The reviewer is replaced as by ReassignReviewer and the decline
+// is recorded in the same transaction, so the user is never assigned to the PR again.
+// If no replacement is found, the reviewer stays assigned and the decline is not recorded.
+//
+// Returns:
+//   - *domain.PullRequest: PR with updated list of assigned reviewers and declines
+//   - string: user_id of the newly assigned reviewer, empty if the PR is over the team policy
+//     and the reviewer was not replaced
+//   - error: domain.ErrNotFound if PR doesn't exist, domain.ErrNotAssigned if the user is not
+//     assigned to the PR, domain.ErrPRMerged if PR is already merged, domain.ErrPRNotOpen
+//     if PR is a draft or closed, domain.ErrNoCandidate if no suitable replacement found
+//     (domain.ErrAllAtCapacity if all candidates are at capacity), or any database error
+func (u *PRUseCase) DeclineReview(ctx context.Context, prID, userID, reason string) (*domain.PullRequest, string, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// The declining user is still assigned while the replacement is selected, so they are not selected again
+	pr, newReviewerID, err := u.reassignReviewerTx(ctx, tx, prID, userID, "", domain.UnassignDeclined, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decline := domain.ReviewDecline{UserID: userID, Reason: reason}
+	err = u.prRepo.AddDecline(ctx, tx, prID, &decline)
 	if err != nil {
 		return nil, "", err
 	}
 
-	// Commit changes
 	if err = tx.Commit(); err != nil {
 		return nil, "", err
 	}
 
 	// update PR model
-	pr.ReviewersIDs = append(pr.ReviewersIDs[:oldIdx], pr.ReviewersIDs[oldIdx+1:]...)
-	pr.ReviewersIDs = append(pr.ReviewersIDs, newReviewerID)
+	pr.Declines = append(pr.Declines, decline)
 
 	return pr, newReviewerID, nil
 }
+
+// GetAssignmentHistory returns all current and ended assignments of reviewers to the PR,
+// so it is visible who reviewed the PR originally and why reviewers were replaced.
+//
+// Returns:
+//   - []domain.ReviewAssignment: assignments ordered by their start (empty if there are none)
+//   - error: domain.ErrNotFound if PR doesn't exist, or any database error
+func (u *PRUseCase) GetAssignmentHistory(ctx context.Context, prID string) ([]domain.ReviewAssignment, error) {
+	// Check that PR exists
+	if _, err := u.prRepo.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	return u.prRepo.GetAssignments(ctx, prID)
+}
+
+// reassignOpenReviewsTx hands over all OPEN reviews of the user within the given transaction,
+// following the ReassignReviewer rules for each PR. PRs without a suitable replacement
+// keep the user as their reviewer. reason is recorded in the ended assignments of the user.
+//
+// Returns:
+//   - *domain.ReassignmentReport: reassigned reviews and PRs that kept the user
+//   - error: any database error
+func (u *PRUseCase) reassignOpenReviewsTx(ctx context.Context, tx *sql.Tx, userID string, reason domain.UnassignReason) (*domain.ReassignmentReport, error) {
+	return u.reassignLeavingReviewsTx(ctx, tx, []string{userID}, reason)
+}
+
+// reassignLeavingReviewsTx hands over all OPEN reviews of the users deactivated together
+// within the given transaction, as reassignOpenReviewsTx does, without picking any of them
+// as a replacement. Reviews of PRs authored by one of the users are kept, as their authors
+// leave as well.
+//
+// Returns:
+//   - *domain.ReassignmentReport: reassigned reviews and PRs that kept their reviewers
+//   - error: any database error
+func (u *PRUseCase) reassignLeavingReviewsTx(ctx context.Context, tx *sql.Tx, userIDs []string, reason domain.UnassignReason) (*domain.ReassignmentReport, error) {
+	report := &domain.ReassignmentReport{}
+	// reviews handed over and users deactivated in this transaction are not seen by the candidate queries
+	pending := newPendingChanges(userIDs...)
+
+	for _, userID := range userIDs {
+		prs, err := u.prRepo.GetPRsByReviewer(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if pr.Status != domain.StatusOpen {
+				continue
+			}
+			if pending.isLeaving(pr.AuthorID) {
+				report.NotReassigned = append(report.NotReassigned, domain.UnreassignedReview{
+					PRID:       pr.ID,
+					ReviewerID: userID,
+					Reason:     fmt.Sprintf("author %s leaves as well", pr.AuthorID),
+				})
+				continue
+			}
+
+			_, newReviewerID, err := u.reassignReviewerTx(ctx, tx, pr.ID, userID, "", reason, pending)
+			switch {
+			case err == nil:
+				report.Reassigned = append(report.Reassigned, domain.ReviewReassignment{
+					PRID:          pr.ID,
+					OldReviewerID: userID,
+					NewReviewerID: newReviewerID,
+				})
+			case errors.Is(err, domain.ErrNoCandidate):
+				report.NotReassigned = append(report.NotReassigned, domain.UnreassignedReview{
+					PRID:       pr.ID,
+					ReviewerID: userID,
+					Reason:     err.Error(),
+				})
+			case errors.Is(err, domain.ErrNotAssigned), errors.Is(err, domain.ErrPRMerged), errors.Is(err, domain.ErrPRNotOpen):
+				// PR changed concurrently, nothing to hand over
+			default:
+				return nil, err
+			}
+		}
+	}
+
+	return report, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -13,11 +14,39 @@ import (
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 )
 
+// reviewCandidates builds candidates without any open reviews from the given user IDs
+func reviewCandidates(ids ...string) []domain.ReviewCandidate {
+	candidates := make([]domain.ReviewCandidate, len(ids))
+	for i, id := range ids {
+		candidates[i] = domain.ReviewCandidate{UserID: id}
+	}
+	return candidates
+}
+
+// newPRRepoMock creates a PR repository mock accepting any PR events (see recordedEvents)
+func newPRRepoMock() *PullRequestRepoMock {
+	m := new(PullRequestRepoMock)
+	m.On("AddEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+// recordedEvents returns the PR events passed to the mock, in the order they were recorded
+func recordedEvents(m *PullRequestRepoMock) []domain.PREvent {
+	var events []domain.PREvent
+	for _, call := range m.Calls {
+		if call.Method == "AddEvent" {
+			events = append(events, *call.Arguments.Get(2).(*domain.PREvent))
+		}
+	}
+	return events
+}
+
 // ---- CreatePRAndSetReviewers tests ----
 
 func TestPRUseCase_CreatePRAndSetReviewers_Success_TwoReviewers(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -37,19 +66,20 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_TwoReviewers(t *testing.T) {
 
 	// Mock expectations
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
 
 	mockPRRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(p *domain.PullRequest) bool {
 		return p.ID == "pr-1001" && p.Status == domain.StatusOpen
 	})).Return(nil)
 
 	// Expect 2 reviewers to be added
-	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1001", mock.AnythingOfType("string")).Return(nil).Twice()
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1001", mock.AnythingOfType("string"), domain.SourceTeam).Return(nil).Twice()
 
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -58,6 +88,21 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_TwoReviewers(t *testing.T) {
 	assert.Equal(t, domain.StatusOpen, result.Status)
 	assert.Len(t, result.ReviewersIDs, 2) // must be 2 reviewers
 
+	// the author creates the PR, then the reviewers are assigned
+	events := recordedEvents(mockPRRepo)
+	require.Len(t, events, 3)
+	assert.Equal(t, domain.PREvent{
+		PRID:    "pr-1001",
+		Type:    domain.EventCreated,
+		Actor:   "u1",
+		Payload: map[string]any{"name": "Add feature", "author_id": "u1", "status": "OPEN"},
+	}, events[0])
+	for i, reviewerID := range result.ReviewersIDs {
+		assert.Equal(t, domain.EventReviewerAssigned, events[i+1].Type)
+		assert.Empty(t, events[i+1].Actor)
+		assert.Equal(t, map[string]any{"reviewer_id": reviewerID, "source": "TEAM"}, events[i+1].Payload)
+	}
+
 	require.NoError(t, dbMock.ExpectationsWereMet())
 	mockUserRepo.AssertExpectations(t)
 	mockPRRepo.AssertExpectations(t)
@@ -65,7 +110,8 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_TwoReviewers(t *testing.T) {
 
 func TestPRUseCase_CreatePRAndSetReviewers_Success_OneReviewer(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -84,15 +130,17 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_OneReviewer(t *testing.T) {
 	dbMock.ExpectBegin()
 
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
 
 	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
-	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1002", "u2").Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1002", "u2", domain.SourceTeam).Return(nil)
 
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -106,7 +154,8 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_OneReviewer(t *testing.T) {
 
 func TestPRUseCase_CreatePRAndSetReviewers_Success_NoReviewers(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -125,14 +174,16 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_NoReviewers(t *testing.T) {
 	dbMock.ExpectBegin()
 
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
 
 	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
 
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -146,7 +197,8 @@ func TestPRUseCase_CreatePRAndSetReviewers_Success_NoReviewers(t *testing.T) {
 
 func TestPRUseCase_CreatePRAndSetReviewers_AuthorNotFound(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, _, err := sqlmock.New()
 	require.NoError(t, err)
@@ -162,7 +214,7 @@ func TestPRUseCase_CreatePRAndSetReviewers_AuthorNotFound(t *testing.T) {
 	mockUserRepo.On("GetByID", ctx, "nonexistent").Return(nil, domain.ErrNotFound)
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -173,9 +225,30 @@ func TestPRUseCase_CreatePRAndSetReviewers_AuthorNotFound(t *testing.T) {
 	mockPRRepo.AssertNotCalled(t, "Create")
 }
 
+func TestPRUseCase_CreatePRAndSetReviewers_AuthorWithoutTeam(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	ctx := context.Background()
+	pr := domain.PullRequest{ID: "pr-1004", Name: "Feature", AuthorID: "u1"}
+
+	// The author was removed from their team
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestPRUseCase_CreatePRAndSetReviewers_PRAlreadyExists(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -194,13 +267,15 @@ func TestPRUseCase_CreatePRAndSetReviewers_PRAlreadyExists(t *testing.T) {
 	dbMock.ExpectBegin()
 
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
 	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(domain.ErrPRExists)
 
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -214,7 +289,8 @@ func TestPRUseCase_CreatePRAndSetReviewers_PRAlreadyExists(t *testing.T) {
 
 func TestPRUseCase_CreatePRAndSetReviewers_AddReviewerError(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -233,14 +309,16 @@ func TestPRUseCase_CreatePRAndSetReviewers_AddReviewerError(t *testing.T) {
 	dbMock.ExpectBegin()
 
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
 	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
-	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1005", "u2").Return(errors.New("db error"))
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1005", "u2", domain.SourceTeam).Return(errors.New("db error"))
 
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
 
 	// Assert
@@ -256,7 +334,8 @@ func TestPRUseCase_CreatePRAndSetReviewers_AddReviewerError(t *testing.T) {
 
 func TestPRUseCase_MergePR_Success(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -276,6 +355,8 @@ func TestPRUseCase_MergePR_Success(t *testing.T) {
 	dbMock.ExpectBegin()
 
 	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: 2}, nil)
 	mockPRRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(p *domain.PullRequest) bool {
 		return p.ID == prID && p.Status == domain.StatusMerged
 	})).Return(nil)
@@ -283,7 +364,7 @@ func TestPRUseCase_MergePR_Success(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.MergePR(ctx, prID)
 
 	// Assert
@@ -296,7 +377,8 @@ func TestPRUseCase_MergePR_Success(t *testing.T) {
 
 func TestPRUseCase_MergePR_Idempotent(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, mockDb, err := sqlmock.New()
 	require.NoError(t, err)
@@ -317,7 +399,7 @@ func TestPRUseCase_MergePR_Idempotent(t *testing.T) {
 	mockDb.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.MergePR(ctx, prID)
 
 	// Assert
@@ -331,7 +413,8 @@ func TestPRUseCase_MergePR_Idempotent(t *testing.T) {
 
 func TestPRUseCase_MergePR_NotFound(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, mockDb, err := sqlmock.New()
 	require.NoError(t, err)
@@ -346,7 +429,7 @@ func TestPRUseCase_MergePR_NotFound(t *testing.T) {
 	mockDb.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.MergePR(ctx, prID)
 
 	// Assert
@@ -360,7 +443,8 @@ func TestPRUseCase_MergePR_NotFound(t *testing.T) {
 
 func TestPRUseCase_MergePR_UpdateError(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -370,19 +454,22 @@ func TestPRUseCase_MergePR_UpdateError(t *testing.T) {
 	prID := "pr-1001"
 
 	pr := &domain.PullRequest{
-		ID:     prID,
-		Status: domain.StatusOpen,
+		ID:       prID,
+		AuthorID: "u1",
+		Status:   domain.StatusOpen,
 	}
 
 	dbMock.ExpectBegin()
 
 	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: 2}, nil)
 	mockPRRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(errors.New("db error"))
 
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
 	result, err := uc.MergePR(ctx, prID)
 
 	// Assert
@@ -395,9 +482,225 @@ func TestPRUseCase_MergePR_UpdateError(t *testing.T) {
 
 // ---- ReassignReviewer tests ----
 
+func TestPRUseCase_MergePR_Blocked(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1001"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+		Decisions: map[string]domain.ReviewDecision{
+			"u2": {Decision: domain.DecisionApproved},
+			"u3": {Decision: domain.DecisionChangesRequested},
+		},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: 2, MinApprovals: 2}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.MergePR(ctx, prID)
+
+	require.ErrorIs(t, err, domain.ErrMergeBlocked)
+	assert.Contains(t, err.Error(), "2 approvals required, 1 given")
+	assert.Contains(t, err.Error(), "changes requested by u3")
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_MergePR_ArchivedTeamPolicy(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	archivedAt := time.Now()
+
+	// the author was detached when their team was archived
+	pr := &domain.PullRequest{
+		ID:             "pr-1001",
+		AuthorID:       "u1",
+		Status:         domain.StatusOpen,
+		ReviewersIDs:   []string{"u2"},
+		Decisions:      map[string]domain.ReviewDecision{"u2": {Decision: domain.DecisionChangesRequested}},
+		ArchivedTeamID: 1,
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1001").Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MinApprovals: 1, ArchivedAt: &archivedAt}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.MergePR(ctx, "pr-1001")
+
+	// the policy of the archived team still applies
+	require.ErrorIs(t, err, domain.ErrMergeBlocked)
+	assert.Contains(t, err.Error(), "1 approvals required, 0 given")
+	assert.Contains(t, err.Error(), "changes requested by u2")
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_MergePR_AuthorWithoutTeam(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := &domain.PullRequest{ID: "pr-1001", AuthorID: "u1", Status: domain.StatusOpen}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1001").Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.MergePR(ctx, "pr-1001")
+
+	// the author was removed from their team, there is no policy to check
+	require.ErrorIs(t, err, domain.ErrMergeBlocked)
+	assert.Contains(t, err.Error(), "author u1 has no team")
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_MergePR_ChangesRequestedAllowed(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1001"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+		Decisions: map[string]domain.ReviewDecision{
+			"u2": {Decision: domain.DecisionApproved},
+			"u3": {Decision: domain.DecisionChangesRequested},
+		},
+	}
+	team := &domain.Team{ID: 1, MaxReviewers: 2, MinApprovals: 1, AllowChangesRequested: true}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything, pr).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.MergePR(ctx, prID)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, result.Status)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_ForceMergePR(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1001"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2"},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: 2, MinApprovals: 1}, nil)
+	mockPRRepo.On("Update", ctx, mock.Anything, pr).Return(nil)
+	mockPRRepo.On("AddForcedMerge", ctx, mock.Anything, &domain.ForcedMerge{
+		PRID:            prID,
+		ForcedBy:        "lead",
+		Reason:          "hotfix",
+		UnmetConditions: []string{"1 approvals required, 0 given"},
+	}).Return(nil)
+	dbMock.ExpectCommit()
+
+	// Already merged PR is not recorded again
+	merged := &domain.PullRequest{ID: "pr-1002", Status: domain.StatusMerged}
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1002").Return(merged, nil)
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	result, err := uc.ForceMergePR(ctx, prID, "lead", "hotfix")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusMerged, result.Status)
+	assert.Equal(t, []domain.PREvent{{
+		PRID:  prID,
+		Type:  domain.EventMerged,
+		Actor: "lead",
+		Payload: map[string]any{
+			"forced":           true,
+			"reason":           "hotfix",
+			"unmet_conditions": []string{"1 approvals required, 0 given"},
+		},
+	}}, recordedEvents(mockPRRepo))
+
+	result, err = uc.ForceMergePR(ctx, "pr-1002", "lead", "hotfix")
+	require.NoError(t, err)
+	assert.Equal(t, merged, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNumberOfCalls(t, "AddForcedMerge", 1)
+}
+
 func TestPRUseCase_ReassignReviewer_Success(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -422,16 +725,17 @@ func TestPRUseCase_ReassignReviewer_Success(t *testing.T) {
 
 	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return([]string{"u2", "u3", "u4"}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3", "u4"), nil)
 
-	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, oldReviewerID).Return(nil)
-	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, candidate).Return(nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, oldReviewerID, domain.UnassignReassigned).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, candidate, domain.SourceTeam).Return(nil)
 
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	require.NoError(t, err)
@@ -447,7 +751,8 @@ func TestPRUseCase_ReassignReviewer_Success(t *testing.T) {
 
 func TestPRUseCase_ReassignReviewer_NotAssigned(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -468,8 +773,8 @@ func TestPRUseCase_ReassignReviewer_NotAssigned(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrNotAssigned)
@@ -482,7 +787,8 @@ func TestPRUseCase_ReassignReviewer_NotAssigned(t *testing.T) {
 
 func TestPRUseCase_ReassignReviewer_PRMerged(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -503,8 +809,8 @@ func TestPRUseCase_ReassignReviewer_PRMerged(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrPRMerged)
@@ -517,7 +823,8 @@ func TestPRUseCase_ReassignReviewer_PRMerged(t *testing.T) {
 
 func TestPRUseCase_ReassignReviewer_NoCandidate(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -541,12 +848,14 @@ func TestPRUseCase_ReassignReviewer_NoCandidate(t *testing.T) {
 	dbMock.ExpectBegin()
 	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrNoCandidate)
@@ -558,9 +867,40 @@ func TestPRUseCase_ReassignReviewer_NoCandidate(t *testing.T) {
 	mockPRRepo.AssertExpectations(t)
 }
 
+func TestPRUseCase_ReassignReviewer_AuthorWithoutTeam(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := &domain.PullRequest{ID: "pr-1001", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}}
+
+	// PRs of authors removed from their team have no team to take a replacement from
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1001").Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(0), "u1").Return(nil, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(0)).Return(nil, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, "pr-1001", "u2", "")
+
+	assert.ErrorIs(t, err, domain.ErrNoCandidate)
+	assert.Empty(t, newReviewerID)
+	assert.Nil(t, resultPR)
+	mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
 func TestPRUseCase_ReassignReviewer_PRNotFound(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -575,8 +915,8 @@ func TestPRUseCase_ReassignReviewer_PRNotFound(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrNotFound)
@@ -589,7 +929,8 @@ func TestPRUseCase_ReassignReviewer_PRNotFound(t *testing.T) {
 
 func TestPRUseCase_ReassignReviewer_RemoveReviewerError(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -613,14 +954,15 @@ func TestPRUseCase_ReassignReviewer_RemoveReviewerError(t *testing.T) {
 
 	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
 	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
-	mockUserRepo.On("GetActiveTeamMembersIDs", ctx, int64(1), "u1").Return(candidates, nil)
-	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, oldReviewerID).Return(errors.New("db error"))
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates(candidates...), nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, oldReviewerID, domain.UnassignReassigned).Return(errors.New("db error"))
 
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewPRUseCase(mockUserRepo, mockPRRepo, db)
-	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID)
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, oldReviewerID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -631,3 +973,1790 @@ func TestPRUseCase_ReassignReviewer_RemoveReviewerError(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 	mockPRRepo.AssertExpectations(t)
 }
+
+func TestPRUseCase_CreatePRAndSetReviewers_UsesTeamStrategy(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1006",
+		Name:     "Balanced",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 4},
+		{UserID: "u3", OpenReviews: 2},
+		{UserID: "u4", OpenReviews: 0},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(candidates, nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1006", "u4", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1006", "u3", domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: two least loaded candidates are selected
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4", "u3"}, result.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_SkipsUsersAtCapacity(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1007",
+		Name:     "Crunch",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 3, MaxOpenReviews: 3}, // at capacity
+		{UserID: "u3", OpenReviews: 5, MaxOpenReviews: 0}, // no limit
+		{UserID: "u4", OpenReviews: 1, MaxOpenReviews: 2},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(candidates, nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1007", "u3", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1007", "u4", domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: saturated u2 is never picked
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, result.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_AllAtCapacity(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1008",
+		Name:     "Crunch",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 1, MaxOpenReviews: 1},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: PR is still created, just without reviewers
+	require.NoError(t, err)
+	assert.Empty(t, result.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_ReassignReviewer_AllAtCapacity(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1009"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2"},
+	}
+
+	author := &domain.User{ID: "u1", TeamID: 1}
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 1},
+		{UserID: "u3", OpenReviews: 2, MaxOpenReviews: 2},
+		{UserID: "u4", OpenReviews: 4, MaxOpenReviews: 3},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(candidates, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
+	dbMock.ExpectRollback()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", "")
+
+	// Assert: distinct reason, but still a NO_CANDIDATE case
+	assert.ErrorIs(t, err, domain.ErrAllAtCapacity)
+	assert.ErrorIs(t, err, domain.ErrNoCandidate)
+	assert.Empty(t, newReviewerID)
+	assert.Nil(t, resultPR)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_UsesTeamMaxReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1010",
+		Name:     "Platform change",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 3}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3", "u4", "u5"), nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1010", mock.Anything, domain.SourceTeam).Return(nil).Times(3)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result.ReviewersIDs, 3)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_NotEnoughReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1011",
+		Name:     "Platform change",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+	team := &domain.Team{ID: 1, Name: "platform", AssignmentStrategy: domain.StrategyRandom, MinReviewers: 3, MaxReviewers: 3}
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3"), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(nil, nil)
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: nothing is written
+	assert.ErrorIs(t, err, domain.ErrNotEnoughReviewers)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_ReassignReviewer_OverTeamMaxReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1012"
+
+	// PR was created when the team required two reviewers, now it requires one
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+	}
+
+	author := &domain.User{ID: "u1", TeamID: 1}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 1}, nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, "u2", domain.UnassignReassigned).Return(nil)
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", "")
+
+	// Assert: reviewer removed without replacement
+	require.NoError(t, err)
+	assert.Empty(t, newReviewerID)
+	assert.Equal(t, []string{"u3"}, resultPR.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", mock.Anything, mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_TopsUpFromFallbackTeams(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1013",
+		Name:     "Small team",
+		AuthorID: "u1",
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+	fallbacks := []*domain.Team{
+		{ID: 2, Name: "saturated", AssignmentStrategy: domain.StrategyRandom},
+		{ID: 3, Name: "helpers", AssignmentStrategy: domain.StrategyLeastLoaded},
+		{ID: 4, Name: "unused", AssignmentStrategy: domain.StrategyRandom},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 3}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2"), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return(fallbacks, nil)
+	// first fallback team can't help - its only member is at capacity
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(2), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "f1", OpenReviews: 1, MaxOpenReviews: 1},
+	}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(3), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "h1", OpenReviews: 3},
+		{UserID: "h2", OpenReviews: 0},
+		{UserID: "h3", OpenReviews: 1},
+	}, nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1013", "u2", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1013", "h2", domain.SourceFallback).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1013", "h3", domain.SourceFallback).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: own team first, then the least loaded members of the "helpers" team
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "h2", "h3"}, result.ReviewersIDs)
+	assert.Equal(t, []string{"h2", "h3"}, result.ReviewersIDsBySource(domain.SourceFallback))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", ctx, int64(4), "u1")
+}
+
+func TestPRUseCase_ReassignReviewer_FromFallbackTeam(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1014"
+
+	pr := &domain.PullRequest{
+		ID:              prID,
+		AuthorID:        "u1",
+		Status:          domain.StatusOpen,
+		ReviewersIDs:    []string{"u2", "f1"},
+		ReviewerSources: map[string]domain.ReviewerSource{"u2": domain.SourceTeam, "f1": domain.SourceFallback},
+	}
+
+	author := &domain.User{ID: "u1", TeamID: 1}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2"), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{{ID: 2, AssignmentStrategy: domain.StrategyRandom}}, nil)
+	// f1 is already assigned, so f2 is the only candidate
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(2), "u1").Return(reviewCandidates("f1", "f2"), nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, "u2", domain.UnassignReassigned).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, "f2", domain.SourceFallback).Return(nil)
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "f2", newReviewerID)
+	assert.Equal(t, []string{"f1", "f2"}, resultPR.ReviewersIDs)
+	assert.Equal(t, []string{"f1", "f2"}, resultPR.ReviewersIDsBySource(domain.SourceFallback))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_PrefersCodeOwners(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:           "pr-1015",
+		Name:         "Update API docs",
+		AuthorID:     "u1",
+		Repository:   "avito/service",
+		ChangedFiles: []string{"docs/api.md", "internal/usecase/pr_usecase.go"},
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}, nil)
+	mockCodeOwnersRepo.On("GetByRepository", ctx, "avito/service").Return(&domain.CodeOwners{
+		Repository: "avito/service",
+		Content:    "* @u2\n/docs/ @avito/docs @ghost\n",
+	}, nil)
+	// the owner team has a single member able to review
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 5, Name: "docs"}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(5), "u1").Return(reviewCandidates("d1"), nil)
+	// unknown users are just not returned
+	mockUserRepo.On("GetReviewCandidatesByIDs", ctx, []string{"ghost", "u2"}, "u1").Return(reviewCandidates("u2"), nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1015", "d1", domain.SourceCodeOwners).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1015", "u2", domain.SourceCodeOwners).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, mockCodeOwnersRepo, domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: both reviewers are owners, so the team itself is not queried
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d1", "u2"}, result.ReviewersIDs)
+	assert.ElementsMatch(t, []string{"d1", "u2"}, result.ReviewersIDsBySource(domain.SourceCodeOwners))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", ctx, int64(1), "u1")
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_NoMatchingCodeOwners(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:           "pr-1016",
+		Name:         "Bump version",
+		AuthorID:     "u1",
+		Repository:   "avito/service",
+		ChangedFiles: []string{"VERSION"},
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}, nil)
+	mockCodeOwnersRepo.On("GetByRepository", ctx, "avito/service").Return(&domain.CodeOwners{Content: "*.go @u9"}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3"), nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1016", mock.Anything, domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, mockCodeOwnersRepo, domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, result.ReviewersIDs)
+	assert.Empty(t, result.ReviewersIDsBySource(domain.SourceCodeOwners))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidatesByIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_PrefersMatchingTags(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		ID:       "pr-1017",
+		Name:     "Add index migration",
+		AuthorID: "u1",
+		Labels:   []string{"SQL", "migrations"},
+	}
+
+	author := &domain.User{ID: "u1", Name: "Alice", TeamID: 1}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(author, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: 2}, nil)
+	// u4 knows SQL but is at capacity, u2 is the least loaded of the rest
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "u2", OpenReviews: 0, Tags: []string{"frontend"}},
+		{UserID: "u3", OpenReviews: 5, Tags: []string{"go", "sql"}},
+		{UserID: "u4", OpenReviews: 1, MaxOpenReviews: 1, Tags: []string{"sql"}},
+		{UserID: "u5", OpenReviews: 3},
+	}, nil)
+
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return assert.ObjectsAreEqual([]string{"sql", "migrations"}, pr.Labels)
+	})).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1017", "u3", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1017", "u2", domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Assert: the SQL reviewer goes first despite his load
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u2"}, result.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_ReassignReviewer_PrefersMatchingTags(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1018"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2"},
+		Labels:       []string{"frontend"},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "u2", Tags: []string{"frontend"}},
+		{UserID: "u3"},
+		{UserID: "u4", Tags: []string{"frontend"}},
+		{UserID: "u5", Tags: []string{"go"}},
+	}, nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, "u2", domain.UnassignReassigned).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, "u4", domain.SourceTeam).Return(nil)
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	_, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", "")
+
+	// Assert: the only other frontend developer replaces the old reviewer
+	require.NoError(t, err)
+	assert.Equal(t, "u4", newReviewerID)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestPRUseCase_ReassignOpenReviews(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: 2}
+
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{
+		{ID: "pr-1", Status: domain.StatusOpen},
+		{ID: "pr-2", Status: domain.StatusOpen},
+		{ID: "pr-3", Status: domain.StatusOpen},
+		{ID: "pr-4", Status: domain.StatusMerged},
+	}, nil)
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}},
+		{ID: "pr-2", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}},
+		{ID: "pr-3", AuthorID: "u4", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}},
+	} {
+		mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, pr.ID).Return(pr, nil)
+	}
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, "u4").Return(&domain.User{ID: "u4", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{}, nil)
+
+	// u3 has a free slot, u4 already reviews one PR; the candidate queries don't see
+	// reviews assigned earlier in the same transaction
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "u2"},
+		{UserID: "u3", MaxOpenReviews: 1},
+		{UserID: "u4", OpenReviews: 1},
+	}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u4").Return([]domain.ReviewCandidate{
+		{UserID: "u2"},
+		{UserID: "u3", MaxOpenReviews: 1},
+	}, nil)
+
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, mock.Anything, "u2", domain.UnassignDeactivated).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1", "u3", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-2", "u4", domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectBegin()
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	report, err := uc.reassignOpenReviewsTx(ctx, tx, "u2", domain.UnassignDeactivated)
+
+	// Assert: u3 reaches his capacity with pr-1, so pr-2 goes to u4 and pr-3 keeps u2
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewReassignment{
+		{PRID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3"},
+		{PRID: "pr-2", OldReviewerID: "u2", NewReviewerID: "u4"},
+	}, report.Reassigned)
+	require.Len(t, report.NotReassigned, 1)
+	assert.Equal(t, "pr-3", report.NotReassigned[0].PRID)
+	mockPRRepo.AssertNotCalled(t, "GetByIDForUpdate", ctx, mock.Anything, "pr-4")
+	mockPRRepo.AssertNotCalled(t, "RemoveReviewer", ctx, mock.Anything, "pr-3", "u2", mock.Anything)
+}
+
+func TestPRUseCase_ReassignLeavingReviews(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: 2}
+	fallback := &domain.Team{ID: 2, AssignmentStrategy: domain.StrategyLeastLoaded, MaxReviewers: 2}
+
+	// u2 and u3 leave together, u3 authored pr-2
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{
+		{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen},
+		{ID: "pr-2", AuthorID: "u3", Status: domain.StatusOpen},
+	}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u3").Return([]*domain.PullRequest{
+		{ID: "pr-3", AuthorID: "u1", Status: domain.StatusOpen},
+	}, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").
+		Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}}, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-3").
+		Return(&domain.PullRequest{ID: "pr-3", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u3"}}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{fallback}, nil)
+
+	// the candidate queries still see the leaving users as active members of the fallback team
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "u4", MaxOpenReviews: 1},
+	}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(2), "u1").Return([]domain.ReviewCandidate{
+		{UserID: "u2"},
+		{UserID: "u3"},
+	}, nil)
+
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, "pr-1", "u2", domain.UnassignLeftTeam).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1", "u4", domain.SourceTeam).Return(nil)
+
+	dbMock.ExpectBegin()
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	report, err := uc.reassignLeavingReviewsTx(ctx, tx, []string{"u2", "u3"}, domain.UnassignLeftTeam)
+
+	// Assert: u4 reaches his capacity with pr-1, and the leaving users don't take pr-3;
+	// pr-2 is kept as its author leaves as well
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewReassignment{
+		{PRID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"},
+	}, report.Reassigned)
+	require.Len(t, report.NotReassigned, 2)
+	assert.Equal(t, domain.UnreassignedReview{PRID: "pr-2", ReviewerID: "u2", Reason: "author u3 leaves as well"}, report.NotReassigned[0])
+	assert.Equal(t, "pr-3", report.NotReassigned[1].PRID)
+	assert.Equal(t, "u3", report.NotReassigned[1].ReviewerID)
+	mockPRRepo.AssertNotCalled(t, "GetByIDForUpdate", ctx, mock.Anything, "pr-2")
+	mockPRRepo.AssertNotCalled(t, "RemoveReviewer", ctx, mock.Anything, "pr-3", "u3", mock.Anything)
+}
+
+func TestPRUseCase_PreviewReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	mockCodeOwnersRepo := new(CodeOwnersRepoMock)
+
+	ctx := context.Background()
+	pr := domain.PullRequest{
+		AuthorID:     "u1",
+		Repository:   "avito/service",
+		ChangedFiles: []string{"main.go"},
+		ReviewersIDs: []string{"u5"},
+	}
+
+	team := &domain.Team{ID: 1, Name: "backend", AssignmentStrategy: domain.StrategyRoundRobin, MaxReviewers: 2}
+	u2 := domain.ReviewCandidate{UserID: "u2", OpenReviews: 2, MaxOpenReviews: 2}
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockCodeOwnersRepo.On("GetByRepository", ctx, "avito/service").Return(&domain.CodeOwners{
+		Repository: "avito/service",
+		Content:    "* @u2\n",
+	}, nil)
+
+	// selection: the only code owner is at capacity, so the team's rotation picks the reviewer
+	mockUserRepo.On("GetReviewCandidatesByIDs", ctx, []string{"u2"}, "u1").Return([]domain.ReviewCandidate{u2}, nil)
+	mockUserRepo.On("GetReviewCandidates", mock.Anything, int64(1), "u1").Return(reviewCandidates("u3", "u4", "u5"), nil)
+
+	// explanation of the pool
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"u2"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: u2, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetTeamReviewPool", ctx, int64(1)).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u1"}, IsActive: true},
+		{ReviewCandidate: u2, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u3"}, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u4", OpenReviews: 1}, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u5"}, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u6"}, IsActive: false},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u7"}, IsActive: true, Absent: true},
+	}, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{{ID: 2, Name: "helpers"}}, nil)
+	mockUserRepo.On("GetTeamReviewPool", ctx, int64(2)).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "f1"}, IsActive: true},
+	}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, mockCodeOwnersRepo, domain.DefaultWorkingHours, nil)
+
+	// Preview twice: round-robin rotation must not move
+	for range 2 {
+		preview, err := uc.PreviewReviewers(ctx, pr)
+		require.NoError(t, err)
+
+		assert.Equal(t, team, preview.Team)
+		assert.Equal(t, 1, preview.Amount) // one place is taken by u5
+		assert.Equal(t, []domain.ReviewCandidate{
+			{UserID: "u3", Source: domain.SourceTeam},
+		}, preview.Reviewers)
+		assert.Equal(t, []domain.ReviewCandidate{
+			{UserID: "u3", Source: domain.SourceTeam},
+			{UserID: "u4", OpenReviews: 1, Source: domain.SourceTeam},
+			{UserID: "f1", Source: domain.SourceFallback},
+		}, preview.Candidates)
+		assert.Equal(t, []domain.ExcludedCandidate{
+			{UserID: "u2", Source: domain.SourceCodeOwners, Reason: domain.ExcludedAtCapacity},
+			{UserID: "u1", Source: domain.SourceTeam, Reason: domain.ExcludedAuthor},
+			{UserID: "u5", Source: domain.SourceTeam, Reason: domain.ExcludedAlreadyAssigned},
+			{UserID: "u6", Source: domain.SourceTeam, Reason: domain.ExcludedInactive},
+			{UserID: "u7", Source: domain.SourceTeam, Reason: domain.ExcludedAbsent},
+		}, preview.Excluded)
+	}
+
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_PreviewReviewers_AuthorNotFound(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewPRUseCase(mockUserRepo, new(PullRequestRepoMock), new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+	preview, err := uc.PreviewReviewers(ctx, domain.PullRequest{AuthorID: "ghost"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, preview)
+}
+
+func TestPRUseCase_PreviewReviewers_AuthorWithoutTeam(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, new(PullRequestRepoMock), new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+	preview, err := uc.PreviewReviewers(ctx, domain.PullRequest{AuthorID: "u1"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, preview)
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "GetTeamReviewPool", mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_PreviewReviewers_Shortfall(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	ctx := context.Background()
+
+	// the team requires three reviewers, one is assigned and only one more is available
+	team := &domain.Team{ID: 1, Name: "backend", AssignmentStrategy: domain.StrategyRoundRobin, MinReviewers: 3, MaxReviewers: 3}
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(team, nil)
+	mockUserRepo.On("GetReviewCandidates", mock.Anything, int64(1), "u1").Return(reviewCandidates("u2", "u3"), nil)
+	mockTeamRepo.On("GetFallbackTeams", mock.Anything, int64(1)).Return(nil, nil)
+	mockUserRepo.On("GetTeamReviewPool", ctx, int64(1)).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u2"}, IsActive: true},
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u3"}, IsActive: true},
+	}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+	preview, err := uc.PreviewReviewers(ctx, domain.PullRequest{AuthorID: "u1", ReviewersIDs: []string{"u2"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewCandidate{{UserID: "u3", Source: domain.SourceTeam}}, preview.Reviewers)
+	assert.Equal(t, 1, preview.Shortfall)
+}
+
+func TestPRUseCase_ReassignReviewer_ChosenReviewer(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1020"
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	// the team policy is lowered, but an explicit choice still replaces the reviewer
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 1}, nil)
+	// the chosen user comes from a fallback team
+	mockUserRepo.On("GetByID", ctx, "f1").Return(&domain.User{ID: "f1", TeamID: 2}, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{{ID: 2, Name: "helpers"}}, nil)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"f1"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "f1", OpenReviews: 1, MaxOpenReviews: 2}, IsActive: true},
+	}, nil)
+
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, "u2", domain.UnassignReassigned).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, "f1", domain.SourceFallback).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", "f1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "f1", newReviewerID)
+	assert.Equal(t, []string{"u3", "f1"}, resultPR.ReviewersIDs)
+	assert.Equal(t, []string{"f1"}, resultPR.ReviewersIDsBySource(domain.SourceFallback))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_ReassignReviewer_InvalidChosenReviewer(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1021"
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+	}
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, Name: "backend", MaxReviewers: 2}, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{}, nil)
+
+	mockUserRepo.On("GetByID", ctx, "x1").Return(&domain.User{ID: "x1", TeamID: 7}, nil)
+	mockUserRepo.On("GetByID", ctx, "u3").Return(&domain.User{ID: "u3", TeamID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, "u4").Return(&domain.User{ID: "u4", TeamID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"u3"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u3"}, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"u4"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u4", OpenReviews: 3, MaxOpenReviews: 3}, IsActive: true},
+	}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	cases := []struct {
+		newReviewerID string
+		expected      error
+		message       string
+	}{
+		{newReviewerID: "x1", expected: domain.ErrInvalidCandidate, message: "not a member"},
+		{newReviewerID: "u3", expected: domain.ErrInvalidCandidate, message: "ALREADY_ASSIGNED"},
+		{newReviewerID: "u4", expected: domain.ErrInvalidCandidate, message: "AT_CAPACITY"},
+		{newReviewerID: "ghost", expected: domain.ErrNotFound},
+	}
+	for _, tc := range cases {
+		dbMock.ExpectBegin()
+		dbMock.ExpectRollback()
+
+		resultPR, newReviewerID, err := uc.ReassignReviewer(ctx, prID, "u2", tc.newReviewerID)
+
+		assert.ErrorIs(t, err, tc.expected, tc.newReviewerID)
+		assert.ErrorContains(t, err, tc.message, tc.newReviewerID)
+		assert.Nil(t, resultPR)
+		assert.Empty(t, newReviewerID)
+	}
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_AddReviewer_Success(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1030"
+	pr := &domain.PullRequest{
+		ID:              prID,
+		AuthorID:        "u1",
+		Status:          domain.StatusOpen,
+		ReviewersIDs:    []string{"u2"},
+		ReviewerSources: map[string]domain.ReviewerSource{"u2": domain.SourceTeam},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	// the user is from another team
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"x1"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "x1"}, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: 2}, nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, "x1", domain.SourceManual).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.AddReviewer(ctx, prID, "x1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "x1"}, result.ReviewersIDs)
+	assert.Equal(t, []string{"x1"}, result.ReviewersIDsBySource(domain.SourceManual))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_AddReviewer_RulesViolated(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	full := &domain.PullRequest{ID: "pr-full", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2", "u3"},
+		Declines: []domain.ReviewDecline{{UserID: "u8", Reason: "no context"}}}
+	merged := &domain.PullRequest{ID: "pr-merged", AuthorID: "u1", Status: domain.StatusMerged}
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-full").Return(full, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-merged").Return(merged, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-ghost").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"x1"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "x1"}, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"u9"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u9"}, IsActive: false},
+	}, nil)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"u8"}).Return([]domain.ReviewPoolMember{
+		{ReviewCandidate: domain.ReviewCandidate{UserID: "u8"}, IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetReviewPoolByIDs", ctx, []string{"ghost"}).Return([]domain.ReviewPoolMember{}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, Name: "backend", MaxReviewers: 2}, nil)
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	cases := []struct {
+		prID     string
+		userID   string
+		expected error
+	}{
+		{prID: "pr-full", userID: "x1", expected: domain.ErrTooManyReviewers},
+		{prID: "pr-full", userID: "u9", expected: domain.ErrInvalidCandidate},
+		{prID: "pr-full", userID: "u8", expected: domain.ErrInvalidCandidate}, // declined the PR
+		{prID: "pr-full", userID: "ghost", expected: domain.ErrNotFound},
+		{prID: "pr-merged", userID: "x1", expected: domain.ErrPRMerged},
+		{prID: "pr-ghost", userID: "x1", expected: domain.ErrNotFound},
+	}
+	for _, tc := range cases {
+		dbMock.ExpectBegin()
+		dbMock.ExpectRollback()
+
+		result, err := uc.AddReviewer(ctx, tc.prID, tc.userID)
+
+		assert.ErrorIs(t, err, tc.expected, tc.prID+"/"+tc.userID)
+		assert.Nil(t, result)
+	}
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_RemoveReviewer(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := &domain.PullRequest{
+		ID:              "pr-1031",
+		AuthorID:        "u1",
+		Status:          domain.StatusOpen,
+		ReviewersIDs:    []string{"u2", "u3"},
+		ReviewerSources: map[string]domain.ReviewerSource{"u2": domain.SourceTeam, "u3": domain.SourceTeam},
+	}
+	merged := &domain.PullRequest{ID: "pr-merged", AuthorID: "u1", Status: domain.StatusMerged, ReviewersIDs: []string{"u2"}}
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1031").Return(pr, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-merged").Return(merged, nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, "pr-1031", "u2", domain.UnassignManual).Return(nil)
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit()
+	result, err := uc.RemoveReviewer(ctx, "pr-1031", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, result.ReviewersIDs)
+	assert.NotContains(t, result.ReviewerSources, "u2")
+
+	// Not a reviewer
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	result, err = uc.RemoveReviewer(ctx, "pr-1031", "u9")
+	assert.ErrorIs(t, err, domain.ErrNotAssigned)
+	assert.Nil(t, result)
+
+	// Merged PR
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	result, err = uc.RemoveReviewer(ctx, "pr-merged", "u2")
+	assert.ErrorIs(t, err, domain.ErrPRMerged)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNumberOfCalls(t, "RemoveReviewer", 1)
+}
+
+func TestPRUseCase_SubmitReview(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	decidedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		ID:           "pr-1041",
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+		Decisions: map[string]domain.ReviewDecision{
+			"u2": {Decision: domain.DecisionChangesRequested, DecidedAt: decidedAt.Add(-time.Hour)},
+		},
+	}
+	merged := &domain.PullRequest{ID: "pr-merged", AuthorID: "u1", Status: domain.StatusMerged, ReviewersIDs: []string{"u2"}}
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1041").Return(pr, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-merged").Return(merged, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "ghost").Return(nil, domain.ErrNotFound)
+	mockPRRepo.On("SetDecision", ctx, mock.Anything, "pr-1041", "u2", mock.MatchedBy(func(d *domain.ReviewDecision) bool {
+		return d.Decision == domain.DecisionApproved && d.Text == "LGTM"
+	})).Run(func(args mock.Arguments) {
+		args.Get(4).(*domain.ReviewDecision).DecidedAt = decidedAt
+	}).Return(nil)
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	// The latest decision replaces the previous one
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit()
+	result, err := uc.SubmitReview(ctx, "pr-1041", "u2", domain.DecisionApproved, "LGTM")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewDecision{Decision: domain.DecisionApproved, Text: "LGTM", DecidedAt: decidedAt}, result.Decisions["u2"])
+	assert.False(t, result.HasDecision("u3"))
+
+	// Not a reviewer
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	result, err = uc.SubmitReview(ctx, "pr-1041", "u9", domain.DecisionApproved, "")
+	assert.ErrorIs(t, err, domain.ErrNotAssigned)
+	assert.Nil(t, result)
+
+	// Merged PR
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	result, err = uc.SubmitReview(ctx, "pr-merged", "u2", domain.DecisionCommented, "")
+	assert.ErrorIs(t, err, domain.ErrPRMerged)
+	assert.Nil(t, result)
+
+	// Unknown PR
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	result, err = uc.SubmitReview(ctx, "ghost", "u2", domain.DecisionApproved, "")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNumberOfCalls(t, "SetDecision", 1)
+}
+
+func TestPRUseCase_TopUpReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	understaffed := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2"}}
+	merged := &domain.PullRequest{ID: "pr-2", AuthorID: "u1", Status: domain.StatusMerged}
+	full := &domain.PullRequest{ID: "pr-3", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2", "u4"}}
+
+	mockPRRepo.On("GetUnderstaffedPRIDs", ctx).Return([]string{"pr-1", "pr-2", "pr-3"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3"), nil)
+
+	// pr-1 gets the missing reviewer
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(understaffed, nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1", "u3", domain.SourceTeam).Return(nil)
+	dbMock.ExpectCommit()
+
+	// pr-2 was merged and pr-3 got a reviewer since they were selected
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-2").Return(merged, nil)
+	dbMock.ExpectRollback()
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-3").Return(full, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.TopUpReviewers(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewerTopUp{{PRID: "pr-1", ReviewerID: "u3", Source: domain.SourceTeam}}, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNumberOfCalls(t, "AddReviewer", 1)
+}
+
+func TestPRUseCase_TopUpTeamReviewers_Error(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mockPRRepo.On("GetUnderstaffedPRIDsByTeam", ctx, int64(1)).Return([]string{"pr-1"}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(nil, errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.TopUpTeamReviewers(ctx, 1)
+
+	assert.Error(t, err)
+	assert.Empty(t, result)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+// ---- PR status transitions tests ----
+
+func TestPRUseCase_CreatePRAndSetReviewers_Draft(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := domain.PullRequest{ID: "pr-1", Name: "WIP", AuthorID: "u1", Status: domain.StatusDraft}
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MinReviewers: 1, MaxReviewers: 2}, nil)
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(p *domain.PullRequest) bool {
+		return p.ID == "pr-1" && p.Status == domain.StatusDraft
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// Drafts get no reviewers, even if the team requires some
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDraft, result.Status)
+	assert.Empty(t, result.ReviewersIDs)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetReviewCandidates", mock.Anything, mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_MarkReadyForReview(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	draft := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusDraft}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(draft, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRoundRobin, MaxReviewers: 2}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3", "u4"), nil)
+	mockPRRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(p *domain.PullRequest) bool {
+		return p.ID == "pr-1" && p.Status == domain.StatusOpen
+	})).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1", mock.AnythingOfType("string"), domain.SourceTeam).Return(nil).Twice()
+	dbMock.ExpectCommit()
+
+	// Open PR is not a draft
+	open := &domain.PullRequest{ID: "pr-2", AuthorID: "u1", Status: domain.StatusOpen}
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-2").Return(open, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	result, err := uc.MarkReadyForReview(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOpen, result.Status)
+	assert.Len(t, result.ReviewersIDs, 2)
+
+	result, err = uc.MarkReadyForReview(ctx, "pr-2")
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_ClosePR(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	pr := &domain.PullRequest{
+		ID:              "pr-1",
+		Status:          domain.StatusOpen,
+		ReviewersIDs:    []string{"u2", "u3"},
+		ReviewerSources: map[string]domain.ReviewerSource{"u2": domain.SourceTeam, "u3": domain.SourceTeam},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(pr, nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, "pr-1", "u2", domain.UnassignClosed).Return(nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, "pr-1", "u3", domain.UnassignClosed).Return(nil)
+	mockPRRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(p *domain.PullRequest) bool {
+		return p.ID == "pr-1" && p.Status == domain.StatusClosed
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	// Closing again changes nothing
+	closed := &domain.PullRequest{ID: "pr-2", Status: domain.StatusClosed}
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-2").Return(closed, nil)
+	dbMock.ExpectRollback()
+
+	// Merged PR can't be closed
+	merged := &domain.PullRequest{ID: "pr-3", Status: domain.StatusMerged}
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-3").Return(merged, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	result, err := uc.ClosePR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusClosed, result.Status)
+	assert.Empty(t, result.ReviewersIDs)
+	assert.Equal(t, []domain.PREvent{
+		{PRID: "pr-1", Type: domain.EventReviewerRemoved, Payload: map[string]any{"reviewer_id": "u2", "reason": "CLOSED"}},
+		{PRID: "pr-1", Type: domain.EventReviewerRemoved, Payload: map[string]any{"reviewer_id": "u3", "reason": "CLOSED"}},
+		{PRID: "pr-1", Type: domain.EventStatusChanged, Payload: map[string]any{"from": "OPEN", "to": "CLOSED"}},
+	}, recordedEvents(mockPRRepo))
+
+	result, err = uc.ClosePR(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, closed, result)
+
+	result, err = uc.ClosePR(ctx, "pr-3")
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestPRUseCase_ReopenPR_NotEnoughReviewers(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	closed := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusClosed}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(closed, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, Name: "backend", MinReviewers: 1, MaxReviewers: 1}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return([]domain.ReviewCandidate{}, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.ReopenPR(ctx, "pr-1")
+
+	// The PR stays closed
+	assert.ErrorIs(t, err, domain.ErrNotEnoughReviewers)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_NotOpenPRs(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	draft := &domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusDraft}
+	closed := &domain.PullRequest{ID: "pr-2", AuthorID: "u1", Status: domain.StatusClosed}
+
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").Return(draft, nil)
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-2").Return(closed, nil)
+	for range 3 {
+		dbMock.ExpectBegin()
+		dbMock.ExpectRollback()
+	}
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+
+	// Drafts can't be merged
+	result, err := uc.MergePR(ctx, "pr-1")
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Nil(t, result)
+
+	// Reviewers can't be added to drafts and closed PRs
+	result, err = uc.AddReviewer(ctx, "pr-1", "u2")
+	assert.ErrorIs(t, err, domain.ErrPRNotOpen)
+	assert.Nil(t, result)
+
+	result, err = uc.AddReviewer(ctx, "pr-2", "u2")
+	assert.ErrorIs(t, err, domain.ErrPRNotOpen)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ---- Review decline tests ----
+
+func TestPRUseCase_DeclineReview(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1001"
+	declinedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// u4 has declined the PR before and must not come back
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2", "u3"},
+		Declines:     []domain.ReviewDecline{{UserID: "u4", Reason: "overloaded"}},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3", "u4", "u5"), nil)
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, prID, "u2", domain.UnassignDeclined).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, prID, "u5", domain.SourceTeam).Return(nil)
+	mockPRRepo.On("AddDecline", ctx, mock.Anything, prID, mock.MatchedBy(func(d *domain.ReviewDecline) bool {
+		return d.UserID == "u2" && d.Reason == "no context"
+	})).Run(func(args mock.Arguments) {
+		args.Get(3).(*domain.ReviewDecline).DeclinedAt = declinedAt
+	}).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.DeclineReview(ctx, prID, "u2", "no context")
+
+	require.NoError(t, err)
+	assert.Equal(t, "u5", newReviewerID)
+	assert.ElementsMatch(t, []string{"u3", "u5"}, resultPR.ReviewersIDs)
+	assert.Equal(t, []string{"u4", "u2"}, resultPR.DeclinedIDs())
+	assert.Equal(t, declinedAt, resultPR.Declines[1].DeclinedAt)
+	assert.Equal(t, []domain.PREvent{{
+		PRID:    prID,
+		Type:    domain.EventReviewerReassigned,
+		Actor:   "u2", // the declining reviewer
+		Payload: map[string]any{"old_reviewer_id": "u2", "new_reviewer_id": "u5", "source": "TEAM", "reason": "DECLINED"},
+	}}, recordedEvents(mockPRRepo))
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_DeclineReview_NoCandidate(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	prID := "pr-1001"
+
+	pr := &domain.PullRequest{
+		ID:           prID,
+		AuthorID:     "u1",
+		Status:       domain.StatusOpen,
+		ReviewersIDs: []string{"u2"},
+		Declines:     []domain.ReviewDecline{{UserID: "u3", Reason: "conflict of interest"}},
+	}
+
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, prID).Return(pr, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3"), nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	resultPR, newReviewerID, err := uc.DeclineReview(ctx, prID, "u2", "overloaded")
+
+	// the reviewer stays assigned and the decline is not recorded
+	assert.ErrorIs(t, err, domain.ErrNoCandidate)
+	assert.Nil(t, resultPR)
+	assert.Empty(t, newReviewerID)
+	mockPRRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddDecline", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestPRUseCase_GetAssignmentHistory(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+	ctx := context.Background()
+	unassignedAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	assignments := []domain.ReviewAssignment{
+		{UserID: "u2", Source: domain.SourceTeam, UnassignedAt: &unassignedAt, UnassignReason: domain.UnassignReassigned},
+		{UserID: "u3", Source: domain.SourceTeam},
+	}
+	mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", ReviewersIDs: []string{"u3"}}, nil)
+	mockPRRepo.On("GetAssignments", ctx, "pr-1").Return(assignments, nil)
+	mockPRRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+
+	result, err := uc.GetAssignmentHistory(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, assignments, result)
+
+	result, err = uc.GetAssignmentHistory(ctx, "ghost")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockPRRepo.AssertNotCalled(t, "GetAssignments", ctx, "ghost")
+}
+
+func TestPRUseCase_CreatePRAndSetReviewers_EventError(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := new(PullRequestRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the actor of the request is recorded instead of the author
+	ctx := domain.WithActor(context.Background(), "ci-bot")
+	pr := domain.PullRequest{ID: "pr-1001", Name: "Add feature", AuthorID: "u1", Status: domain.StatusDraft}
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockPRRepo.On("AddEvent", ctx, mock.Anything, mock.MatchedBy(func(e *domain.PREvent) bool {
+		return e.Type == domain.EventCreated && e.Actor == "ci-bot"
+	})).Return(errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	result, err := uc.CreatePRAndSetReviewers(ctx, pr)
+
+	// the PR is not created without its event
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRUseCase_GetEvents(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+	ctx := context.Background()
+
+	events := []domain.PREvent{
+		{ID: 1, PRID: "pr-1", Type: domain.EventCreated, Actor: "u1"},
+		{ID: 2, PRID: "pr-1", Type: domain.EventReviewerAssigned, Payload: map[string]any{"reviewer_id": "u2"}},
+	}
+	mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil)
+	mockPRRepo.On("GetEvents", ctx, "pr-1").Return(events, nil)
+	mockPRRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+
+	result, err := uc.GetEvents(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, events, result)
+
+	result, err = uc.GetEvents(ctx, "ghost")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockPRRepo.AssertNotCalled(t, "GetEvents", ctx, "ghost")
+}
+
+func TestPRUseCase_GetOverdueReviews(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+	ctx := context.Background()
+	longAgo := time.Now().Add(-30 * 24 * time.Hour)
+
+	reviews := []domain.PendingReview{
+		{PRID: "pr-1", ReviewerID: "u2", TeamName: "backend", AssignedAt: longAgo, ReviewSLAHours: 24},
+		{PRID: "pr-2", ReviewerID: "u3", TeamName: "frontend", AssignedAt: longAgo, ReviewSLAHours: 24},
+		{PRID: "pr-3", ReviewerID: "u4", TeamName: "backend", AssignedAt: time.Now(), ReviewSLAHours: 24},
+	}
+	mockPRRepo.On("GetPendingReviews", ctx).Return(reviews, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, nil)
+
+	// the fresh review is within the SLA
+	result, err := uc.GetOverdueReviews(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, reviews[0], result[0].PendingReview)
+	assert.Equal(t, reviews[1], result[1].PendingReview)
+	// 30 days hold at least 20 working days of 9 hours
+	assert.GreaterOrEqual(t, result[0].Waiting, 20*9*time.Hour)
+	assert.Less(t, result[0].Waiting, 30*24*time.Hour)
+
+	result, err = uc.GetOverdueReviews(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, reviews[0], result[0].PendingReview)
+
+	result, err = uc.GetOverdueReviews(ctx, "ghost")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+}
+
+func TestPRUseCase_CheckReviewSLA(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	longAgo := time.Now().Add(-30 * 24 * time.Hour)
+	markedAt := longAgo.Add(24 * time.Hour)
+
+	mockPRRepo.On("GetPendingReviews", ctx).Return([]domain.PendingReview{
+		// overdue for long: marked and reassigned
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: longAgo, ReviewSLAHours: 24, SLAReassignHours: 48},
+		// already marked, the team doesn't reassign
+		{PRID: "pr-2", ReviewerID: "u2", AssignedAt: longAgo, OverdueAt: &markedAt, ReviewSLAHours: 24},
+		// within the SLA
+		{PRID: "pr-3", ReviewerID: "u2", AssignedAt: time.Now(), ReviewSLAHours: 24, SLAReassignHours: 48},
+		// already marked, no replacement
+		{PRID: "pr-4", ReviewerID: "u5", AssignedAt: longAgo, OverdueAt: &markedAt, ReviewSLAHours: 24, SLAReassignHours: 48},
+	}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockTeamRepo.On("GetByID", ctx, int64(1)).Return(&domain.Team{ID: 1, AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil)
+	mockTeamRepo.On("GetFallbackTeams", ctx, int64(1)).Return([]*domain.Team{}, nil)
+
+	// pr-1
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkOverdue", ctx, mock.Anything, "pr-1", "u2", mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-1").
+		Return(&domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u2", "u3"}}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u2", "u3", "u4"), nil).Once()
+	mockPRRepo.On("RemoveReviewer", ctx, mock.Anything, "pr-1", "u2", domain.UnassignOverdue).Return(nil)
+	mockPRRepo.On("AddReviewer", ctx, mock.Anything, "pr-1", "u4", domain.SourceTeam).Return(nil)
+	dbMock.ExpectCommit()
+
+	// pr-4
+	dbMock.ExpectBegin()
+	mockPRRepo.On("GetByIDForUpdate", ctx, mock.Anything, "pr-4").
+		Return(&domain.PullRequest{ID: "pr-4", AuthorID: "u1", Status: domain.StatusOpen, ReviewersIDs: []string{"u5"}}, nil)
+	mockUserRepo.On("GetReviewCandidates", ctx, int64(1), "u1").Return(reviewCandidates("u5"), nil).Once()
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	report, err := uc.CheckReviewSLA(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, report.Overdue, 1)
+	assert.Equal(t, "pr-1", report.Overdue[0].PRID)
+	assert.NotNil(t, report.Overdue[0].OverdueAt)
+	assert.Equal(t, []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"}}, report.Reassigned)
+	require.Len(t, report.NotReassigned, 1)
+	assert.Equal(t, "pr-4", report.NotReassigned[0].PRID)
+
+	events := recordedEvents(mockPRRepo)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.PREvent{
+		PRID:    "pr-1",
+		Type:    domain.EventReviewOverdue,
+		Payload: map[string]any{"reviewer_id": "u2", "sla_hours": 24},
+	}, events[0])
+	assert.Equal(t, domain.EventReviewerReassigned, events[1].Type)
+	assert.Equal(t, "OVERDUE", events[1].Payload["reason"])
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNotCalled(t, "MarkOverdue", mock.Anything, mock.Anything, "pr-3", mock.Anything, mock.Anything)
+}
+
+func TestPRUseCase_CheckReviewSLA_MarkedMeanwhile(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	longAgo := time.Now().Add(-30 * 24 * time.Hour)
+
+	mockPRRepo.On("GetPendingReviews", ctx).Return([]domain.PendingReview{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: longAgo, ReviewSLAHours: 24, SLAReassignHours: 48},
+	}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkOverdue", ctx, mock.Anything, "pr-1", "u2", mock.Anything).Return(domain.ErrNotFound)
+	dbMock.ExpectRollback()
+
+	uc := NewPRUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(CodeOwnersRepoMock), domain.DefaultWorkingHours, db)
+	report, err := uc.CheckReviewSLA(ctx)
+
+	// the assignment ended concurrently, so it is neither reported nor reassigned
+	require.NoError(t, err)
+	assert.Empty(t, report.Overdue)
+	assert.Empty(t, report.Reassigned)
+	assert.Empty(t, recordedEvents(mockPRRepo))
+	mockPRRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything, mock.Anything)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
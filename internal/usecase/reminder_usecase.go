@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/repository"
+)
+
+// Notifier delivers reminders to reviewers, e.g. to a chat or by email
+type Notifier interface {
+	Notify(ctx context.Context, reminder domain.ReviewReminder) error
+}
+
+type ReminderUseCase struct {
+	prRepo   repository.PullRequestRepository
+	notifier Notifier
+	policy   domain.ReminderPolicy
+	db       *sql.DB
+}
+
+func NewReminderUseCase(
+	prRepo repository.PullRequestRepository,
+	notifier Notifier,
+	policy domain.ReminderPolicy,
+	db *sql.DB) *ReminderUseCase {
+	return &ReminderUseCase{
+		prRepo:   prRepo,
+		notifier: notifier,
+		policy:   policy,
+		db:       db,
+	}
+}
+
+// SendReminders reminds reviewers about their pending assignments that are due for a reminder
+// according to the policy. Every reviewer gets a single reminder listing all such PRs.
+// A reminder is recorded before it is delivered, so it is sent once per assignment and backoff step;
+// the record of an undelivered reminder is reverted, and it is retried on the next run.
+//
+// Returns:
+//   - *domain.ReminderReport: sent reminders and reminders the notifier failed to deliver
+//   - error: any database error; reminders sent before it are included in the report
+func (u *ReminderUseCase) SendReminders(ctx context.Context) (*domain.ReminderReport, error) {
+	now := time.Now()
+	assignments, err := u.prRepo.GetRemindableAssignments(ctx, now.Add(-u.policy.After))
+	if err != nil {
+		return nil, err
+	}
+
+	// Assignments come ordered by reviewer
+	var reviewers []string
+	due := make(map[string][]domain.PendingAssignment)
+	for _, a := range assignments {
+		if u.policy.NextReminderAt(a).After(now) {
+			continue
+		}
+		if _, ok := due[a.ReviewerID]; !ok {
+			reviewers = append(reviewers, a.ReviewerID)
+		}
+		due[a.ReviewerID] = append(due[a.ReviewerID], a)
+	}
+
+	report := &domain.ReminderReport{}
+	for _, reviewerID := range reviewers {
+		reminder, err := u.remind(ctx, reviewerID, due[reviewerID], now)
+		var notifyErr *notifyError
+		switch {
+		case err == nil:
+			if reminder != nil {
+				report.Sent = append(report.Sent, *reminder)
+			}
+		case errors.As(err, &notifyErr):
+			report.Failed = append(report.Failed, domain.UnsentReminder{ReviewerID: reviewerID, Reason: notifyErr.Error()})
+		default:
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// notifyError marks errors of the notifier, so they don't abort the run
+type notifyError struct {
+	err error
+}
+
+func (e *notifyError) Error() string { return e.err.Error() }
+
+func (e *notifyError) Unwrap() error { return e.err }
+
+// remind records a reminder about the assignments of the reviewer and delivers it once the record is committed,
+// so the notifier doesn't hold row locks. If the delivery fails, the record is reverted to be retried on the next run.
+// Returns nil reminder if all the assignments ended or were reminded about concurrently.
+func (u *ReminderUseCase) remind(ctx context.Context, reviewerID string, assignments []domain.PendingAssignment, now time.Time) (*domain.ReviewReminder, error) {
+	prs, err := u.prRepo.GetPRsByReviewer(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	prByID := make(map[string]*domain.PullRequest, len(prs))
+	for _, pr := range prs {
+		prByID[pr.ID] = pr
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	reminder := &domain.ReviewReminder{ReviewerID: reviewerID}
+	var marked []domain.PendingAssignment
+	for _, a := range assignments {
+		pr, ok := prByID[a.PRID]
+		if !ok {
+			continue // unassigned meanwhile
+		}
+
+		err = u.prRepo.MarkReminded(ctx, tx, a.PRID, reviewerID, a.RemindersSent, now)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue // unassigned or reminded meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		marked = append(marked, a)
+		reminder.Items = append(reminder.Items, domain.ReminderItem{
+			PR:         pr,
+			AssignedAt: a.AssignedAt,
+			Reminder:   a.RemindersSent + 1,
+		})
+	}
+
+	if len(reminder.Items) == 0 {
+		return nil, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err = u.notifier.Notify(ctx, *reminder); err != nil {
+		// The delivery may have failed as ctx was cancelled, e.g. on shutdown: the record is reverted anyway
+		if unmarkErr := u.unmark(context.WithoutCancel(ctx), marked); unmarkErr != nil {
+			return nil, unmarkErr
+		}
+		return nil, &notifyError{err: err}
+	}
+
+	return reminder, nil
+}
+
+// unmark reverts the records of an undelivered reminder about the assignments
+func (u *ReminderUseCase) unmark(ctx context.Context, assignments []domain.PendingAssignment) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, a := range assignments {
+		err = u.prRepo.UnmarkReminded(ctx, tx, a.PRID, a.ReviewerID, a.RemindersSent, a.LastRemindedAt)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type NotifierMock struct {
+	mock.Mock
+}
+
+func (m *NotifierMock) Notify(ctx context.Context, reminder domain.ReviewReminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+var testReminderPolicy = domain.ReminderPolicy{After: 24 * time.Hour, MaxInterval: 72 * time.Hour}
+
+func TestReminderUseCase_SendReminders(t *testing.T) {
+	mockPRRepo := new(PullRequestRepoMock)
+	mockNotifier := new(NotifierMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	ago := func(hours int) time.Time { return now.Add(-time.Duration(hours) * time.Hour) }
+	remindedAt := func(hours int) *time.Time { at := ago(hours); return &at }
+
+	mockPRRepo.On("GetRemindableAssignments", ctx, mock.Anything).Return([]domain.PendingAssignment{
+		// first reminder
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: ago(30)},
+		// reminded recently, the next one is due in a day
+		{PRID: "pr-2", ReviewerID: "u2", AssignedAt: ago(30), RemindersSent: 1, LastRemindedAt: remindedAt(2)},
+		// the third reminder is due two days after the second one
+		{PRID: "pr-3", ReviewerID: "u3", AssignedAt: ago(120), RemindersSent: 2, LastRemindedAt: remindedAt(50)},
+		// the interval doesn't grow beyond MaxInterval
+		{PRID: "pr-4", ReviewerID: "u3", AssignedAt: ago(400), RemindersSent: 5, LastRemindedAt: remindedAt(73)},
+		// the notifier fails
+		{PRID: "pr-5", ReviewerID: "u4", AssignedAt: ago(30)},
+		// reminded by another instance meanwhile
+		{PRID: "pr-6", ReviewerID: "u5", AssignedAt: ago(30)},
+	}, nil)
+
+	pr1 := &domain.PullRequest{ID: "pr-1", Name: "Fix"}
+	pr3 := &domain.PullRequest{ID: "pr-3", Name: "Feature"}
+	pr4 := &domain.PullRequest{ID: "pr-4", Name: "Refactoring"}
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{pr1, {ID: "pr-2"}}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u3").Return([]*domain.PullRequest{pr3, pr4}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u4").Return([]*domain.PullRequest{{ID: "pr-5"}}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u5").Return([]*domain.PullRequest{{ID: "pr-6"}}, nil)
+
+	// u2
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-1", "u2", 0, mock.Anything).Return(nil)
+	u2Reminder := domain.ReviewReminder{ReviewerID: "u2", Items: []domain.ReminderItem{
+		{PR: pr1, AssignedAt: ago(30), Reminder: 1},
+	}}
+	dbMock.ExpectCommit()
+	mockNotifier.On("Notify", ctx, u2Reminder).Return(nil)
+
+	// u3
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-3", "u3", 2, mock.Anything).Return(nil)
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-4", "u3", 5, mock.Anything).Return(nil)
+	u3Reminder := domain.ReviewReminder{ReviewerID: "u3", Items: []domain.ReminderItem{
+		{PR: pr3, AssignedAt: ago(120), Reminder: 3},
+		{PR: pr4, AssignedAt: ago(400), Reminder: 6},
+	}}
+	dbMock.ExpectCommit()
+	mockNotifier.On("Notify", ctx, u3Reminder).Return(nil)
+
+	// u4: the record of the undelivered reminder is reverted
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-5", "u4", 0, mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+	mockNotifier.On("Notify", ctx, mock.MatchedBy(func(r domain.ReviewReminder) bool { return r.ReviewerID == "u4" })).
+		Return(errors.New("chat is unavailable"))
+	dbMock.ExpectBegin()
+	mockPRRepo.On("UnmarkReminded", mock.Anything, mock.Anything, "pr-5", "u4", 0, (*time.Time)(nil)).Return(nil)
+	dbMock.ExpectCommit()
+
+	// u5
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-6", "u5", 0, mock.Anything).Return(domain.ErrNotFound)
+	dbMock.ExpectRollback()
+
+	uc := NewReminderUseCase(mockPRRepo, mockNotifier, testReminderPolicy, db)
+	report, err := uc.SendReminders(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReviewReminder{u2Reminder, u3Reminder}, report.Sent)
+	assert.Equal(t, []domain.UnsentReminder{{ReviewerID: "u4", Reason: "chat is unavailable"}}, report.Failed)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+	mockNotifier.AssertExpectations(t)
+	mockPRRepo.AssertNotCalled(t, "MarkReminded", mock.Anything, mock.Anything, "pr-2", mock.Anything, mock.Anything, mock.Anything)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.MatchedBy(func(r domain.ReviewReminder) bool { return r.ReviewerID == "u5" }))
+}
+
+func TestReminderUseCase_SendReminders_NotifiesAfterCommit(t *testing.T) {
+	mockPRRepo := new(PullRequestRepoMock)
+	mockNotifier := new(NotifierMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	mockPRRepo.On("GetRemindableAssignments", ctx, mock.Anything).Return([]domain.PendingAssignment{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now().Add(-48 * time.Hour)},
+	}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{{ID: "pr-1"}}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-1", "u2", 0, mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+	mockNotifier.On("Notify", ctx, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		// the transaction holding the assignment rows is over
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+	})
+
+	uc := NewReminderUseCase(mockPRRepo, mockNotifier, testReminderPolicy, db)
+	report, err := uc.SendReminders(ctx)
+
+	require.NoError(t, err)
+	assert.Len(t, report.Sent, 1)
+	mockNotifier.AssertExpectations(t)
+}
+
+func TestReminderUseCase_SendReminders_CancelledWhileNotifying(t *testing.T) {
+	mockPRRepo := new(PullRequestRepoMock)
+	mockNotifier := new(NotifierMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	remindedAt := time.Now().Add(-48 * time.Hour)
+	mockPRRepo.On("GetRemindableAssignments", ctx, mock.Anything).Return([]domain.PendingAssignment{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now().Add(-96 * time.Hour), RemindersSent: 1, LastRemindedAt: &remindedAt},
+	}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{{ID: "pr-1"}}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-1", "u2", 1, mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+	// the service is shut down while the reminder is being delivered
+	mockNotifier.On("Notify", ctx, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(context.Canceled)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("UnmarkReminded", mock.MatchedBy(func(c context.Context) bool { return c.Err() == nil }),
+		mock.Anything, "pr-1", "u2", 1, &remindedAt).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewReminderUseCase(mockPRRepo, mockNotifier, testReminderPolicy, db)
+	report, err := uc.SendReminders(ctx)
+
+	// the undelivered reminder is not recorded as sent
+	require.NoError(t, err)
+	assert.Empty(t, report.Sent)
+	assert.Equal(t, []domain.UnsentReminder{{ReviewerID: "u2", Reason: context.Canceled.Error()}}, report.Failed)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestReminderUseCase_SendReminders_NothingDue(t *testing.T) {
+	mockPRRepo := new(PullRequestRepoMock)
+	mockNotifier := new(NotifierMock)
+	ctx := context.Background()
+	remindedAt := time.Now().Add(-time.Hour)
+
+	mockPRRepo.On("GetRemindableAssignments", ctx, mock.Anything).Return([]domain.PendingAssignment{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now().Add(-48 * time.Hour), RemindersSent: 1, LastRemindedAt: &remindedAt},
+	}, nil)
+
+	uc := NewReminderUseCase(mockPRRepo, mockNotifier, testReminderPolicy, nil)
+	report, err := uc.SendReminders(ctx)
+
+	require.NoError(t, err)
+	assert.Empty(t, report.Sent)
+	assert.Empty(t, report.Failed)
+	mockPRRepo.AssertNotCalled(t, "GetPRsByReviewer", mock.Anything, mock.Anything)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestReminderUseCase_SendReminders_Error(t *testing.T) {
+	mockPRRepo := new(PullRequestRepoMock)
+	mockNotifier := new(NotifierMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	mockPRRepo.On("GetRemindableAssignments", ctx, mock.Anything).Return([]domain.PendingAssignment{
+		{PRID: "pr-1", ReviewerID: "u2", AssignedAt: time.Now().Add(-48 * time.Hour)},
+	}, nil)
+	mockPRRepo.On("GetPRsByReviewer", ctx, "u2").Return([]*domain.PullRequest{{ID: "pr-1"}}, nil)
+	dbMock.ExpectBegin()
+	mockPRRepo.On("MarkReminded", ctx, mock.Anything, "pr-1", "u2", 0, mock.Anything).Return(errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	uc := NewReminderUseCase(mockPRRepo, mockNotifier, testReminderPolicy, db)
+	report, err := uc.SendReminders(ctx)
+
+	// the reviewer is not reminded without the record of it
+	assert.Error(t, err)
+	assert.Empty(t, report.Sent)
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
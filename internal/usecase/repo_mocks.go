@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -17,6 +18,19 @@ func (m *TeamRepoMock) Create(ctx context.Context, tx *sql.Tx, team *domain.Team
 	return args.Error(0)
 }
 
+func (m *TeamRepoMock) Update(ctx context.Context, tx *sql.Tx, team *domain.Team) error {
+	args := m.Called(ctx, tx, team)
+	return args.Error(0)
+}
+
+func (m *TeamRepoMock) GetByID(ctx context.Context, teamID int64) (*domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
 func (m *TeamRepoMock) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
 	args := m.Called(ctx, teamName)
 	if args.Get(0) == nil {
@@ -33,6 +47,37 @@ func (m *TeamRepoMock) GetTeamNameByID(ctx context.Context, teamID int64) (strin
 	return args.String(0), args.Error(1)
 }
 
+func (m *TeamRepoMock) GetFallbackTeams(ctx context.Context, teamID int64) ([]*domain.Team, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *TeamRepoMock) SetFallbackTeams(ctx context.Context, tx *sql.Tx, teamID int64, fallbackTeamIDs []int64) error {
+	args := m.Called(ctx, tx, teamID, fallbackTeamIDs)
+	return args.Error(0)
+}
+
+func (m *TeamRepoMock) GetByNameForUpdate(ctx context.Context, tx *sql.Tx, teamName string) (*domain.Team, error) {
+	args := m.Called(ctx, tx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *TeamRepoMock) Archive(ctx context.Context, tx *sql.Tx, teamID int64) (time.Time, error) {
+	args := m.Called(ctx, tx, teamID)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *TeamRepoMock) Delete(ctx context.Context, tx *sql.Tx, teamID int64) error {
+	args := m.Called(ctx, tx, teamID)
+	return args.Error(0)
+}
+
 type UserRepoMock struct {
 	mock.Mock
 }
@@ -55,11 +100,43 @@ func (m *UserRepoMock) GetByID(ctx context.Context, userID string) (*domain.User
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *UserRepoMock) GetActiveTeamMembersIDs(ctx context.Context, teamID int64, excludeUserID string) ([]string, error) {
+func (m *UserRepoMock) GetReviewCandidates(ctx context.Context, teamID int64, excludeUserID string) ([]domain.ReviewCandidate, error) {
 	args := m.Called(ctx, teamID, excludeUserID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).([]domain.ReviewCandidate), args.Error(1)
+}
+
+func (m *UserRepoMock) GetReviewCandidatesByIDs(ctx context.Context, userIDs []string, excludeUserID string) ([]domain.ReviewCandidate, error) {
+	args := m.Called(ctx, userIDs, excludeUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewCandidate), args.Error(1)
+}
+
+func (m *UserRepoMock) GetTeamReviewPool(ctx context.Context, teamID int64) ([]domain.ReviewPoolMember, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewPoolMember), args.Error(1)
+}
+
+func (m *UserRepoMock) GetReviewPoolByIDs(ctx context.Context, userIDs []string) ([]domain.ReviewPoolMember, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewPoolMember), args.Error(1)
+}
+
+func (m *UserRepoMock) DetachTeamMembers(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error) {
+	args := m.Called(ctx, tx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]string), args.Error(1)
 }
 
@@ -93,16 +170,34 @@ func (m *PullRequestRepoMock) GetByIDForUpdate(ctx context.Context, tx *sql.Tx,
 	return args.Get(0).(*domain.PullRequest), args.Error(1)
 }
 
-func (m *PullRequestRepoMock) AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error {
-	args := m.Called(ctx, tx, prID, userID)
+func (m *PullRequestRepoMock) AddReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, source domain.ReviewerSource) error {
+	args := m.Called(ctx, tx, prID, userID, source)
 	return args.Error(0)
 }
 
-func (m *PullRequestRepoMock) RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string) error {
-	args := m.Called(ctx, tx, prID, userID)
+func (m *PullRequestRepoMock) RemoveReviewer(ctx context.Context, tx *sql.Tx, prID, userID string, reason domain.UnassignReason) error {
+	args := m.Called(ctx, tx, prID, userID, reason)
 	return args.Error(0)
 }
 
+func (m *PullRequestRepoMock) SetDecision(ctx context.Context, tx *sql.Tx, prID, userID string, decision *domain.ReviewDecision) error {
+	args := m.Called(ctx, tx, prID, userID, decision)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) AddDecline(ctx context.Context, tx *sql.Tx, prID string, decline *domain.ReviewDecline) error {
+	args := m.Called(ctx, tx, prID, decline)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) GetAssignments(ctx context.Context, prID string) ([]domain.ReviewAssignment, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewAssignment), args.Error(1)
+}
+
 func (m *PullRequestRepoMock) GetPRsByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -110,3 +205,154 @@ func (m *PullRequestRepoMock) GetPRsByReviewer(ctx context.Context, userID strin
 	}
 	return args.Get(0).([]*domain.PullRequest), args.Error(1)
 }
+
+func (m *PullRequestRepoMock) GetUnderstaffedPRIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) GetUnderstaffedPRIDsByTeam(ctx context.Context, teamID int64) ([]string, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) AddForcedMerge(ctx context.Context, tx *sql.Tx, merge *domain.ForcedMerge) error {
+	args := m.Called(ctx, tx, merge)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) GetForcedMerges(ctx context.Context) ([]domain.ForcedMerge, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ForcedMerge), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PendingReview), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) MarkOverdue(ctx context.Context, tx *sql.Tx, prID, userID string, overdueAt time.Time) error {
+	args := m.Called(ctx, tx, prID, userID, overdueAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) GetRemindableAssignments(ctx context.Context, assignedBefore time.Time) ([]domain.PendingAssignment, error) {
+	args := m.Called(ctx, assignedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PendingAssignment), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) MarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, remindedAt time.Time) error {
+	args := m.Called(ctx, tx, prID, userID, remindersSent, remindedAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) UnmarkReminded(ctx context.Context, tx *sql.Tx, prID, userID string, remindersSent int, lastRemindedAt *time.Time) error {
+	args := m.Called(ctx, tx, prID, userID, remindersSent, lastRemindedAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) AddEvent(ctx context.Context, tx *sql.Tx, event *domain.PREvent) error {
+	args := m.Called(ctx, tx, event)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PREvent), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) FlagTeamArchived(ctx context.Context, tx *sql.Tx, teamID int64) ([]string, error) {
+	args := m.Called(ctx, tx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) FlagTeamReviewsArchived(ctx context.Context, tx *sql.Tx, teamID int64) error {
+	args := m.Called(ctx, tx, teamID)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepoMock) CountOpenReviewsByTeam(ctx context.Context, tx *sql.Tx, teamID int64) (int, error) {
+	args := m.Called(ctx, tx, teamID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *PullRequestRepoMock) GetOrphanedPRs(ctx context.Context) ([]domain.OrphanedPR, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrphanedPR), args.Error(1)
+}
+
+type CodeOwnersRepoMock struct {
+	mock.Mock
+}
+
+func (m *CodeOwnersRepoMock) Upsert(ctx context.Context, tx *sql.Tx, codeOwners *domain.CodeOwners) error {
+	args := m.Called(ctx, tx, codeOwners)
+	return args.Error(0)
+}
+
+func (m *CodeOwnersRepoMock) GetByRepository(ctx context.Context, repository string) (*domain.CodeOwners, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CodeOwners), args.Error(1)
+}
+
+type AbsenceRepoMock struct {
+	mock.Mock
+}
+
+func (m *AbsenceRepoMock) Create(ctx context.Context, tx *sql.Tx, absence *domain.Absence) error {
+	args := m.Called(ctx, tx, absence)
+	return args.Error(0)
+}
+
+func (m *AbsenceRepoMock) Delete(ctx context.Context, tx *sql.Tx, userID string, absenceID int64) error {
+	args := m.Called(ctx, tx, userID, absenceID)
+	return args.Error(0)
+}
+
+func (m *AbsenceRepoMock) GetByUser(ctx context.Context, userID string) ([]domain.Absence, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Absence), args.Error(1)
+}
+
+func (m *AbsenceRepoMock) GetStartedToReassign(ctx context.Context, now time.Time) ([]domain.Absence, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Absence), args.Error(1)
+}
+
+func (m *AbsenceRepoMock) MarkReassigned(ctx context.Context, tx *sql.Tx, absenceID int64, reassignedAt time.Time) error {
+	args := m.Called(ctx, tx, absenceID, reassignedAt)
+	return args.Error(0)
+}
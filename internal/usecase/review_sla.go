@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// GetOverdueReviews returns reviews of OPEN PRs whose reviewers exceeded the review SLA of the author's team
+// without a decision, longest waiting first. If teamName is not empty, only PRs authored by its members are returned.
+// Waiting time is measured in the configured working hours.
+//
+// Returns:
+//   - []domain.OverdueReview: overdue reviews with their waiting time (empty if there are none)
+//   - error: domain.ErrNotFound if the team doesn't exist, or any database error
+func (u *PRUseCase) GetOverdueReviews(ctx context.Context, teamName string) ([]domain.OverdueReview, error) {
+	if teamName != "" {
+		// Check that team exists
+		if _, err := u.teamRepo.GetByName(ctx, teamName); err != nil {
+			return nil, err
+		}
+	}
+
+	reviews, err := u.prRepo.GetPendingReviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	overdue := make([]domain.OverdueReview, 0)
+	for _, r := range reviews {
+		if !r.IsOverdue(now, u.workingHours) || (teamName != "" && r.TeamName != teamName) {
+			continue
+		}
+		overdue = append(overdue, domain.OverdueReview{PendingReview: r, Waiting: r.Waiting(now, u.workingHours)})
+	}
+
+	return overdue, nil
+}
+
+// CheckReviewSLA marks reviews that exceeded the review SLA of the author's team as overdue, recording it
+// in the PR timeline. Waiting time is measured in the configured working hours. Reviews overdue for longer than the team allows (see domain.Team.SLAReassignHours)
+// are handed over as by ReassignReviewer; if there is no replacement the reviewer keeps the review.
+// Every review is processed in its own transaction.
+//
+// Returns:
+//   - *domain.SLAReport: reviews found overdue in this run, reassigned reviews and reviews that kept their reviewer
+//   - error: any database error; reviews processed before it are included in the report
+func (u *PRUseCase) CheckReviewSLA(ctx context.Context) (*domain.SLAReport, error) {
+	reviews, err := u.prRepo.GetPendingReviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.SLAReport{}
+	now := time.Now()
+
+	for _, review := range reviews {
+		if !review.IsOverdue(now, u.workingHours) {
+			continue
+		}
+
+		if review.OverdueAt == nil {
+			marked, err := u.markOverdue(ctx, review, now)
+			if err != nil {
+				return report, err
+			}
+			if !marked {
+				continue // the review changed concurrently
+			}
+			review.OverdueAt = &now
+			report.Overdue = append(report.Overdue, review)
+		}
+
+		if !review.IsDueForReassign(now, u.workingHours) {
+			continue
+		}
+
+		newReviewerID, err := u.reassignOverdue(ctx, review)
+		switch {
+		case err == nil:
+			report.Reassigned = append(report.Reassigned, domain.ReviewReassignment{
+				PRID:          review.PRID,
+				OldReviewerID: review.ReviewerID,
+				NewReviewerID: newReviewerID,
+			})
+		case errors.Is(err, domain.ErrNoCandidate):
+			report.NotReassigned = append(report.NotReassigned, domain.UnreassignedReview{
+				PRID:       review.PRID,
+				ReviewerID: review.ReviewerID,
+				Reason:     err.Error(),
+			})
+		case errors.Is(err, domain.ErrNotAssigned), errors.Is(err, domain.ErrPRMerged), errors.Is(err, domain.ErrPRNotOpen):
+			// PR changed concurrently, nothing to hand over
+		default:
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// markOverdue marks the review as overdue together with its event in the PR timeline.
+// Returns false if the assignment has ended or was marked meanwhile.
+func (u *PRUseCase) markOverdue(ctx context.Context, review domain.PendingReview, now time.Time) (bool, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.prRepo.MarkOverdue(ctx, tx, review.PRID, review.ReviewerID, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = u.addEventTx(ctx, tx, review.PRID, domain.EventReviewOverdue, "", map[string]any{
+		"reviewer_id": review.ReviewerID,
+		"sla_hours":   review.ReviewSLAHours,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reassignOverdue hands over the overdue review to another reviewer
+func (u *PRUseCase) reassignOverdue(ctx context.Context, review domain.PendingReview) (string, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, newReviewerID, err := u.reassignReviewerTx(ctx, tx, review.PRID, review.ReviewerID, "", domain.UnassignOverdue, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
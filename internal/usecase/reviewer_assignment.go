@@ -2,60 +2,399 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"slices"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 )
 
-// getReviewersToAssign selects random active team members to be assigned as reviewers.
-// Excludes users provided in excludeUserIDs (used to exclude the author and any user IDs).
-// Returns up to domain.MaxReviewersAmount reviewers.
+// getAuthorTeam returns the team of the PR author, which defines the candidates
+// and the assignment rules for the PR.
+//
+// PRs of authors removed from their team are left with the default rules and no team candidates.
 //
 // Returns:
-//   - []string: slice of user IDs to be assigned as reviewers (can be empty if no candidates)
-//   - error: domain.ErrNotFound if author doesn't exist, or any database error
-func (u *PRUseCase) getReviewersToAssign(ctx context.Context, authorID string, excludeUserIDs ...string) ([]string, error) {
+//   - *domain.Team: team of the author (without members), with zero ID if the author has no team
+//   - error: domain.ErrNotFound if author or his team doesn't exist, or any database error
+func (u *PRUseCase) getAuthorTeam(ctx context.Context, authorID string) (*domain.Team, error) {
 	// Get author to extract his team ID
 	author, err := u.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get all active team members
-	candidates, err := u.userRepo.GetActiveTeamMembersIDs(ctx, author.TeamID, authorID)
+	if author.TeamID == 0 {
+		return &domain.Team{AssignmentStrategy: domain.StrategyRandom, MaxReviewers: domain.DefaultMaxReviewers}, nil
+	}
+
+	// Get team to find out its assignment strategy and reviewers policy
+	return u.teamRepo.GetByID(ctx, author.TeamID)
+}
+
+// getReviewersToAssign selects reviewers for the PR, using the selector configured for the team.
+// Candidates are taken in tiers until amount reviewers are selected:
+//   - preferred candidates (code owners of the changed files), if any
+//   - active members of the author's team
+//   - members of the team's fallback teams in their priority order, each with its own selector
+//
+// Within each tier candidates whose tags match the PR labels are preferred.
+// Excludes the author, reviewers already assigned to the PR, users that declined it
+// and users that reached their review capacity.
+// pending adjusts the candidates by the changes made earlier in the current transaction (can be nil).
+// Returns up to amount reviewers.
+//
+// Returns:
+//   - []domain.ReviewCandidate: reviewers to be assigned with their Source set (can be empty if no candidates)
+//   - error: domain.ErrAllAtCapacity if there were candidates but all of them are at capacity,
+//     or any database error
+func (u *PRUseCase) getReviewersToAssign(ctx context.Context, team *domain.Team, pr *domain.PullRequest, amount int, preferred []domain.ReviewCandidate, pending *pendingChanges) ([]domain.ReviewCandidate, error) {
+	authorID := pr.AuthorID
+	var reviewers []domain.ReviewCandidate
+	atCapacity := false
+
+	// pickFrom tops up reviewers from the given candidates, skipping already selected ones
+	pickFrom := func(selectorTeam *domain.Team, candidates []domain.ReviewCandidate, source domain.ReviewerSource) error {
+		exclude := slices.Concat(pr.ReviewersIDs, pr.DeclinedIDs(), reviewerIDs(reviewers))
+		candidates = pending.adjust(candidates)
+
+		picked, saturated, err := u.selectFrom(ctx, selectorTeam, candidates, amount-len(reviewers), exclude)
+		if err != nil {
+			return err
+		}
+		for _, r := range picked {
+			r.Source = source
+			reviewers = append(reviewers, r)
+		}
+		atCapacity = atCapacity || saturated
+		return nil
+	}
+
+	// pick takes reviewers from one tier: candidates with matching tags first, then the rest
+	pick := func(selectorTeam *domain.Team, candidates []domain.ReviewCandidate, source domain.ReviewerSource) error {
+		if len(pr.Labels) > 0 {
+			matching := slices.DeleteFunc(slices.Clone(candidates), func(c domain.ReviewCandidate) bool {
+				return !c.HasAnyTag(pr.Labels)
+			})
+			if err := pickFrom(selectorTeam, matching, source); err != nil {
+				return err
+			}
+			if len(reviewers) >= amount {
+				return nil
+			}
+		}
+		return pickFrom(selectorTeam, candidates, source)
+	}
+
+	// Code owners go first
+	if len(preferred) > 0 {
+		if err := pick(team, preferred, domain.SourceCodeOwners); err != nil {
+			return nil, err
+		}
+	}
+
+	// Then the author's team
+	if len(reviewers) < amount {
+		// Get all active team members together with their current review load
+		candidates, err := u.userRepo.GetReviewCandidates(ctx, team.ID, authorID)
+		if err != nil {
+			return nil, err
+		}
+		if err = pick(team, candidates, domain.SourceTeam); err != nil {
+			return nil, err
+		}
+	}
+
+	// Top up from fallback teams if the team itself is too small
+	if len(reviewers) < amount {
+		fallbacks, err := u.teamRepo.GetFallbackTeams(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fallback := range fallbacks {
+			if len(reviewers) >= amount {
+				break
+			}
+
+			candidates, err := u.userRepo.GetReviewCandidates(ctx, fallback.ID, authorID)
+			if err != nil {
+				return nil, err
+			}
+			if err = pick(fallback, candidates, domain.SourceFallback); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if amount > 0 && len(reviewers) == 0 && atCapacity {
+		return nil, domain.ErrAllAtCapacity
+	}
+
+	return reviewers, nil
+}
+
+// selectFrom selects up to amount reviewers among the candidates with the team's selector,
+// skipping excluded users and users at their review capacity.
+//
+// Returns:
+//   - []domain.ReviewCandidate: selected reviewers
+//   - bool: true if some of the candidates were skipped because they are at capacity
+//   - error: any selector error
+func (u *PRUseCase) selectFrom(ctx context.Context, team *domain.Team, candidates []domain.ReviewCandidate, amount int, excludeUserIDs []string) ([]domain.ReviewCandidate, bool, error) {
+	// remove candidates that must be excluded
+	candidates = slices.DeleteFunc(slices.Clone(candidates), func(c domain.ReviewCandidate) bool {
+		return slices.Contains(excludeUserIDs, c.UserID)
+	})
+
+	// remove candidates that cannot take more reviews
+	available := slices.DeleteFunc(slices.Clone(candidates), domain.ReviewCandidate.AtCapacity)
+	atCapacity := len(available) < len(candidates)
+
+	// Select reviewers (up to amount) with the team's strategy
+	selected, err := u.selectorFor(team).Select(ctx, team.ID, available, amount)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return selected, atCapacity, nil
+}
+
+// checkRequestedReviewer checks that the explicitly chosen user can review the PR: he must be
+// a member of the author's team or one of its fallback teams and pass the usual rules
+// (active, not absent, not the author, not already assigned, not declined the PR, below his review capacity).
+// pending adjusts the user by the changes made earlier in the current transaction (can be nil).
+//
+// Returns:
+//   - domain.ReviewCandidate: the user as a candidate with his Source set
+//   - error: domain.ErrNotFound if user doesn't exist, domain.ErrInvalidCandidate describing
+//     the violated rule, or any database error
+func (u *PRUseCase) checkRequestedReviewer(ctx context.Context, team *domain.Team, pr *domain.PullRequest, userID string, pending *pendingChanges) (domain.ReviewCandidate, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.ReviewCandidate{}, err
+	}
+
+	source := domain.SourceTeam
+	if user.TeamID != team.ID {
+		fallbacks, err := u.teamRepo.GetFallbackTeams(ctx, team.ID)
+		if err != nil {
+			return domain.ReviewCandidate{}, err
+		}
+		if !slices.ContainsFunc(fallbacks, func(t *domain.Team) bool { return t.ID == user.TeamID }) {
+			return domain.ReviewCandidate{}, fmt.Errorf("%w: user %q is not a member of team %q or its fallback teams",
+				domain.ErrInvalidCandidate, userID, team.Name)
+		}
+		source = domain.SourceFallback
+	}
+
+	candidate, err := u.checkReviewerAvailable(ctx, pr, userID, pending)
+	if err != nil {
+		return domain.ReviewCandidate{}, err
+	}
+
+	candidate.Source = source
+	return candidate, nil
+}
+
+// checkReviewerAvailable checks that the user can take the review of the PR regardless of his team:
+// he must be active, not absent, not the author, not already assigned, not declined the PR
+// and below his review capacity.
+// pending adjusts the user by the changes made earlier in the current transaction (can be nil).
+//
+// Returns:
+//   - domain.ReviewCandidate: the user as a candidate, without Source
+//   - error: domain.ErrNotFound if user doesn't exist, domain.ErrInvalidCandidate describing
+//     the violated rule, or any database error
+func (u *PRUseCase) checkReviewerAvailable(ctx context.Context, pr *domain.PullRequest, userID string, pending *pendingChanges) (domain.ReviewCandidate, error) {
+	members, err := u.userRepo.GetReviewPoolByIDs(ctx, []string{userID})
+	if err != nil {
+		return domain.ReviewCandidate{}, err
+	}
+	if len(members) == 0 {
+		return domain.ReviewCandidate{}, domain.ErrNotFound
+	}
+
+	member := members[0]
+	member.OpenReviews += pending.loadOf(userID)
+	member.IsActive = member.IsActive && !pending.isLeaving(userID)
+	if reason := member.ExclusionReason(pr); reason != "" {
+		return domain.ReviewCandidate{}, fmt.Errorf("%w: user %q is excluded as %s",
+			domain.ErrInvalidCandidate, userID, reason)
+	}
+
+	return member.ReviewCandidate, nil
+}
+
+// getCodeOwnerCandidates resolves the owners of the files changed in the PR according to
+// the CODEOWNERS of its repository. Owner teams are expanded to their active members.
+// Owners unknown to the service are skipped.
+//
+// Returns:
+//   - []domain.ReviewCandidate: owners that can review the PR (empty if the PR has no repository
+//     or changed files, the repository has no CODEOWNERS or no rule matches)
+//   - error: any database error
+func (u *PRUseCase) getCodeOwnerCandidates(ctx context.Context, pr *domain.PullRequest) ([]domain.ReviewCandidate, error) {
+	userIDs, teamIDs, err := u.resolveCodeOwners(ctx, pr)
 	if err != nil {
 		return nil, err
 	}
 
-	// remove candidates that must be excluded
-	for _, id := range excludeUserIDs {
-		// remove current reviewer
-		currIdx := slices.Index(candidates, id)
-		if currIdx != -1 { // if it is in slice
-			candidates = append(candidates[:currIdx], candidates[currIdx+1:]...)
+	var candidates []domain.ReviewCandidate
+	for _, teamID := range teamIDs {
+		members, err := u.userRepo.GetReviewCandidates(ctx, teamID, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, members...)
+	}
+
+	if len(userIDs) > 0 {
+		users, err := u.userRepo.GetReviewCandidatesByIDs(ctx, userIDs, pr.AuthorID)
+		if err != nil {
+			return nil, err
 		}
+		candidates = append(candidates, users...)
 	}
 
-	// Select random reviewers (up to domain.MaxReviewersAmount)
-	reviewers := selectRandomReviewers(candidates, domain.MaxReviewersAmount)
+	// a user can be an owner both personally and through his team
+	seen := make(map[string]bool, len(candidates))
+	return slices.DeleteFunc(candidates, func(c domain.ReviewCandidate) bool {
+		if seen[c.UserID] {
+			return true
+		}
+		seen[c.UserID] = true
+		return false
+	}), nil
+}
 
-	return reviewers, nil
+// resolveCodeOwners matches the files changed in the PR against the CODEOWNERS of its repository.
+// Owner teams unknown to the service are skipped, owner users are returned as is.
+//
+// Returns:
+//   - []string: IDs of the users owning the changed files
+//   - []int64: IDs of the teams owning the changed files
+//   - error: any database error
+func (u *PRUseCase) resolveCodeOwners(ctx context.Context, pr *domain.PullRequest) ([]string, []int64, error) {
+	if pr.Repository == "" || len(pr.ChangedFiles) == 0 {
+		return nil, nil, nil
+	}
+
+	codeOwners, err := u.codeOwnersRepo.GetByRepository(ctx, pr.Repository)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	// stored files are validated on upload
+	rules, err := parseCodeOwners(codeOwners.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var userIDs []string
+	var teamIDs []int64
+	for _, owner := range matchCodeOwners(rules, pr.ChangedFiles) {
+		name, isTeam, _ := parseCodeOwner(owner)
+		if !isTeam {
+			userIDs = append(userIDs, name)
+			continue
+		}
+
+		team, err := u.teamRepo.GetByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			return nil, nil, err
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	return userIDs, teamIDs, nil
+}
+
+// pendingChanges holds changes made earlier in the current transaction, which the candidate queries
+// don't see as they run outside of it. A nil *pendingChanges holds no changes.
+type pendingChanges struct {
+	load    map[string]int // reviews assigned to the users
+	leaving []string       // users deactivated, who can't take reviews anymore
+}
+
+// newPendingChanges starts tracking changes of a transaction that deactivates the leaving users
+func newPendingChanges(leaving ...string) *pendingChanges {
+	return &pendingChanges{load: make(map[string]int), leaving: leaving}
+}
+
+// assigned counts a review assigned to the user
+func (p *pendingChanges) assigned(userID string) {
+	if p != nil {
+		p.load[userID]++
+	}
+}
+
+// loadOf returns the number of reviews assigned to the user
+func (p *pendingChanges) loadOf(userID string) int {
+	if p == nil {
+		return 0
+	}
+	return p.load[userID]
+}
+
+// isLeaving reports whether the user is deactivated
+func (p *pendingChanges) isLeaving(userID string) bool {
+	return p != nil && slices.Contains(p.leaving, userID)
+}
+
+// adjust drops leaving users from the candidates and adds reviews assigned to the rest to their load
+func (p *pendingChanges) adjust(candidates []domain.ReviewCandidate) []domain.ReviewCandidate {
+	if p == nil || (len(p.load) == 0 && len(p.leaving) == 0) {
+		return candidates
+	}
+
+	adjusted := slices.DeleteFunc(slices.Clone(candidates), func(c domain.ReviewCandidate) bool {
+		return p.isLeaving(c.UserID)
+	})
+	for i := range adjusted {
+		adjusted[i].OpenReviews += p.load[adjusted[i].UserID]
+	}
+	return adjusted
+}
+
+// reviewerIDs extracts user IDs of the reviewers
+func reviewerIDs(reviewers []domain.ReviewCandidate) []string {
+	ids := make([]string, len(reviewers))
+	for i, r := range reviewers {
+		ids[i] = r.UserID
+	}
+	return ids
+}
+
+// selectorFor returns the reviewer selector configured for the team.
+// Falls back to random selection if the team strategy is unknown.
+func (u *PRUseCase) selectorFor(team *domain.Team) ReviewerSelector {
+	if selector, ok := u.selectors[team.AssignmentStrategy]; ok {
+		return selector
+	}
+	return u.selectors[domain.StrategyRandom]
 }
 
 // selectRandomReviewers randomly selects up to reviewersAmount users from candidates.
 // If there are fewer candidates than requested, returns all candidates.
 //
 // Returns:
-//   - []string: randomly shuffled slice of candidates' user IDs
-func selectRandomReviewers(candidates []string, reviewersAmount int) []string {
+//   - []domain.ReviewCandidate: randomly shuffled slice of candidates
+func selectRandomReviewers(candidates []domain.ReviewCandidate, reviewersAmount int) []domain.ReviewCandidate {
 	// If the amount of available candidates is less than needed amount, just select all of them
 	if len(candidates) <= reviewersAmount {
 		return candidates
 	}
 
 	// Shuffle candidates slice and return first `reviewersAmount` elements
-	shuffled := make([]string, len(candidates))
+	shuffled := make([]domain.ReviewCandidate, len(candidates))
 	copy(shuffled, candidates)
 
 	rand.Shuffle(len(shuffled), func(i, j int) {
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// PreviewReviewers runs reviewer selection for a hypothetical PR without writing anything.
+// Selection works as on PR creation (see getReviewersToAssign), but reviewers in pr.ReviewersIDs
+// are treated as already assigned and only the remaining places up to the team's maximum are filled.
+// The preview tells how many reviewers are missing to meet the team's minimum, where creation would fail.
+// Round-robin rotation is not advanced, random strategies may choose differently on the actual run.
+//
+// The candidate pool lists users of all tiers - code owners, the author's team and its fallback teams -
+// although later tiers are only consulted when earlier ones lack candidates.
+//
+// Returns:
+//   - *domain.AssignmentPreview: pool of candidates, excluded users with reasons and reviewers
+//     that would be assigned
+//   - error: domain.ErrNotFound if author or his team doesn't exist or the author has no team,
+//     or any database error
+func (u *PRUseCase) PreviewReviewers(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error) {
+	pr.Status = domain.StatusOpen
+	pr.Labels = domain.NormalizeTags(pr.Labels)
+
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	// PR creation is refused for such authors, so there is nothing to preview
+	if team.ID == 0 {
+		return nil, fmt.Errorf("%w: author %s has no team", domain.ErrNotFound, pr.AuthorID)
+	}
+
+	amount := max(team.MaxReviewers-len(pr.ReviewersIDs), 0)
+
+	owners, err := u.getCodeOwnerCandidates(ctx, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := u.getReviewersToAssign(withDryRun(ctx), team, &pr, amount, owners, nil)
+	if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+		return nil, err
+	}
+
+	preview := &domain.AssignmentPreview{
+		Team:      team,
+		Amount:    amount,
+		Reviewers: reviewers,
+		Shortfall: max(team.MinReviewers-len(pr.ReviewersIDs)-len(reviewers), 0),
+	}
+
+	err = u.explainReviewPool(ctx, team, &pr, preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// explainReviewPool fills candidates and excluded users of the preview, going through the tiers
+// in the same order as getReviewersToAssign. A user is classified once, in the first tier he belongs to.
+func (u *PRUseCase) explainReviewPool(ctx context.Context, team *domain.Team, pr *domain.PullRequest, preview *domain.AssignmentPreview) error {
+	seen := make(map[string]bool)
+
+	classify := func(members []domain.ReviewPoolMember, source domain.ReviewerSource) {
+		for _, member := range members {
+			if seen[member.UserID] {
+				continue
+			}
+			seen[member.UserID] = true
+
+			if reason := member.ExclusionReason(pr); reason != "" {
+				preview.Excluded = append(preview.Excluded, domain.ExcludedCandidate{
+					UserID: member.UserID,
+					Source: source,
+					Reason: reason,
+				})
+				continue
+			}
+
+			candidate := member.ReviewCandidate
+			candidate.Source = source
+			preview.Candidates = append(preview.Candidates, candidate)
+		}
+	}
+
+	// Code owners of the changed files
+	ownerIDs, ownerTeamIDs, err := u.resolveCodeOwners(ctx, pr)
+	if err != nil {
+		return err
+	}
+	for _, teamID := range ownerTeamIDs {
+		members, err := u.userRepo.GetTeamReviewPool(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		classify(members, domain.SourceCodeOwners)
+	}
+	if len(ownerIDs) > 0 {
+		members, err := u.userRepo.GetReviewPoolByIDs(ctx, ownerIDs)
+		if err != nil {
+			return err
+		}
+		classify(members, domain.SourceCodeOwners)
+	}
+
+	// The author's team
+	members, err := u.userRepo.GetTeamReviewPool(ctx, team.ID)
+	if err != nil {
+		return err
+	}
+	classify(members, domain.SourceTeam)
+
+	// Fallback teams in their priority order
+	fallbacks, err := u.teamRepo.GetFallbackTeams(ctx, team.ID)
+	if err != nil {
+		return err
+	}
+	for _, fallback := range fallbacks {
+		members, err := u.userRepo.GetTeamReviewPool(ctx, fallback.ID)
+		if err != nil {
+			return err
+		}
+		classify(members, domain.SourceFallback)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// ReviewerSelector picks reviewers among candidates that already passed all assignment rules
+// (active, not the author, not already assigned).
+// Implementations return at most amount candidates, all of them taken from candidates.
+// Selection in a dry run context (see withDryRun) must not change the selector state.
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamID int64, candidates []domain.ReviewCandidate, amount int) ([]domain.ReviewCandidate, error)
+}
+
+type dryRunKey struct{}
+
+// withDryRun marks the context of a selection that is only previewed and won't be assigned
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// isDryRun reports whether the selection result won't be assigned
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// RandomSelector picks reviewers uniformly at random.
+type RandomSelector struct{}
+
+// NewRandomSelector creates a new instance of RandomSelector
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+// Select returns up to amount randomly chosen candidates.
+func (s *RandomSelector) Select(_ context.Context, _ int64, candidates []domain.ReviewCandidate, amount int) ([]domain.ReviewCandidate, error) {
+	return selectRandomReviewers(candidates, amount), nil
+}
+
+// RoundRobinSelector rotates through team members in a stable order (sorted by user ID),
+// continuing right after the last reviewer it picked for the same team.
+// Rotation state is kept in memory, so it restarts from the beginning after service restart.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last map[int64]string // teamID -> last picked user ID
+}
+
+// NewRoundRobinSelector creates a new instance of RoundRobinSelector
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{last: make(map[int64]string)}
+}
+
+// Select returns up to amount candidates following the rotation of the team.
+// The rotation is not advanced in a dry run.
+func (s *RoundRobinSelector) Select(ctx context.Context, teamID int64, candidates []domain.ReviewCandidate, amount int) ([]domain.ReviewCandidate, error) {
+	if amount <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+	amount = min(amount, len(candidates))
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b domain.ReviewCandidate) int {
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Start from the first candidate that goes after the last picked one.
+	// Comparing IDs instead of remembering an index keeps the rotation fair
+	// when team members join, leave or change their activity.
+	start := 0
+	if last, ok := s.last[teamID]; ok {
+		start = sort.Search(len(ordered), func(i int) bool {
+			return ordered[i].UserID > last
+		})
+		start %= len(ordered)
+	}
+
+	reviewers := make([]domain.ReviewCandidate, 0, amount)
+	for i := 0; i < amount; i++ {
+		reviewers = append(reviewers, ordered[(start+i)%len(ordered)])
+	}
+
+	if !isDryRun(ctx) {
+		s.last[teamID] = reviewers[len(reviewers)-1].UserID
+	}
+
+	return reviewers, nil
+}
+
+// LeastLoadedSelector picks candidates with the smallest number of OPEN pull requests to review.
+// Candidates with equal load are picked randomly.
+type LeastLoadedSelector struct{}
+
+// NewLeastLoadedSelector creates a new instance of LeastLoadedSelector
+func NewLeastLoadedSelector() *LeastLoadedSelector {
+	return &LeastLoadedSelector{}
+}
+
+// Select returns up to amount least loaded candidates.
+func (s *LeastLoadedSelector) Select(_ context.Context, _ int64, candidates []domain.ReviewCandidate, amount int) ([]domain.ReviewCandidate, error) {
+	if amount <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Shuffle first, so the stable sort below breaks ties randomly
+	ordered := slices.Clone(candidates)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	slices.SortStableFunc(ordered, func(a, b domain.ReviewCandidate) int {
+		return cmp.Compare(a.OpenReviews, b.OpenReviews)
+	})
+
+	return ordered[:min(amount, len(ordered))], nil
+}
+
+// newSelectors returns the selectors available for teams, keyed by assignment strategy
+func newSelectors() map[domain.AssignmentStrategy]ReviewerSelector {
+	return map[domain.AssignmentStrategy]ReviewerSelector{
+		domain.StrategyRandom:      NewRandomSelector(),
+		domain.StrategyRoundRobin:  NewRoundRobinSelector(),
+		domain.StrategyLeastLoaded: NewLeastLoadedSelector(),
+	}
+}
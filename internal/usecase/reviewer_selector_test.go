@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestRandomSelector_Select(t *testing.T) {
+	selector := NewRandomSelector()
+	candidates := reviewCandidates("u1", "u2", "u3", "u4")
+
+	reviewers, err := selector.Select(context.Background(), 1, candidates, 2)
+	require.NoError(t, err)
+	assert.Len(t, reviewers, 2)
+	assert.Subset(t, candidates, reviewers)
+	assert.NotEqual(t, reviewers[0], reviewers[1])
+
+	// Fewer candidates than needed - all of them are returned
+	reviewers, err = selector.Select(context.Background(), 1, reviewCandidates("u1"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, reviewerIDs(reviewers))
+}
+
+func TestRoundRobinSelector_Select_Rotates(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	ctx := context.Background()
+	candidates := reviewCandidates("u3", "u1", "u2") // order in input does not matter
+
+	reviewers, err := selector.Select(ctx, 1, candidates, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, reviewerIDs(reviewers))
+
+	// Continues after the last picked one and wraps around
+	reviewers, err = selector.Select(ctx, 1, candidates, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u1"}, reviewerIDs(reviewers))
+
+	reviewers, err = selector.Select(ctx, 1, candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, reviewerIDs(reviewers))
+
+	// Rotation is tracked per team
+	reviewers, err = selector.Select(ctx, 2, candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, reviewerIDs(reviewers))
+}
+
+func TestRoundRobinSelector_Select_LastPickedLeftTeam(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	ctx := context.Background()
+
+	reviewers, err := selector.Select(ctx, 1, reviewCandidates("u1", "u2", "u3"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, reviewerIDs(reviewers))
+
+	// u2 is not a candidate anymore (e.g. already assigned) - rotation proceeds with the next one
+	reviewers, err = selector.Select(ctx, 1, reviewCandidates("u1", "u3"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, reviewerIDs(reviewers))
+}
+
+func TestRoundRobinSelector_Select_DryRun(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	ctx := context.Background()
+	candidates := reviewCandidates("u1", "u2", "u3")
+
+	// Previews do not advance the rotation
+	for range 2 {
+		reviewers, err := selector.Select(withDryRun(ctx), 1, candidates, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, reviewerIDs(reviewers))
+	}
+
+	reviewers, err := selector.Select(ctx, 1, candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, reviewerIDs(reviewers))
+
+	reviewers, err = selector.Select(withDryRun(ctx), 1, candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, reviewerIDs(reviewers))
+}
+
+func TestRoundRobinSelector_Select_NoCandidates(t *testing.T) {
+	selector := NewRoundRobinSelector()
+
+	reviewers, err := selector.Select(context.Background(), 1, nil, 2)
+	require.NoError(t, err)
+	assert.Empty(t, reviewers)
+}
+
+func TestLeastLoadedSelector_Select(t *testing.T) {
+	selector := NewLeastLoadedSelector()
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u1", OpenReviews: 5},
+		{UserID: "u2", OpenReviews: 1},
+		{UserID: "u3", OpenReviews: 0},
+		{UserID: "u4", OpenReviews: 3},
+	}
+
+	reviewers, err := selector.Select(context.Background(), 1, candidates, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u2"}, reviewerIDs(reviewers))
+}
+
+func TestLeastLoadedSelector_Select_BreaksTiesRandomly(t *testing.T) {
+	selector := NewLeastLoadedSelector()
+	candidates := []domain.ReviewCandidate{
+		{UserID: "u1", OpenReviews: 2},
+		{UserID: "u2", OpenReviews: 1},
+		{UserID: "u3", OpenReviews: 1},
+	}
+
+	// Both equally loaded candidates must eventually be picked first
+	picked := make(map[string]bool)
+	for i := 0; i < 100 && len(picked) < 2; i++ {
+		reviewers, err := selector.Select(context.Background(), 1, candidates, 1)
+		require.NoError(t, err)
+		require.Len(t, reviewers, 1)
+		picked[reviewers[0].UserID] = true
+	}
+
+	assert.Equal(t, map[string]bool{"u2": true, "u3": true}, picked)
+}
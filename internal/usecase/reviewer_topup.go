@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// TopUpReviewers fills missing reviewer places of all OPEN PRs that have fewer reviewers
+// than the author's team allows (e.g. created when the team was too small).
+// Reviewers are selected as on PR creation, except that code owners are not known anymore.
+// Every PR is processed in its own transaction.
+//
+// Returns:
+//   - []domain.ReviewerTopUp: assigned reviewers (empty if there were no gaps or no candidates)
+//   - error: any database error; reviewers assigned before it are included in the result
+func (u *PRUseCase) TopUpReviewers(ctx context.Context) ([]domain.ReviewerTopUp, error) {
+	prIDs, err := u.prRepo.GetUnderstaffedPRIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.topUpPRs(ctx, prIDs)
+}
+
+// TopUpTeamReviewers works like TopUpReviewers, but only for PRs authored by members of the team.
+// Called when the team gets new or reactivated members.
+func (u *PRUseCase) TopUpTeamReviewers(ctx context.Context, teamID int64) ([]domain.ReviewerTopUp, error) {
+	prIDs, err := u.prRepo.GetUnderstaffedPRIDsByTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.topUpPRs(ctx, prIDs)
+}
+
+// topUpPRs fills missing reviewer places of the given PRs one by one
+func (u *PRUseCase) topUpPRs(ctx context.Context, prIDs []string) ([]domain.ReviewerTopUp, error) {
+	var result []domain.ReviewerTopUp
+	for _, prID := range prIDs {
+		assigned, err := u.topUpPR(ctx, prID)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, assigned...)
+	}
+
+	return result, nil
+}
+
+// topUpPR assigns reviewers to the OPEN PR until it has as many as the author's team allows.
+// The PR row is locked, so concurrent changes of its reviewers are taken into account.
+func (u *PRUseCase) topUpPR(ctx context.Context, prID string) ([]domain.ReviewerTopUp, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	pr, err := u.prRepo.GetByIDForUpdate(ctx, tx, prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil // deleted meanwhile
+		}
+		return nil, err
+	}
+	if pr.Status != domain.StatusOpen {
+		return nil, nil
+	}
+
+	team, err := u.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := team.MaxReviewers - len(pr.ReviewersIDs)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	reviewers, err := u.getReviewersToAssign(ctx, team, pr, amount, nil, nil)
+	if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+		return nil, err
+	}
+	if len(reviewers) == 0 {
+		return nil, nil
+	}
+
+	err = u.assignReviewersTx(ctx, tx, pr, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make([]domain.ReviewerTopUp, 0, len(reviewers))
+	for _, rev := range reviewers {
+		assigned = append(assigned, domain.ReviewerTopUp{PRID: prID, ReviewerID: rev.UserID, Source: rev.Source})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return assigned, nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// ArchiveTeam archives the team: it is hidden from lookups, and its members are detached from it and deactivated.
+// Open and draft PRs authored by the members are flagged and get an AUTHOR_TEAM_ARCHIVED event,
+// see PRUseCase.GetOrphanedPRs. Open reviews of the members are handed over in the same transaction,
+// none of them taking another's review. Reviews without a replacement and reviews of the flagged PRs
+// are kept: they are handed over or finished by hand, and the team can't be deleted until then (see DeleteTeam).
+// The name of the team stays taken until the team is deleted.
+//
+// Returns:
+//   - *domain.TeamArchive: detached members, flagged PRs and handed over reviews
+//   - error: domain.ErrNotFound if team doesn't exist or is already archived, or any database error
+func (u *TeamUseCase) ArchiveTeam(ctx context.Context, teamName string) (*domain.TeamArchive, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the team: users can't join it until the commit, as the foreign key check waits for the lock
+	team, err := u.teamRepo.GetByNameForUpdate(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team.ArchivedAt != nil {
+		return nil, fmt.Errorf("%w: team %s is already archived", domain.ErrNotFound, teamName)
+	}
+
+	archivedAt, err := u.teamRepo.Archive(ctx, tx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// PRs are found by the team of their authors, so they are flagged before the members are detached
+	flaggedPRs, err := u.prRepo.FlagTeamArchived(ctx, tx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, prID := range flaggedPRs {
+		err = u.prRepo.AddEvent(ctx, tx, &domain.PREvent{
+			PRID:    prID,
+			Type:    domain.EventAuthorTeamArchived,
+			Actor:   domain.ActorFromContext(ctx),
+			Payload: map[string]any{"team_name": team.Name},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Reviews kept by the members still reference the team after they are detached, see DeleteTeam
+	if err = u.prRepo.FlagTeamReviewsArchived(ctx, tx, team.ID); err != nil {
+		return nil, err
+	}
+
+	detached, err := u.userRepo.DetachTeamMembers(ctx, tx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignment, err := u.assigner.reassignLeavingReviewsTx(ctx, tx, detached, domain.UnassignLeftTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &domain.TeamArchive{
+		TeamName:        team.Name,
+		ArchivedAt:      archivedAt,
+		DetachedMembers: detached,
+		FlaggedPRs:      flaggedPRs,
+		Reassignment:    reassignment,
+	}, nil
+}
+
+// DeleteTeam removes the team for good, archived or not, releasing its name.
+// Remaining members are detached from it, not deleted; fallback links to and from the team are removed.
+// The team is kept while OPEN PRs have reviewers that reference it: the author or the reviewer
+// is its member, or was when the team was archived.
+//
+// Returns:
+//   - error: domain.ErrNotFound if team doesn't exist, domain.ErrTeamInUse if open reviews
+//     reference the team, or any database error
+func (u *TeamUseCase) DeleteTeam(ctx context.Context, teamName string) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Lock the team, so it isn't archived or joined while the reviews are counted
+	team, err := u.teamRepo.GetByNameForUpdate(ctx, tx, teamName)
+	if err != nil {
+		return err
+	}
+
+	openReviews, err := u.prRepo.CountOpenReviewsByTeam(ctx, tx, team.ID)
+	if err != nil {
+		return err
+	}
+	if openReviews > 0 {
+		return fmt.Errorf("%w: %d open reviews reference team %s", domain.ErrTeamInUse, openReviews, teamName)
+	}
+
+	if err = u.teamRepo.Delete(ctx, tx, team.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestTeamUseCase_ArchiveTeam_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := new(PullRequestRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := domain.WithActor(context.Background(), "admin")
+	archivedAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("Archive", ctx, mock.Anything, int64(1)).Return(archivedAt, nil)
+	mockPRRepo.On("FlagTeamArchived", ctx, mock.Anything, int64(1)).Return([]string{"pr-1", "pr-2"}, nil)
+	mockPRRepo.On("AddEvent", ctx, mock.Anything, mock.MatchedBy(func(e *domain.PREvent) bool {
+		return e.Type == domain.EventAuthorTeamArchived && e.Actor == "admin" && e.Payload["team_name"] == "backend"
+	})).Return(nil).Twice()
+	mockPRRepo.On("FlagTeamReviewsArchived", ctx, mock.Anything, int64(1)).Return(nil)
+	mockUserRepo.On("DetachTeamMembers", ctx, mock.Anything, int64(1)).Return([]string{"u1", "u2"}, nil)
+	report := &domain.ReassignmentReport{
+		Reassigned:    []domain.ReviewReassignment{{PRID: "pr-3", OldReviewerID: "u1", NewReviewerID: "u5"}},
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-1", ReviewerID: "u2", Reason: "author u1 leaves as well"}},
+	}
+	mockAssigner := noTopUps()
+	mockAssigner.On("reassignLeavingReviewsTx", ctx, mock.AnythingOfType("*sql.Tx"), []string{"u1", "u2"}, domain.UnassignLeftTeam).
+		Return(report, nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, mockPRRepo, mockAssigner, db, zap.NewNop())
+	archive, err := uc.ArchiveTeam(ctx, "backend")
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.TeamArchive{
+		TeamName:        "backend",
+		ArchivedAt:      archivedAt,
+		DetachedMembers: []string{"u1", "u2"},
+		FlaggedPRs:      []string{"pr-1", "pr-2"},
+		Reassignment:    report,
+	}, archive)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+	mockAssigner.AssertExpectations(t)
+}
+
+func TestTeamUseCase_ArchiveTeam_ReassignFails(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := new(PullRequestRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("Archive", ctx, mock.Anything, int64(1)).Return(time.Now(), nil)
+	mockPRRepo.On("FlagTeamArchived", ctx, mock.Anything, int64(1)).Return([]string{}, nil)
+	mockPRRepo.On("FlagTeamReviewsArchived", ctx, mock.Anything, int64(1)).Return(nil)
+	mockUserRepo.On("DetachTeamMembers", ctx, mock.Anything, int64(1)).Return([]string{"u1"}, nil)
+	mockAssigner := noTopUps()
+	mockAssigner.On("reassignLeavingReviewsTx", ctx, mock.Anything, []string{"u1"}, domain.UnassignLeftTeam).
+		Return(nil, errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, mockPRRepo, mockAssigner, db, zap.NewNop())
+	archive, err := uc.ArchiveTeam(ctx, "backend")
+
+	// Nothing is archived when the reviews can't be handed over
+	assert.Error(t, err)
+	assert.Nil(t, archive)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestTeamUseCase_ArchiveTeam_AlreadyArchived(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	archivedAt := time.Now()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").
+		Return(&domain.Team{ID: 1, Name: "backend", ArchivedAt: &archivedAt}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	archive, err := uc.ArchiveTeam(ctx, "backend")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, archive)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNotCalled(t, "Archive", mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "DetachTeamMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_DeleteTeam_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockPRRepo := new(PullRequestRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	archivedAt := time.Now()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").
+		Return(&domain.Team{ID: 1, Name: "backend", ArchivedAt: &archivedAt}, nil)
+	mockPRRepo.On("CountOpenReviewsByTeam", ctx, mock.Anything, int64(1)).Return(0, nil)
+	mockTeamRepo.On("Delete", ctx, mock.Anything, int64(1)).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, new(UserRepoMock), mockPRRepo, noTopUps(), db, zap.NewNop())
+	err = uc.DeleteTeam(ctx, "backend")
+
+	require.NoError(t, err)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_DeleteTeam_InUse(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockPRRepo := new(PullRequestRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockPRRepo.On("CountOpenReviewsByTeam", ctx, mock.Anything, int64(1)).Return(2, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, new(UserRepoMock), mockPRRepo, noTopUps(), db, zap.NewNop())
+	err = uc.DeleteTeam(ctx, "backend")
+
+	assert.ErrorIs(t, err, domain.ErrTeamInUse)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+// AddTeamMembers adds members to an existing team. Users that don't exist yet are created,
+// existing users of the team and users removed from their team have their data updated as on CreateTeam.
+// Users of other teams are not taken over: they have to be moved (see MoveTeamMember).
+// Missing reviewer places of open PRs of the team are filled after the change is committed.
+//
+// Returns:
+//   - *domain.Team: team object with all its members
+//   - error: domain.ErrNotFound if team doesn't exist, domain.ErrMemberOfOtherTeam
+//     if any of the users belongs to another team, or any database error
+func (u *TeamUseCase) AddTeamMembers(ctx context.Context, teamName string, members []domain.User) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		user, err := u.userRepo.GetByID(ctx, member.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user.TeamID != 0 && user.TeamID != team.ID {
+			return nil, fmt.Errorf("%w: %s", domain.ErrMemberOfOtherTeam, member.ID)
+		}
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.addTeamMembers(ctx, tx, &domain.Team{ID: team.ID, Members: members})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// The new members can take reviews and bring their open PRs (see CreateTeam)
+	topUpAfterCommit(ctx, u.assigner, u.logger, team.ID)
+
+	return u.teamRepo.GetByName(ctx, teamName)
+}
+
+// RemoveTeamMember removes the user from the team. The user is kept, without a team: they can't author PRs
+// and are not selected as a reviewer until added to a team again.
+// If reassignReviews is set, every OPEN PR the user reviews is reassigned in the same transaction,
+// following the PRUseCase.ReassignReviewer rules; otherwise the user keeps their reviews.
+// PRs without a suitable replacement keep the user as their reviewer.
+//
+// Returns:
+//   - *domain.Team: team object with the remaining members
+//   - *domain.ReassignmentReport: moved reviews and PRs that could not be reassigned,
+//     nil if no reassignment was requested
+//   - error: domain.ErrNotFound if team or user doesn't exist, domain.ErrNotTeamMember
+//     if the user is not a member of the team, or any database error
+func (u *TeamUseCase) RemoveTeamMember(ctx context.Context, teamName, userID string, reassignReviews bool) (*domain.Team, *domain.ReassignmentReport, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.TeamID != team.ID {
+		return nil, nil, fmt.Errorf("%w: %s is not in %s", domain.ErrNotTeamMember, userID, teamName)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	user.TeamID = 0
+	report, err := u.leaveTeamTx(ctx, tx, user, reassignReviews)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	team, err = u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return team, report, nil
+}
+
+// MoveTeamMember moves the user to another team. Open reviews of the user are handled
+// as on RemoveTeamMember: with reassignReviews they are handed over to reviewers of the PR author's team,
+// so reviews of PRs of the old team stay in the old team. This operation is idempotent - if the user
+// is already in the team, it returns the user without modifications.
+// Missing reviewer places of open PRs of the new team, including PRs authored by the user,
+// are filled after the change is committed.
+//
+// Returns:
+//   - *domain.User: moved user with the new team
+//   - *domain.ReassignmentReport: moved reviews and PRs that could not be reassigned,
+//     nil if no reassignment was requested or the user is already in the team
+//   - error: domain.ErrNotFound if team or user doesn't exist, or any database error
+func (u *TeamUseCase) MoveTeamMember(ctx context.Context, userID, teamName string, reassignReviews bool) (*domain.User, *domain.ReassignmentReport, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.TeamID == team.ID {
+		user.TeamName = team.Name
+		return user, nil, nil
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	user.TeamID = team.ID
+	report, err := u.leaveTeamTx(ctx, tx, user, reassignReviews)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	user.TeamName = team.Name
+
+	// The moved member can take reviews of the new team and brings their open PRs into it
+	topUpAfterCommit(ctx, u.assigner, u.logger, team.ID)
+
+	return user, report, nil
+}
+
+// leaveTeamTx saves the user with their new team and, if requested, hands over their open reviews.
+func (u *TeamUseCase) leaveTeamTx(ctx context.Context, tx *sql.Tx, user *domain.User, reassignReviews bool) (*domain.ReassignmentReport, error) {
+	err := u.userRepo.Update(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reassignReviews {
+		return nil, nil
+	}
+
+	return u.assigner.reassignOpenReviewsTx(ctx, tx, user.ID, domain.UnassignLeftTeam)
+}
+
+// SyncTeam reconciles the team with the full desired roster in a single transaction:
+// users that don't exist yet are created, members whose data differs from the roster are updated,
+// users without a team join the team, and members missing from the roster are detached from it
+// as on RemoveTeamMember. As on AddTeamMembers, users of other teams are not taken over: they have
+// to be moved (see MoveTeamMember), so their open reviews are handled. Open reviews of detached and deactivated members
+// are kept unless reassignReviews is set: then they are handed over together in the same transaction,
+// none of them taking another's review, and the report is added to the diff.
+// Review capacity and tags missing from the roster keep their stored values (see domain.RosterMember).
+// The team is locked before the roster is compared with its members, so concurrent changes of the team wait for the sync.
+// A missing team is created with default settings. Syncing the same roster again changes nothing.
+// Missing reviewer places of open PRs of the team are filled after the change is committed.
+//
+// Returns:
+//   - *domain.Team: team object with all its members
+//   - *domain.RosterDiff: changes made by the sync
+//   - error: domain.ErrInvalidRoster if a user is listed twice, domain.ErrMemberOfOtherTeam
+//     if any of the users belongs to another team, domain.ErrTeamExists if the name is taken
+//     by an archived team, or any database error
+func (u *TeamUseCase) SyncTeam(ctx context.Context, teamName string, members []domain.RosterMember, reassignReviews bool) (*domain.Team, *domain.RosterDiff, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	plan, err := u.planRoster(ctx, tx, teamName, members)
+	if err != nil {
+		return nil, nil, err
+	}
+	team, diff := plan.team, plan.diff
+
+	if diff.TeamCreated {
+		// Created team ID is assigned to team.ID inside
+		err = u.teamRepo.Create(ctx, tx, team)
+		if err != nil {
+			return nil, nil, err // err can be domain.ErrTeamExists if the team was created concurrently
+		}
+	}
+
+	for _, user := range plan.users {
+		user.TeamID = team.ID
+	}
+
+	for _, userID := range diff.Created {
+		err = u.userRepo.Create(ctx, tx, plan.users[userID])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, userID := range slices.Concat(diff.Joined, diff.Updated) {
+		err = u.userRepo.Update(ctx, tx, plan.users[userID])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, existing := range team.Members {
+		if !plan.listed(existing.ID) {
+			existing.TeamID = 0
+			err = u.userRepo.Update(ctx, tx, &existing)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if reassignReviews {
+		// Removed and deactivated members leave together, so none of them replaces another
+		leaving := slices.Concat(diff.Removed, plan.deactivated)
+		diff.Reassignment, err = u.assigner.reassignLeavingReviewsTx(ctx, tx, leaving, domain.UnassignManual)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	if diff.HasChanges() {
+		// New and returning members can take reviews of the team and bring their open PRs (see CreateTeam)
+		topUpAfterCommit(ctx, u.assigner, u.logger, team.ID)
+	}
+
+	team, err = u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return team, diff, nil
+}
+
+// PlanTeamSync computes the changes SyncTeam would make with the roster, without applying them.
+// The team is locked while planning, as on SyncTeam, and nothing is written.
+//
+// Returns:
+//   - *domain.RosterDiff: changes the sync would make
+//   - error: domain.ErrInvalidRoster if a user is listed twice, domain.ErrMemberOfOtherTeam
+//     if any of the users belongs to another team, domain.ErrTeamExists if the name is taken
+//     by an archived team, or any database error
+func (u *TeamUseCase) PlanTeamSync(ctx context.Context, teamName string, members []domain.RosterMember) (*domain.RosterDiff, error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	plan, err := u.planRoster(ctx, tx, teamName, members)
+	if err != nil {
+		return nil, err
+	}
+	return plan.diff, nil
+}
+
+// rosterPlan is the outcome of comparing a roster with the current team
+type rosterPlan struct {
+	team        *domain.Team // team with its current members, or a new team with default settings
+	diff        *domain.RosterDiff
+	users       map[string]*domain.User // users to create or update, with the roster applied to their stored data
+	names       map[string]struct{}     // IDs of all listed users
+	deactivated []string                // active members the roster deactivates
+}
+
+// listed reports whether the user is listed in the roster.
+func (p *rosterPlan) listed(userID string) bool {
+	_, ok := p.names[userID]
+	return ok
+}
+
+// planRoster locks the team within the transaction and compares the roster with its current members.
+func (u *TeamUseCase) planRoster(ctx context.Context, tx *sql.Tx, teamName string, members []domain.RosterMember) (*rosterPlan, error) {
+	plan := &rosterPlan{
+		diff:  &domain.RosterDiff{},
+		users: make(map[string]*domain.User),
+		names: make(map[string]struct{}, len(members)),
+	}
+	for _, member := range members {
+		if plan.listed(member.ID) {
+			return nil, fmt.Errorf("%w: %s is listed twice", domain.ErrInvalidRoster, member.ID)
+		}
+		plan.names[member.ID] = struct{}{}
+	}
+
+	// Lock the team: users can't join it until the commit, as the foreign key check waits for the lock
+	team, err := u.teamRepo.GetByNameForUpdate(ctx, tx, teamName)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		team = &domain.Team{
+			Name:               teamName,
+			AssignmentStrategy: domain.StrategyRandom,
+			MaxReviewers:       domain.DefaultMaxReviewers,
+		}
+		plan.diff.TeamCreated = true
+	case err != nil:
+		return nil, err
+	case team.ArchivedAt != nil:
+		return nil, fmt.Errorf("%w: team %s is archived", domain.ErrTeamExists, teamName)
+	default:
+		// Members are read once the team is locked
+		current, err := u.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		team = current
+	}
+	plan.team = team
+
+	current := make(map[string]*domain.User, len(team.Members))
+	for i := range team.Members {
+		current[team.Members[i].ID] = &team.Members[i]
+	}
+
+	for _, member := range members {
+		if existing, ok := current[member.ID]; ok {
+			user := member.Apply(*existing)
+			if memberChanged(existing, &user) {
+				plan.users[member.ID] = &user
+				plan.diff.Updated = append(plan.diff.Updated, member.ID)
+			}
+			if existing.IsActive && !user.IsActive {
+				plan.deactivated = append(plan.deactivated, member.ID)
+			}
+			continue
+		}
+
+		stored, err := u.userRepo.GetByID(ctx, member.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			user := member.Apply(domain.User{})
+			plan.users[member.ID] = &user
+			plan.diff.Created = append(plan.diff.Created, member.ID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if stored.TeamID != 0 {
+			return nil, fmt.Errorf("%w: %s", domain.ErrMemberOfOtherTeam, member.ID)
+		}
+		user := member.Apply(*stored)
+		plan.users[member.ID] = &user
+		plan.diff.Joined = append(plan.diff.Joined, member.ID)
+	}
+
+	for _, existing := range team.Members {
+		if !plan.listed(existing.ID) {
+			plan.diff.Removed = append(plan.diff.Removed, existing.ID)
+		}
+	}
+
+	return plan, nil
+}
+
+// memberChanged reports whether the roster applied to the stored member changed it.
+func memberChanged(existing, updated *domain.User) bool {
+	return existing.Name != updated.Name ||
+		existing.IsActive != updated.IsActive ||
+		existing.MaxOpenReviews != updated.MaxOpenReviews ||
+		!slices.Equal(existing.Tags, updated.Tags)
+}
@@ -0,0 +1,624 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+)
+
+func TestTeamUseCase_AddTeamMembers_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend"}
+	members := []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true},
+		{ID: "u2", Name: "Bob", IsActive: true},
+	}
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil).Once()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(nil, domain.ErrNotFound)
+	// u2 was removed from their team before
+	mockUserRepo.On("GetByID", ctx, "u2").Return(&domain.User{ID: "u2"}, nil)
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1" && u.TeamID == 1
+	})).Return(nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u2" && u.TeamID == 1
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(1)).Return(nil, nil)
+	updated := &domain.Team{ID: 1, Name: "backend", Members: members}
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(updated, nil).Once()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	result, err := uc.AddTeamMembers(ctx, "backend", members)
+
+	require.NoError(t, err)
+	assert.Equal(t, updated, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockAssigner.AssertExpectations(t)
+}
+
+func TestTeamUseCase_AddTeamMembers_TopUpErrorLogged(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend"}
+	members := []domain.User{{ID: "u1", Name: "Alice", IsActive: true}}
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(nil, domain.ErrNotFound)
+	dbMock.ExpectBegin()
+	mockUserRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	dbMock.ExpectCommit()
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(1)).Return(nil, errors.New("db error"))
+
+	core, logs := observer.New(zap.InfoLevel)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.New(core))
+	_, err = uc.AddTeamMembers(ctx, "backend", members)
+
+	// the members are added anyway, and the failed top-up is logged
+	require.NoError(t, err)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	failures := logs.FilterMessage("failed to assign missing reviewers").All()
+	require.Len(t, failures, 1)
+	assert.Equal(t, int64(1), failures[0].ContextMap()["team_id"])
+}
+
+func TestTeamUseCase_AddTeamMembers_MemberOfOtherTeam(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "missing").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 2}, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), nil, zap.NewNop())
+
+	result, err := uc.AddTeamMembers(ctx, "backend", []domain.User{{ID: "u1", Name: "Alice"}})
+	assert.ErrorIs(t, err, domain.ErrMemberOfOtherTeam)
+	assert.Nil(t, result)
+
+	result, err = uc.AddTeamMembers(ctx, "missing", []domain.User{{ID: "u1", Name: "Alice"}})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_RemoveTeamMember_KeepReviews(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend"}
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	dbMock.ExpectBegin()
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1" && u.TeamID == 0
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	result, report, err := uc.RemoveTeamMember(ctx, "backend", "u1", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, team, result)
+	assert.Nil(t, report)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockAssigner.AssertNotCalled(t, "reassignOpenReviewsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_RemoveTeamMember_ReassignReviews(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	report := &domain.ReassignmentReport{
+		Reassigned: []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"}},
+	}
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	dbMock.ExpectBegin()
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockAssigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u1", domain.UnassignLeftTeam).Return(report, nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	_, result, err := uc.RemoveTeamMember(ctx, "backend", "u1", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, report, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockAssigner.AssertExpectations(t)
+}
+
+func TestTeamUseCase_RemoveTeamMember_NotMember(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 2}, nil)
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), nil, zap.NewNop())
+
+	team, report, err := uc.RemoveTeamMember(ctx, "backend", "u1", true)
+	assert.ErrorIs(t, err, domain.ErrNotTeamMember)
+	assert.Nil(t, team)
+	assert.Nil(t, report)
+
+	_, _, err = uc.RemoveTeamMember(ctx, "backend", "ghost", true)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_MoveTeamMember_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	report := &domain.ReassignmentReport{
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-1", Reason: domain.ErrAllAtCapacity.Error()}},
+	}
+
+	mockTeamRepo.On("GetByName", ctx, "frontend").Return(&domain.Team{ID: 2, Name: "frontend"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1, TeamName: "backend"}, nil)
+	dbMock.ExpectBegin()
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1" && u.TeamID == 2
+	})).Return(nil)
+	mockAssigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u1", domain.UnassignLeftTeam).Return(report, nil)
+	dbMock.ExpectCommit()
+	// open PRs of the user now get reviewers from the new team
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(2)).Return(nil, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	user, result, err := uc.MoveTeamMember(ctx, "u1", "frontend", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), user.TeamID)
+	assert.Equal(t, "frontend", user.TeamName)
+	assert.Equal(t, report, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockAssigner.AssertExpectations(t)
+}
+
+func TestTeamUseCase_MoveTeamMember_AlreadyInTeam(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "missing").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, nil, zap.NewNop())
+
+	user, report, err := uc.MoveTeamMember(ctx, "u1", "backend", true)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.TeamName)
+	assert.Nil(t, report)
+
+	user, _, err = uc.MoveTeamMember(ctx, "u1", "missing", true)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, user)
+
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockAssigner.AssertNotCalled(t, "reassignOpenReviewsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_SyncTeam_Reconcile(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1, Tags: []string{"go"}},
+		{ID: "u2", Name: "Bob", IsActive: true, TeamID: 1},
+		{ID: "u3", Name: "Carol", IsActive: true, TeamID: 1},
+	}}
+	roster := []domain.RosterMember{
+		// unchanged, tags are compared normalized
+		{ID: "u1", Name: "Alice", IsActive: true, Tags: []string{" Go "}},
+		// deactivated
+		{ID: "u2", Name: "Bob", IsActive: false},
+		// joins without a team
+		{ID: "u4", Name: "Dave", IsActive: true},
+		// new user
+		{ID: "u5", Name: "Eve", IsActive: true},
+	}
+
+	// the roster is compared with the team once it is locked
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil).Once()
+	mockUserRepo.On("GetByID", ctx, "u4").Return(&domain.User{ID: "u4"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u5").Return(nil, domain.ErrNotFound)
+
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u2" && !u.IsActive && u.TeamID == 1
+	})).Return(nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u4" && u.TeamID == 1
+	})).Return(nil)
+	mockUserRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u5" && u.TeamID == 1
+	})).Return(nil)
+	// u3 is missing from the roster
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u3" && u.TeamID == 0
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(1)).Return(nil, nil)
+	synced := &domain.Team{ID: 1, Name: "backend"}
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(synced, nil).Once()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	result, diff, err := uc.SyncTeam(ctx, "backend", roster, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, synced, result)
+	assert.Equal(t, &domain.RosterDiff{
+		Created: []string{"u5"},
+		Joined:  []string{"u4"},
+		Updated: []string{"u2"},
+		Removed: []string{"u3"},
+	}, diff)
+
+	// open reviews of u2 and u3 are kept
+	assert.Nil(t, diff.Reassignment)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockAssigner.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1"
+	}))
+	mockAssigner.AssertNotCalled(t, "reassignLeavingReviewsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_SyncTeam_ReassignReviews(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1},
+		{ID: "u2", Name: "Bob", IsActive: true, TeamID: 1},
+		{ID: "u3", Name: "Carol", IsActive: true, TeamID: 1},
+		{ID: "u4", Name: "Dave", IsActive: false, TeamID: 1},
+	}}
+	// u2 is deactivated, u3 is removed, u4 stays inactive
+	roster := []domain.RosterMember{
+		{ID: "u1", Name: "Alice", IsActive: true},
+		{ID: "u2", Name: "Bob", IsActive: false},
+		{ID: "u4", Name: "Dave Smith", IsActive: false},
+	}
+	report := &domain.ReassignmentReport{
+		Reassigned: []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1"}},
+	}
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	// the leaving members are handed over together, after they are saved
+	mockAssigner.On("reassignLeavingReviewsTx", ctx, mock.AnythingOfType("*sql.Tx"), []string{"u3", "u2"}, domain.UnassignManual).
+		Return(report, nil)
+	dbMock.ExpectCommit()
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(1)).Return(nil, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	_, diff, err := uc.SyncTeam(ctx, "backend", roster, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u4"}, diff.Updated)
+	assert.Equal(t, []string{"u3"}, diff.Removed)
+	assert.Equal(t, report, diff.Reassignment)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockAssigner.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SyncTeam_KeepsCapacityAndTags(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1, MaxOpenReviews: 3, Tags: []string{"go"}},
+		{ID: "u2", Name: "Bob", IsActive: true, TeamID: 1, MaxOpenReviews: 2, Tags: []string{"sql"}},
+		{ID: "u3", Name: "Carol", IsActive: true, TeamID: 1, Tags: []string{"frontend"}},
+	}}
+	// the roster has neither review capacity nor tags, except for clearing the tags of Carol
+	roster := []domain.RosterMember{
+		{ID: "u1", Name: "Alice", IsActive: true},
+		{ID: "u2", Name: "Bob Smith", IsActive: true},
+		{ID: "u3", Name: "Carol", IsActive: true, Tags: []string{}},
+		{ID: "u4", Name: "Dave", IsActive: true},
+	}
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockUserRepo.On("GetByID", ctx, "u4").Return(&domain.User{ID: "u4", Name: "Dave", MaxOpenReviews: 5, Tags: []string{"go"}}, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, &domain.User{
+		ID: "u2", Name: "Bob Smith", IsActive: true, TeamID: 1, MaxOpenReviews: 2, Tags: []string{"sql"},
+	}).Return(nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, &domain.User{
+		ID: "u3", Name: "Carol", IsActive: true, TeamID: 1, Tags: []string{},
+	}).Return(nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, &domain.User{
+		ID: "u4", Name: "Dave", IsActive: true, TeamID: 1, MaxOpenReviews: 5, Tags: []string{"go"},
+	}).Return(nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	_, diff, err := uc.SyncTeam(ctx, "backend", roster, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, diff.Updated)
+	assert.Equal(t, []string{"u4"}, diff.Joined)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+	mockUserRepo.AssertNumberOfCalls(t, "Update", 3)
+}
+
+func TestTeamUseCase_SyncTeam_CreatesTeam(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "docs").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(nil, domain.ErrNotFound)
+	mockTeamRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.Name == "docs" && t.AssignmentStrategy == domain.StrategyRandom && t.MaxReviewers == domain.DefaultMaxReviewers
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.Team).ID = 4
+	}).Return(nil)
+	mockUserRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1" && u.TeamID == 4
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 4, Name: "docs"}, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, diff, err := uc.SyncTeam(ctx, "docs", []domain.RosterMember{{ID: "u1", Name: "Alice", IsActive: true}}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.ID)
+	assert.True(t, diff.TeamCreated)
+	assert.Equal(t, []string{"u1"}, diff.Created)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SyncTeam_NoChanges(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1},
+	}}
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockAssigner, db, zap.NewNop())
+	_, diff, err := uc.SyncTeam(ctx, "backend", []domain.RosterMember{{ID: "u1", Name: "Alice", IsActive: true}}, false)
+
+	require.NoError(t, err)
+	assert.False(t, diff.HasChanges())
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockAssigner.AssertNotCalled(t, "TopUpTeamReviewers", mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_SyncTeam_DuplicateMember(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, new(UserRepoMock), new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	team, diff, err := uc.SyncTeam(context.Background(), "backend", []domain.RosterMember{
+		{ID: "u1", Name: "Alice"},
+		{ID: "u1", Name: "Alice Smith"},
+	}, false)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRoster)
+	assert.Nil(t, team)
+	assert.Nil(t, diff)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNotCalled(t, "GetByNameForUpdate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_SyncTeam_MemberOfOtherTeam(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("GetByID", ctx, "u3").Return(&domain.User{ID: "u3", TeamID: 2}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	team, diff, err := uc.SyncTeam(ctx, "backend", []domain.RosterMember{
+		{ID: "u1", Name: "Alice", IsActive: true},
+		{ID: "u3", Name: "Carol", IsActive: true},
+	}, false)
+
+	// nothing is applied, the user has to be moved with their reviews
+	assert.ErrorIs(t, err, domain.ErrMemberOfOtherTeam)
+	assert.Nil(t, team)
+	assert.Nil(t, diff)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_SyncTeam_ArchivedTeam(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	ctx := context.Background()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	archivedAt := time.Now()
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend", ArchivedAt: &archivedAt}, nil)
+	dbMock.ExpectRollback()
+
+	uc := NewTeamUseCase(mockTeamRepo, new(UserRepoMock), new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	team, diff, err := uc.SyncTeam(ctx, "backend", []domain.RosterMember{{ID: "u1", Name: "Alice", IsActive: true}}, false)
+
+	// the name stays taken by the archived team
+	assert.ErrorIs(t, err, domain.ErrTeamExists)
+	assert.Nil(t, team)
+	assert.Nil(t, diff)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_PlanTeamSync(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	ctx := context.Background()
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the plan is made with the team locked, and nothing is written
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByNameForUpdate", ctx, mock.Anything, "backend").Return(&domain.Team{ID: 1, Name: "backend"}, nil)
+	dbMock.ExpectRollback()
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 1, Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1},
+		{ID: "u2", Name: "Bob", IsActive: true, TeamID: 1},
+	}}, nil)
+	mockUserRepo.On("GetByID", ctx, "u3").Return(nil, domain.ErrNotFound)
+
+	roster := []domain.RosterMember{
+		{ID: "u1", Name: "Alice", IsActive: false, Tags: []string{"Go"}},
+		{ID: "u3", Name: "Carol", IsActive: true},
+	}
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	diff, err := uc.PlanTeamSync(ctx, "backend", roster)
+
+	require.NoError(t, err)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	assert.Equal(t, &domain.RosterDiff{
+		Created: []string{"u3"},
+		Updated: []string{"u1"},
+		Removed: []string{"u2"},
+	}, diff)
+	// the roster of the caller is left as is
+	assert.Equal(t, []string{"Go"}, roster[0].Tags)
+
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -4,35 +4,74 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/repository"
+	"go.uber.org/zap"
 )
 
 type TeamUseCase struct {
 	teamRepo repository.TeamRepository
 	userRepo repository.UserRepository
+	prRepo   repository.PullRequestRepository
+	assigner reviewAssigner
 	db       *sql.DB
+	logger   *zap.Logger
 }
 
 func NewTeamUseCase(
 	teamRepo repository.TeamRepository,
 	userRepo repository.UserRepository,
-	db *sql.DB) *TeamUseCase {
+	prRepo repository.PullRequestRepository,
+	assigner reviewAssigner,
+	db *sql.DB,
+	logger *zap.Logger) *TeamUseCase {
 	return &TeamUseCase{
 		teamRepo: teamRepo,
 		userRepo: userRepo,
+		prRepo:   prRepo,
+		assigner: assigner,
 		db:       db,
+		logger:   logger,
 	}
 }
 
 // CreateTeam creates a new team with the specified name and members.
 // For each member: if user exists, updates their data; if user doesn't exist, creates a new user.
+// If no assignment strategy is specified, the team uses domain.StrategyRandom.
+// If no maximum reviewers amount is specified, the team uses domain.DefaultMaxReviewers.
+// Members that already authored open PRs bring them into the team, so missing reviewer places
+// of those PRs are filled after the team is committed (see PRUseCase.TopUpTeamReviewers).
 //
 // Returns:
 //   - *domain.Team: created team with assigned ID and list of members
-//   - error: domain.ErrTeamExists if team name already exists, or any database error
+//   - error: domain.ErrTeamExists if team name already exists, domain.ErrInvalidStrategy if the assignment
+//     strategy is unknown, domain.ErrInvalidReviewersPolicy if min reviewers exceeds max reviewers, domain.ErrInvalidMergePolicy if required approvals
+//     exceed max reviewers, domain.ErrInvalidReviewSLA if reviews would be reassigned before they are overdue,
+//     or any database error
 func (u *TeamUseCase) CreateTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	// Teams select reviewers randomly unless told otherwise
+	if team.AssignmentStrategy == "" {
+		team.AssignmentStrategy = domain.StrategyRandom
+	}
+	if !team.AssignmentStrategy.IsValid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidStrategy, team.AssignmentStrategy)
+	}
+	if team.MaxReviewers == 0 {
+		team.MaxReviewers = max(domain.DefaultMaxReviewers, team.MinReviewers)
+	}
+	if !team.HasValidReviewersPolicy() {
+		return nil, domain.ErrInvalidReviewersPolicy
+	}
+	if !team.HasValidMergePolicy() {
+		return nil, domain.ErrInvalidMergePolicy
+	}
+	if !team.HasValidReviewSLA() {
+		return nil, domain.ErrInvalidReviewSLA
+	}
+
 	// Start transaction
 	tx, err := u.db.Begin()
 	if err != nil {
@@ -57,6 +96,9 @@ func (u *TeamUseCase) CreateTeam(ctx context.Context, team domain.Team) (*domain
 		return nil, err
 	}
 
+	// The members can take reviews of the team and bring their open PRs into it
+	topUpAfterCommit(ctx, u.assigner, u.logger, team.ID)
+
 	return &team, nil
 }
 
@@ -71,6 +113,7 @@ func (u *TeamUseCase) addTeamMembers(ctx context.Context, tx *sql.Tx, team *doma
 		member := &team.Members[i]
 		// Update teamID field for each user
 		member.TeamID = team.ID
+		member.Tags = domain.NormalizeTags(member.Tags)
 
 		// Try to get user if it exists
 		_, err := u.userRepo.GetByID(ctx, member.ID)
@@ -109,3 +152,206 @@ func (u *TeamUseCase) GetTeam(ctx context.Context, teamName string) (*domain.Tea
 
 	return team, nil
 }
+
+// SetAssignmentStrategy changes the strategy used to select reviewers for PRs authored by team members.
+// The new strategy applies to all subsequent assignments and reassignments.
+//
+// Returns:
+//   - *domain.Team: team object with members and the updated strategy
+//   - error: domain.ErrInvalidStrategy if the strategy is unknown, domain.ErrNotFound if team doesn't exist,
+//     or any database error
+func (u *TeamUseCase) SetAssignmentStrategy(ctx context.Context, teamName string, strategy domain.AssignmentStrategy) (*domain.Team, error) {
+	if !strategy.IsValid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidStrategy, strategy)
+	}
+
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	team.AssignmentStrategy = strategy
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.teamRepo.Update(ctx, tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// SetReviewersPolicy changes how many reviewers are assigned to PRs authored by team members.
+// PR creation fails if fewer than minReviewers can be assigned; at most maxReviewers are assigned.
+// Already created PRs are not changed.
+//
+// Returns:
+//   - *domain.Team: team object with members and the updated policy
+//   - error: domain.ErrNotFound if team doesn't exist, domain.ErrInvalidReviewersPolicy
+//     if bounds are inconsistent, domain.ErrInvalidMergePolicy if maxReviewers is below
+//     the approvals required by the merge policy, or any database error
+func (u *TeamUseCase) SetReviewersPolicy(ctx context.Context, teamName string, minReviewers, maxReviewers int) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	team.MinReviewers = minReviewers
+	team.MaxReviewers = maxReviewers
+	if !team.HasValidReviewersPolicy() {
+		return nil, domain.ErrInvalidReviewersPolicy
+	}
+	if !team.HasValidMergePolicy() {
+		return nil, fmt.Errorf("%w: %d approvals are required to merge", domain.ErrInvalidMergePolicy, team.MinApprovals)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.teamRepo.Update(ctx, tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// SetMergePolicy changes when PRs authored by team members can be merged: at least minApprovals
+// reviewers have to approve the PR and, unless allowChangesRequested is set, none of them may
+// request changes. Already merged PRs are not affected.
+//
+// Returns:
+//   - *domain.Team: team object with members and the updated policy
+//   - error: domain.ErrNotFound if team doesn't exist, domain.ErrInvalidMergePolicy
+//     if minApprovals is negative or exceeds max reviewers of the team, or any database error
+func (u *TeamUseCase) SetMergePolicy(ctx context.Context, teamName string, minApprovals int, allowChangesRequested bool) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	team.MinApprovals = minApprovals
+	team.AllowChangesRequested = allowChangesRequested
+	if !team.HasValidMergePolicy() {
+		return nil, fmt.Errorf("%w: at most %d reviewers can approve a PR", domain.ErrInvalidMergePolicy, team.MaxReviewers)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.teamRepo.Update(ctx, tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// SetReviewSLA changes the review SLA of PRs authored by team members: reviewers without a decision
+// are overdue after slaHours working hours, and overdue reviews are reassigned after reassignHours
+// working hours (see PRUseCase.CheckReviewSLA). Zero slaHours disables the SLA, zero reassignHours
+// disables reassignment. Reviews already marked as overdue stay marked.
+//
+// Returns:
+//   - *domain.Team: team object with members and the updated SLA
+//   - error: domain.ErrNotFound if team doesn't exist, domain.ErrInvalidReviewSLA if reassignHours
+//     is set but slaHours is not or exceeds it, or any database error
+func (u *TeamUseCase) SetReviewSLA(ctx context.Context, teamName string, slaHours, reassignHours int) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	team.ReviewSLAHours = slaHours
+	team.SLAReassignHours = reassignHours
+	if !team.HasValidReviewSLA() {
+		return nil, fmt.Errorf("%w: reviews can be reassigned only after they are overdue", domain.ErrInvalidReviewSLA)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.teamRepo.Update(ctx, tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// SetFallbackTeams replaces the list of teams that provide reviewers for PRs of team members
+// when the team itself lacks candidates. Fallback teams are used in the given order.
+// An empty list removes all fallback teams.
+//
+// Returns:
+//   - *domain.Team: team object with members and the updated fallback teams
+//   - error: domain.ErrNotFound if the team or any of the fallback teams doesn't exist,
+//     domain.ErrInvalidFallbackTeam if the list contains the team itself or duplicates, or any database error
+func (u *TeamUseCase) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeamNames []string) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackIDs := make([]int64, 0, len(fallbackTeamNames))
+	for i, name := range fallbackTeamNames {
+		if name == teamName || slices.Contains(fallbackTeamNames[:i], name) {
+			return nil, domain.ErrInvalidFallbackTeam
+		}
+
+		fallback, err := u.teamRepo.GetByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		fallbackIDs = append(fallbackIDs, fallback.ID)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.teamRepo.SetFallbackTeams(ctx, tx, team.ID, fallbackIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	team.FallbackTeams = fallbackTeamNames
+
+	return team, nil
+}
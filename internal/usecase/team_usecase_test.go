@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestTeamUseCase_CreateTeam_Success_NewUsers(t *testing.T) {
@@ -19,7 +20,9 @@ func TestTeamUseCase_CreateTeam_Success_NewUsers(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "backend",
+		Name:               "backend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "User", IsActive: true},
 			{ID: "u2", Name: "Admin", IsActive: true},
@@ -53,7 +56,7 @@ func TestTeamUseCase_CreateTeam_Success_NewUsers(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -76,7 +79,9 @@ func TestTeamUseCase_CreateTeam_Success_ExistingUsers(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "frontend",
+		Name:               "frontend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "User Updated", IsActive: false},
 		},
@@ -114,7 +119,7 @@ func TestTeamUseCase_CreateTeam_Success_ExistingUsers(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -136,8 +141,10 @@ func TestTeamUseCase_CreateTeam_TeamAlreadyExists(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name:    "backend",
-		Members: []domain.User{{ID: "u1", Name: "Admin", IsActive: true}},
+		Name:               "backend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
+		Members:            []domain.User{{ID: "u1", Name: "Admin", IsActive: true}},
 	}
 
 	// Expectations
@@ -152,7 +159,7 @@ func TestTeamUseCase_CreateTeam_TeamAlreadyExists(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -174,7 +181,9 @@ func TestTeamUseCase_CreateTeam_FailedToCreateUser(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "backend",
+		Name:               "backend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "Admin", IsActive: true},
 		},
@@ -200,7 +209,7 @@ func TestTeamUseCase_CreateTeam_FailedToCreateUser(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -221,7 +230,9 @@ func TestTeamUseCase_CreateTeam_FailedToUpdateUser(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "backend",
+		Name:               "backend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "Admin", IsActive: true},
 		},
@@ -253,7 +264,7 @@ func TestTeamUseCase_CreateTeam_FailedToUpdateUser(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -274,7 +285,9 @@ func TestTeamUseCase_CreateTeam_GetUserReturnsUnexpectedError(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "backend",
+		Name:               "backend",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "Admin", IsActive: true},
 		},
@@ -297,7 +310,7 @@ func TestTeamUseCase_CreateTeam_GetUserReturnsUnexpectedError(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -319,7 +332,9 @@ func TestTeamUseCase_CreateTeam_MultipleMembers_MixedScenario(t *testing.T) {
 
 	ctx := context.Background()
 	team := domain.Team{
-		Name: "fullstack",
+		Name:               "fullstack",
+		AssignmentStrategy: domain.StrategyRandom,
+		MaxReviewers:       domain.DefaultMaxReviewers,
 		Members: []domain.User{
 			{ID: "u1", Name: "Admin", IsActive: true},   // new
 			{ID: "u2", Name: "Junior", IsActive: false}, // existing
@@ -369,7 +384,7 @@ func TestTeamUseCase_CreateTeam_MultipleMembers_MixedScenario(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// Perform tests
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 
 	result, err := uc.CreateTeam(ctx, team)
 
@@ -410,7 +425,7 @@ func TestTeamUseCase_GetTeam_Success(t *testing.T) {
 	mockTeamRepo.On("GetByName", ctx, teamName).Return(expectedTeam, nil)
 
 	// Execute
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 	result, err := uc.GetTeam(ctx, teamName)
 
 	// Assert
@@ -433,7 +448,7 @@ func TestTeamUseCase_GetTeam_NotFound(t *testing.T) {
 	mockTeamRepo.On("GetByName", ctx, teamName).Return(nil, domain.ErrNotFound)
 
 	// Execute
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 	result, err := uc.GetTeam(ctx, teamName)
 
 	// Assert
@@ -458,7 +473,7 @@ func TestTeamUseCase_GetTeam_RepoError(t *testing.T) {
 	mockTeamRepo.On("GetByName", ctx, teamName).Return(nil, repoErr)
 
 	// Execute
-	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, db)
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
 	result, err := uc.GetTeam(ctx, teamName)
 
 	// Assert
@@ -467,3 +482,436 @@ func TestTeamUseCase_GetTeam_RepoError(t *testing.T) {
 	assert.Nil(t, result)
 	mockTeamRepo.AssertExpectations(t)
 }
+
+func TestTeamUseCase_CreateTeam_DefaultStrategy(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := domain.Team{
+		Name:    "no-strategy",
+		Members: []domain.User{{ID: "u1", Name: "Admin", IsActive: true}},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("Create", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.AssignmentStrategy == domain.StrategyRandom && t.MaxReviewers == domain.DefaultMaxReviewers
+	})).Return(nil)
+	mockUserRepo.On("GetByID", ctx, "u1").Return(nil, domain.ErrNotFound)
+	mockUserRepo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.CreateTeam(ctx, team)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StrategyRandom, result.AssignmentStrategy)
+	assert.Equal(t, domain.DefaultMinReviewers, result.MinReviewers)
+	assert.Equal(t, domain.DefaultMaxReviewers, result.MaxReviewers)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_CreateTeam_TopsUpReviewers(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+	mockTopUpper := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := domain.Team{
+		Name:    "movers",
+		Members: []domain.User{{ID: "u1", Name: "Alice", IsActive: true}},
+	}
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("Create", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.Team).ID = 3
+	}).Return(nil)
+	// u1 moves from another team together with his open PRs
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", TeamID: 1}, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	// failures are not reported - the team is already created
+	mockTopUpper.On("TopUpTeamReviewers", ctx, int64(3)).Return(nil, errors.New("db error"))
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), mockTopUpper, db, zap.NewNop())
+	result, err := uc.CreateTeam(ctx, team)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.ID)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTopUpper.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SetAssignmentStrategy_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "backend", AssignmentStrategy: domain.StrategyRandom}
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(team, nil)
+	mockTeamRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.ID == 1 && t.AssignmentStrategy == domain.StrategyRoundRobin
+	})).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetAssignmentStrategy(ctx, "backend", domain.StrategyRoundRobin)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StrategyRoundRobin, result.AssignmentStrategy)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SetAssignmentStrategy_TeamNotFound(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "missing").Return(nil, domain.ErrNotFound)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetAssignmentStrategy(ctx, "missing", domain.StrategyLeastLoaded)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "Update")
+}
+
+func TestTeamUseCase_SetAssignmentStrategy_Invalid(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	uc := NewTeamUseCase(mockTeamRepo, new(UserRepoMock), new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetAssignmentStrategy(context.Background(), "backend", domain.AssignmentStrategy("FASTEST"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStrategy)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
+	mockTeamRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTeamUseCase_CreateTeam_InvalidReviewersPolicy(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	team := domain.Team{
+		Name:         "strict",
+		MinReviewers: 3,
+		MaxReviewers: 1,
+		Members:      []domain.User{{ID: "u1", Name: "Admin", IsActive: true}},
+	}
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.CreateTeam(context.Background(), team)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewersPolicy)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "Create")
+}
+
+func TestTeamUseCase_CreateTeam_InvalidReviewSLA(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	team := domain.Team{
+		Name:             "hasty",
+		ReviewSLAHours:   24,
+		SLAReassignHours: 8,
+		Members:          []domain.User{{ID: "u1", Name: "Admin", IsActive: true}},
+	}
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.CreateTeam(context.Background(), team)
+
+	// reviews can't be reassigned before they become overdue
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewSLA)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "Create")
+}
+
+func TestTeamUseCase_SetReviewersPolicy_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "platform", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("GetByName", ctx, "platform").Return(team, nil)
+	mockTeamRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.ID == 1 && t.MinReviewers == 3 && t.MaxReviewers == 3
+	})).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetReviewersPolicy(ctx, "platform", 3, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.MinReviewers)
+	assert.Equal(t, 3, result.MaxReviewers)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SetReviewersPolicy_Invalid(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "docs", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(team, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetReviewersPolicy(ctx, "docs", 2, 1)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewersPolicy)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "Update")
+}
+
+func TestTeamUseCase_SetMergePolicy_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "platform", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("GetByName", ctx, "platform").Return(team, nil)
+	mockTeamRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.ID == 1 && t.MinApprovals == 2 && t.AllowChangesRequested && t.MaxReviewers == 2
+	})).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetMergePolicy(ctx, "platform", 2, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.MinApprovals)
+	assert.True(t, result.AllowChangesRequested)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SetMergePolicy_Invalid(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 1, Name: "docs", MaxReviewers: 2}, nil)
+	mockTeamRepo.On("GetByName", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), nil, zap.NewNop())
+
+	// More approvals than reviewers
+	result, err := uc.SetMergePolicy(ctx, "docs", 3, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidMergePolicy)
+	assert.Nil(t, result)
+
+	result, err = uc.SetMergePolicy(ctx, "ghost", 1, false)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+
+	mockTeamRepo.AssertNotCalled(t, "Update")
+}
+
+func TestTeamUseCase_SetReviewSLA(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "platform", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2}
+
+	dbMock.ExpectBegin()
+	mockTeamRepo.On("GetByName", ctx, "platform").Return(team, nil)
+	mockTeamRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(t *domain.Team) bool {
+		return t.ID == 1 && t.ReviewSLAHours == 24 && t.SLAReassignHours == 48
+	})).Return(nil)
+	dbMock.ExpectCommit()
+
+	mockTeamRepo.On("GetByName", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+
+	result, err := uc.SetReviewSLA(ctx, "platform", 24, 48)
+	require.NoError(t, err)
+	assert.Equal(t, 24, result.ReviewSLAHours)
+	assert.Equal(t, 48, result.SLAReassignHours)
+
+	// Reassignment before the review is overdue
+	result, err = uc.SetReviewSLA(ctx, "platform", 24, 8)
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewSLA)
+	assert.Nil(t, result)
+
+	// Reassignment without SLA
+	result, err = uc.SetReviewSLA(ctx, "platform", 0, 8)
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewSLA)
+	assert.Nil(t, result)
+
+	result, err = uc.SetReviewSLA(ctx, "ghost", 24, 0)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestTeamUseCase_SetReviewersPolicy_BelowRequiredApprovals(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	ctx := context.Background()
+	team := &domain.Team{ID: 1, Name: "docs", AssignmentStrategy: domain.StrategyRandom, MaxReviewers: 2, MinApprovals: 2}
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(team, nil)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), nil, zap.NewNop())
+	result, err := uc.SetReviewersPolicy(ctx, "docs", 0, 1)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMergePolicy)
+	assert.Nil(t, result)
+	mockTeamRepo.AssertNotCalled(t, "Update")
+}
+
+func TestTeamUseCase_SetFallbackTeams_Success(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 1, Name: "docs"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "frontend").Return(&domain.Team{ID: 3, Name: "frontend"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 2, Name: "backend"}, nil)
+	mockTeamRepo.On("SetFallbackTeams", ctx, mock.Anything, int64(1), []int64{3, 2}).Return(nil)
+
+	dbMock.ExpectCommit()
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetFallbackTeams(ctx, "docs", []string{"frontend", "backend"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "backend"}, result.FallbackTeams)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestTeamUseCase_SetFallbackTeams_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	for name, fallbacks := range map[string][]string{
+		"self":      {"docs"},
+		"duplicate": {"backend", "backend"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockTeamRepo := new(TeamRepoMock)
+			mockUserRepo := new(UserRepoMock)
+
+			db, _, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 1, Name: "docs"}, nil)
+			mockTeamRepo.On("GetByName", ctx, "backend").Return(&domain.Team{ID: 2, Name: "backend"}, nil)
+
+			uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+			result, err := uc.SetFallbackTeams(ctx, "docs", fallbacks)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidFallbackTeam)
+			assert.Nil(t, result)
+			mockTeamRepo.AssertNotCalled(t, "SetFallbackTeams", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTeamUseCase_SetFallbackTeams_FallbackNotFound(t *testing.T) {
+	mockTeamRepo := new(TeamRepoMock)
+	mockUserRepo := new(UserRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mockTeamRepo.On("GetByName", ctx, "docs").Return(&domain.Team{ID: 1, Name: "docs"}, nil)
+	mockTeamRepo.On("GetByName", ctx, "ghosts").Return(nil, domain.ErrNotFound)
+
+	uc := NewTeamUseCase(mockTeamRepo, mockUserRepo, new(PullRequestRepoMock), noTopUps(), db, zap.NewNop())
+	result, err := uc.SetFallbackTeams(ctx, "docs", []string{"ghosts"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+}
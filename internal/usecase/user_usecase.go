@@ -6,60 +6,211 @@ import (
 
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/repository"
+	"go.uber.org/zap"
 )
 
+// openReviewsReassigner hands over open reviews of a user within the caller's transaction,
+// implemented by PRUseCase
+type openReviewsReassigner interface {
+	reassignOpenReviewsTx(ctx context.Context, tx *sql.Tx, userID string, reason domain.UnassignReason) (*domain.ReassignmentReport, error)
+}
+
+// leavingReviewsReassigner hands over open reviews of users leaving together within the caller's transaction,
+// implemented by PRUseCase
+type leavingReviewsReassigner interface {
+	reassignLeavingReviewsTx(ctx context.Context, tx *sql.Tx, userIDs []string, reason domain.UnassignReason) (*domain.ReassignmentReport, error)
+}
+
+// reviewersTopUpper fills missing reviewer places of open PRs of a team, implemented by PRUseCase
+type reviewersTopUpper interface {
+	TopUpTeamReviewers(ctx context.Context, teamID int64) ([]domain.ReviewerTopUp, error)
+}
+
+// topUpAfterCommit fills missing reviewer places of open PRs of the team after a change that lets
+// its members take more reviews is committed: candidates are read outside of transactions.
+// Best effort: the change itself is already saved, so a failure is logged, and the gaps left
+// are filled by PRUseCase.TopUpReviewers.
+func topUpAfterCommit(ctx context.Context, topUpper reviewersTopUpper, logger *zap.Logger, teamID int64) {
+	topUps, err := topUpper.TopUpTeamReviewers(ctx, teamID)
+	if len(topUps) > 0 {
+		prIDs := make([]string, len(topUps))
+		for i, topUp := range topUps {
+			prIDs[i] = topUp.PRID
+		}
+		logger.Info("missing reviewers assigned",
+			zap.Int64("team_id", teamID),
+			zap.Strings("pr_ids", prIDs))
+	}
+	if err != nil {
+		logger.Error("failed to assign missing reviewers",
+			zap.Int64("team_id", teamID),
+			zap.Error(err))
+	}
+}
+
+// reviewAssigner changes reviewers of open PRs when users come and go, implemented by PRUseCase
+type reviewAssigner interface {
+	openReviewsReassigner
+	leavingReviewsReassigner
+	reviewersTopUpper
+}
+
 type UserUseCase struct {
 	userRepo repository.UserRepository
 	prRepo   repository.PullRequestRepository
 	teamRepo repository.TeamRepository
+	assigner reviewAssigner
 	db       *sql.DB
+	logger   *zap.Logger
 }
 
 func NewUserUseCase(
 	userRepo repository.UserRepository,
 	prRepo repository.PullRequestRepository,
 	teamRepo repository.TeamRepository,
-	db *sql.DB) *UserUseCase {
+	assigner reviewAssigner,
+	db *sql.DB,
+	logger *zap.Logger) *UserUseCase {
 	return &UserUseCase{
 		userRepo: userRepo,
 		prRepo:   prRepo,
 		teamRepo: teamRepo,
+		assigner: assigner,
 		db:       db,
+		logger:   logger,
 	}
 }
 
 // SetUserIsActive updates the isActive flag for the specified user.
+// If the user is deactivated and reassignReviews is set, every OPEN PR the user reviews is
+// reassigned in the same transaction, following the PRUseCase.ReassignReviewer rules.
+// PRs without a suitable replacement keep the user as their reviewer.
+// If an inactive user is activated, missing reviewer places of open PRs of the user's team are filled
+// after the change is committed (see PRUseCase.TopUpTeamReviewers).
 //
 // Returns:
 //   - *domain.User: updated user with the new isActive value
+//   - *domain.ReassignmentReport: moved reviews and PRs that could not be reassigned,
+//     nil if no reassignment was requested
 //   - error: domain.ErrNotFound if user doesn't exist, or any database error
-func (u *UserUseCase) SetUserIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+func (u *UserUseCase) SetUserIsActive(ctx context.Context, userID string, isActive, reassignReviews bool) (*domain.User, *domain.ReassignmentReport, error) {
 	// Try to get user by ID
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If exists - update isActive field in the domain and call repo method
+	wasActive := user.IsActive
 	user.IsActive = isActive
 	tx, err := u.db.Begin()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback() //nolint:errcheck
 
 	// Update user in database
 	err = u.userRepo.Update(ctx, tx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Hand over open reviews of the deactivated user
+	var report *domain.ReassignmentReport
+	if !isActive && reassignReviews {
+		report, err = u.assigner.reassignOpenReviewsTx(ctx, tx, userID, domain.UnassignDeactivated)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	teamName, err := u.teamNameOf(ctx, user.TeamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Commit
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	user.TeamName = teamName
+
+	// The user can take reviews again
+	if isActive && !wasActive && user.TeamID != 0 {
+		topUpAfterCommit(ctx, u.assigner, u.logger, user.TeamID)
+	}
+
+	return user, report, nil
+}
+
+// SetMaxOpenReviews updates the review capacity of the specified user.
+// 0 removes the limit.
+//
+// Returns:
+//   - *domain.User: updated user with the new capacity
+//   - error: domain.ErrNotFound if user doesn't exist, or any database error
+func (u *UserUseCase) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (*domain.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	teamName, err := u.teamRepo.GetTeamNameByID(ctx, user.TeamID)
+	user.MaxOpenReviews = maxOpenReviews
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.userRepo.Update(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := u.teamNameOf(ctx, user.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	user.TeamName = teamName
+
+	return user, nil
+}
+
+// SetTags replaces skill tags of the specified user. Tags are lowercased, empty ones and
+// duplicates are dropped.
+//
+// Returns:
+//   - *domain.User: updated user with the new tags
+//   - error: domain.ErrNotFound if user doesn't exist, or any database error
+func (u *UserUseCase) SetTags(ctx context.Context, userID string, tags []string) (*domain.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Tags = domain.NormalizeTags(tags)
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = u.userRepo.Update(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := u.teamNameOf(ctx, user.TeamID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Commit
 	if err = tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -69,17 +220,38 @@ func (u *UserUseCase) SetUserIsActive(ctx context.Context, userID string, isActi
 	return user, nil
 }
 
+// teamNameOf returns the name of the team with the given ID, empty for users removed from their team.
+func (u *UserUseCase) teamNameOf(ctx context.Context, teamID int64) (string, error) {
+	if teamID == 0 {
+		return "", nil
+	}
+	return u.teamRepo.GetTeamNameByID(ctx, teamID)
+}
+
 // GetAssignedPRs gets all pull requests where the given user is assigned as a reviewer.
-// Returns both OPEN and MERGED PRs.
+// Returns both OPEN and MERGED PRs. With domain.ReviewStatusPending or domain.ReviewStatusDecided
+// only PRs where the user has not submitted / has submitted a decision are returned.
 //
 // Returns:
 //   - []*domain.PullRequest: slice of PRs where user is a reviewer (empty if no PRs found)
 //   - error: domain.ErrNotFound if user does not exist, or any database error.
-func (u *UserUseCase) GetAssignedPRs(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
+func (u *UserUseCase) GetAssignedPRs(ctx context.Context, userID string, status domain.ReviewStatus) ([]*domain.PullRequest, error) {
 	prs, err := u.prRepo.GetPRsByReviewer(ctx, userID)
 	if err != nil {
 		return nil, err // err can be domain.NotFound if user does not exist
 	}
 
-	return prs, nil
+	if status == domain.ReviewStatusAny {
+		return prs, nil
+	}
+
+	decided := status == domain.ReviewStatusDecided
+	filtered := make([]*domain.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if pr.HasDecision(userID) == decided {
+			filtered = append(filtered, pr)
+		}
+	}
+
+	return filtered, nil
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -10,12 +11,49 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
+type ReviewAssignerMock struct {
+	mock.Mock
+}
+
+func (m *ReviewAssignerMock) reassignOpenReviewsTx(ctx context.Context, tx *sql.Tx, userID string, reason domain.UnassignReason) (*domain.ReassignmentReport, error) {
+	args := m.Called(ctx, tx, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReassignmentReport), args.Error(1)
+}
+
+func (m *ReviewAssignerMock) reassignLeavingReviewsTx(ctx context.Context, tx *sql.Tx, userIDs []string, reason domain.UnassignReason) (*domain.ReassignmentReport, error) {
+	args := m.Called(ctx, tx, userIDs, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReassignmentReport), args.Error(1)
+}
+
+func (m *ReviewAssignerMock) TopUpTeamReviewers(ctx context.Context, teamID int64) ([]domain.ReviewerTopUp, error) {
+	args := m.Called(ctx, teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewerTopUp), args.Error(1)
+}
+
+// noTopUps returns an assigner that finds no reviewer places to fill
+func noTopUps() *ReviewAssignerMock {
+	m := new(ReviewAssignerMock)
+	m.On("TopUpTeamReviewers", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return m
+}
+
 // TestSetUserIsActive_Success tests successful user activation update
 func TestSetUserIsActive_Success(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
@@ -43,8 +81,8 @@ func TestSetUserIsActive_Success(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.SetUserIsActive(ctx, userID, false)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, _, err := uc.SetUserIsActive(ctx, userID, false, false)
 
 	// Assert
 	require.NoError(t, err)
@@ -58,7 +96,7 @@ func TestSetUserIsActive_Success(t *testing.T) {
 // TestSetUserIsActive_UserNotFound tests error when user doesn't exist
 func TestSetUserIsActive_UserNotFound(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, _, err := sqlmock.New()
@@ -72,8 +110,8 @@ func TestSetUserIsActive_UserNotFound(t *testing.T) {
 	mockUserRepo.On("GetByID", ctx, userID).Return(nil, domain.ErrNotFound)
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.SetUserIsActive(ctx, userID, true)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, _, err := uc.SetUserIsActive(ctx, userID, true, false)
 
 	// Assert
 	assert.ErrorIs(t, err, domain.ErrNotFound)
@@ -84,7 +122,7 @@ func TestSetUserIsActive_UserNotFound(t *testing.T) {
 // TestSetUserIsActive_UpdateError tests error during update
 func TestSetUserIsActive_UpdateError(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, dbMock, err := sqlmock.New()
@@ -104,8 +142,8 @@ func TestSetUserIsActive_UpdateError(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.SetUserIsActive(ctx, userID, false)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, _, err := uc.SetUserIsActive(ctx, userID, false, false)
 
 	// Assert
 	assert.Error(t, err)
@@ -116,10 +154,136 @@ func TestSetUserIsActive_UpdateError(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+// TestSetUserIsActive_ReassignReviews tests handing over open reviews on deactivation
+func TestSetUserIsActive_ReassignReviews(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	mockReassigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	user := &domain.User{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1}
+	report := &domain.ReassignmentReport{
+		Reassigned:    []domain.ReviewReassignment{{PRID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"}},
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-2", Reason: domain.ErrNoCandidate.Error()}},
+	}
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(user, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	// reviews are reassigned in the same transaction as the deactivation
+	mockReassigner.On("reassignOpenReviewsTx", ctx, mock.AnythingOfType("*sql.Tx"), "u1", domain.UnassignDeactivated).Return(report, nil)
+	mockTeamRepo.On("GetTeamNameByID", ctx, int64(1)).Return("backend", nil)
+	dbMock.ExpectCommit()
+
+	uc := NewUserUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, mockReassigner, db, zap.NewNop())
+	result, resultReport, err := uc.SetUserIsActive(ctx, "u1", false, true)
+
+	require.NoError(t, err)
+	assert.False(t, result.IsActive)
+	assert.Equal(t, report, resultReport)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockReassigner.AssertExpectations(t)
+}
+
+// TestSetUserIsActive_ReassignReviewsError tests that deactivation is rolled back if reassignment fails
+func TestSetUserIsActive_ReassignReviewsError(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	mockReassigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", IsActive: true, TeamID: 1}, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockReassigner.On("reassignOpenReviewsTx", ctx, mock.Anything, "u1", domain.UnassignDeactivated).Return(nil, errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	uc := NewUserUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, mockReassigner, db, zap.NewNop())
+	result, report, err := uc.SetUserIsActive(ctx, "u1", false, true)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Nil(t, report)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+// TestSetUserIsActive_ActivationIgnoresReassign tests that reviews are reassigned only on deactivation
+func TestSetUserIsActive_ActivationIgnoresReassign(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	mockReassigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", IsActive: false, TeamID: 1}, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockTeamRepo.On("GetTeamNameByID", ctx, int64(1)).Return("backend", nil)
+	dbMock.ExpectCommit()
+	// the reactivated user can fill missing reviewer places of his team
+	mockReassigner.On("TopUpTeamReviewers", ctx, int64(1)).Return([]domain.ReviewerTopUp{
+		{PRID: "pr-1", ReviewerID: "u1", Source: domain.SourceTeam},
+	}, nil)
+
+	uc := NewUserUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, mockReassigner, db, zap.NewNop())
+	result, report, err := uc.SetUserIsActive(ctx, "u1", true, true)
+
+	require.NoError(t, err)
+	assert.True(t, result.IsActive)
+	assert.Nil(t, report)
+	mockReassigner.AssertNotCalled(t, "reassignOpenReviewsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockReassigner.AssertExpectations(t)
+}
+
+func TestSetUserIsActive_TopUpErrorLogged(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockTeamRepo := new(TeamRepoMock)
+	mockAssigner := new(ReviewAssignerMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	dbMock.ExpectBegin()
+	mockUserRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1", IsActive: false, TeamID: 1}, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockTeamRepo.On("GetTeamNameByID", ctx, int64(1)).Return("backend", nil)
+	dbMock.ExpectCommit()
+	mockAssigner.On("TopUpTeamReviewers", ctx, int64(1)).Return(nil, errors.New("db error"))
+
+	core, logs := observer.New(zap.InfoLevel)
+	uc := NewUserUseCase(mockUserRepo, new(PullRequestRepoMock), mockTeamRepo, mockAssigner, db, zap.New(core))
+	result, _, err := uc.SetUserIsActive(ctx, "u1", true, false)
+
+	// the activation is committed anyway, and the failed top-up is logged
+	require.NoError(t, err)
+	assert.True(t, result.IsActive)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	failures := logs.FilterMessage("failed to assign missing reviewers").All()
+	require.Len(t, failures, 1)
+	assert.Equal(t, int64(1), failures[0].ContextMap()["team_id"])
+}
+
 // TestGetAssignedPRs_Success tests successful retrieval of assigned PRs
 func TestGetAssignedPRs_Success(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, _, err := sqlmock.New()
@@ -137,8 +301,8 @@ func TestGetAssignedPRs_Success(t *testing.T) {
 	mockPRRepo.On("GetPRsByReviewer", ctx, userID).Return(expectedPRs, nil)
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.GetAssignedPRs(ctx, userID)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.GetAssignedPRs(ctx, userID, domain.ReviewStatusAny)
 
 	// Assert
 	require.NoError(t, err)
@@ -148,10 +312,37 @@ func TestGetAssignedPRs_Success(t *testing.T) {
 	mockPRRepo.AssertExpectations(t)
 }
 
+// TestGetAssignedPRs_ByReviewStatus tests filtering of pending and decided reviews
+func TestGetAssignedPRs_ByReviewStatus(t *testing.T) {
+	mockPRRepo := newPRRepoMock()
+	ctx := context.Background()
+	userID := "u1"
+	prs := []*domain.PullRequest{
+		{ID: "pr-1", Status: domain.StatusOpen},
+		{ID: "pr-2", Status: domain.StatusOpen, Decisions: map[string]domain.ReviewDecision{
+			userID: {Decision: domain.DecisionApproved},
+		}},
+	}
+
+	mockPRRepo.On("GetPRsByReviewer", ctx, userID).Return(prs, nil)
+
+	uc := NewUserUseCase(new(UserRepoMock), mockPRRepo, new(TeamRepoMock), new(ReviewAssignerMock), nil, zap.NewNop())
+
+	pending, err := uc.GetAssignedPRs(ctx, userID, domain.ReviewStatusPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "pr-1", pending[0].ID)
+
+	decided, err := uc.GetAssignedPRs(ctx, userID, domain.ReviewStatusDecided)
+	require.NoError(t, err)
+	require.Len(t, decided, 1)
+	assert.Equal(t, "pr-2", decided[0].ID)
+}
+
 // TestGetAssignedPRs_NoPRs tests when user has no assigned PRs
 func TestGetAssignedPRs_NoPRs(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, _, err := sqlmock.New()
@@ -165,8 +356,8 @@ func TestGetAssignedPRs_NoPRs(t *testing.T) {
 	mockPRRepo.On("GetPRsByReviewer", ctx, userID).Return([]*domain.PullRequest{}, nil)
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.GetAssignedPRs(ctx, userID)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.GetAssignedPRs(ctx, userID, domain.ReviewStatusAny)
 
 	// Assert
 	require.NoError(t, err)
@@ -177,7 +368,7 @@ func TestGetAssignedPRs_NoPRs(t *testing.T) {
 // TestGetAssignedPRs_RepositoryError tests error from repository
 func TestGetAssignedPRs_RepositoryError(t *testing.T) {
 	mockUserRepo := new(UserRepoMock)
-	mockPRRepo := new(PullRequestRepoMock)
+	mockPRRepo := newPRRepoMock()
 	mockTeamRepo := new(TeamRepoMock)
 
 	db, _, err := sqlmock.New()
@@ -192,8 +383,8 @@ func TestGetAssignedPRs_RepositoryError(t *testing.T) {
 	mockPRRepo.On("GetPRsByReviewer", ctx, userID).Return(nil, repoErr)
 
 	// Execute
-	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, db)
-	result, err := uc.GetAssignedPRs(ctx, userID)
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.GetAssignedPRs(ctx, userID, domain.ReviewStatusAny)
 
 	// Assert
 	assert.Error(t, err)
@@ -201,3 +392,117 @@ func TestGetAssignedPRs_RepositoryError(t *testing.T) {
 	assert.Nil(t, result)
 	mockPRRepo.AssertExpectations(t)
 }
+
+// TestSetMaxOpenReviews_Success tests successful update of user's review capacity
+func TestSetMaxOpenReviews_Success(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	userID := "u1"
+	user := &domain.User{ID: userID, Name: "Alice", IsActive: true, TeamID: 1}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == userID && u.MaxOpenReviews == 3
+	})).Return(nil)
+	mockTeamRepo.On("GetTeamNameByID", ctx, user.TeamID).Return("best_team", nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.SetMaxOpenReviews(ctx, userID, 3)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.MaxOpenReviews)
+	assert.Equal(t, "best_team", result.TeamName)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+}
+
+// TestSetMaxOpenReviews_UserNotFound tests error when user doesn't exist
+func TestSetMaxOpenReviews_UserNotFound(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, "nonexistent").Return(nil, domain.ErrNotFound)
+
+	// Execute
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.SetMaxOpenReviews(ctx, "nonexistent", 3)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// TestSetTags_Success tests that tags are normalized and saved
+func TestSetTags_Success(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	user := &domain.User{ID: "u1", Name: "Alice", IsActive: true, TeamID: 1, Tags: []string{"frontend"}}
+
+	dbMock.ExpectBegin()
+
+	mockUserRepo.On("GetByID", ctx, "u1").Return(user, nil)
+	mockUserRepo.On("Update", ctx, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "u1" && assert.ObjectsAreEqual([]string{"go", "sql"}, u.Tags)
+	})).Return(nil)
+	mockTeamRepo.On("GetTeamNameByID", ctx, int64(1)).Return("backend", nil)
+
+	dbMock.ExpectCommit()
+
+	// Execute
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), db, zap.NewNop())
+	result, err := uc.SetTags(ctx, "u1", []string{" Go", "sql", "", "SQL"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "sql"}, result.Tags)
+	assert.Equal(t, "backend", result.TeamName)
+
+	require.NoError(t, dbMock.ExpectationsWereMet())
+	mockUserRepo.AssertExpectations(t)
+}
+
+// TestSetTags_UserNotFound tests error when user doesn't exist
+func TestSetTags_UserNotFound(t *testing.T) {
+	mockUserRepo := new(UserRepoMock)
+	mockPRRepo := newPRRepoMock()
+	mockTeamRepo := new(TeamRepoMock)
+
+	ctx := context.Background()
+	mockUserRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+	uc := NewUserUseCase(mockUserRepo, mockPRRepo, mockTeamRepo, new(ReviewAssignerMock), nil, zap.NewNop())
+	result, err := uc.SetTags(ctx, "ghost", []string{"go"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, result)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+type absenceUseCase interface {
+	ReassignStartedAbsences(ctx context.Context) ([]domain.AbsenceReassignment, error)
+}
+
+// AbsenceWorker periodically hands over open reviews of users whose absences have started
+type AbsenceWorker struct {
+	absenceUC absenceUseCase
+	interval  time.Duration
+	logger    *zap.Logger
+}
+
+// NewAbsenceWorker creates a new instance of AbsenceWorker checking absences every interval
+func NewAbsenceWorker(absenceUC absenceUseCase, interval time.Duration, logger *zap.Logger) *AbsenceWorker {
+	return &AbsenceWorker{absenceUC: absenceUC, interval: interval, logger: logger}
+}
+
+// Run checks absences right away and then every interval until ctx is cancelled.
+// Reassignments are recorded in the PR events as made by domain.ActorSystem.
+func (w *AbsenceWorker) Run(ctx context.Context) {
+	ctx = domain.WithActor(ctx, domain.ActorSystem)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.reassign(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reassign runs a single check, logging the outcome
+func (w *AbsenceWorker) reassign(ctx context.Context) {
+	reassignments, err := w.absenceUC.ReassignStartedAbsences(ctx)
+
+	for _, r := range reassignments {
+		reassigned := make([]string, len(r.Report.Reassigned))
+		for i, review := range r.Report.Reassigned {
+			reassigned[i] = review.PRID
+		}
+		kept := make([]string, len(r.Report.NotReassigned))
+		for i, review := range r.Report.NotReassigned {
+			kept[i] = review.PRID
+		}
+
+		w.logger.Info("reviews of absent user reassigned",
+			zap.String("user_id", r.Absence.UserID),
+			zap.Int64("absence_id", r.Absence.ID),
+			zap.Strings("reassigned_pr_ids", reassigned),
+			zap.Strings("kept_pr_ids", kept))
+	}
+
+	if err != nil && ctx.Err() == nil {
+		w.logger.Error("failed to reassign reviews of absent users", zap.Error(err))
+	}
+}
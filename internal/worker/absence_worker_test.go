@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type absenceUseCaseStub struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (s *absenceUseCaseStub) ReassignStartedAbsences(_ context.Context) ([]domain.AbsenceReassignment, error) {
+	s.calls.Add(1)
+	return []domain.AbsenceReassignment{{Absence: domain.Absence{ID: 1, UserID: "u1"}}}, s.err
+}
+
+func TestAbsenceWorker_Run(t *testing.T) {
+	uc := &absenceUseCaseStub{err: errors.New("db error")}
+	w := NewAbsenceWorker(uc, 10*time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	// errors don't stop the worker
+	assert.Eventually(t, func() bool { return uc.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+}
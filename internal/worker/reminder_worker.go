@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+type reminderUseCase interface {
+	SendReminders(ctx context.Context) (*domain.ReminderReport, error)
+}
+
+// ReminderWorker periodically reminds reviewers about their pending reviews
+type ReminderWorker struct {
+	reminderUC reminderUseCase
+	interval   time.Duration
+	logger     *zap.Logger
+}
+
+// NewReminderWorker creates a new instance of ReminderWorker sending reminders every interval
+func NewReminderWorker(reminderUC reminderUseCase, interval time.Duration, logger *zap.Logger) *ReminderWorker {
+	return &ReminderWorker{reminderUC: reminderUC, interval: interval, logger: logger}
+}
+
+// Run sends due reminders right away and then every interval until ctx is cancelled.
+// A run in progress is interrupted by the cancellation; its undelivered reminders are not recorded.
+func (w *ReminderWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.remind(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// remind runs a single round of reminders, logging the outcome
+func (w *ReminderWorker) remind(ctx context.Context) {
+	report, err := w.reminderUC.SendReminders(ctx)
+
+	if report != nil {
+		for _, r := range report.Sent {
+			w.logger.Info("reviewer reminded",
+				zap.String("reviewer_id", r.ReviewerID),
+				zap.Int("prs", len(r.Items)))
+		}
+		for _, r := range report.Failed {
+			w.logger.Warn("failed to remind reviewer",
+				zap.String("reviewer_id", r.ReviewerID),
+				zap.String("reason", r.Reason))
+		}
+	}
+
+	if err != nil && ctx.Err() == nil {
+		w.logger.Error("failed to send reminders", zap.Error(err))
+	}
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type reminderUseCaseStub struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (s *reminderUseCaseStub) SendReminders(_ context.Context) (*domain.ReminderReport, error) {
+	s.calls.Add(1)
+	return &domain.ReminderReport{
+		Sent:   []domain.ReviewReminder{{ReviewerID: "u2", Items: []domain.ReminderItem{{PR: &domain.PullRequest{ID: "pr-1"}}}}},
+		Failed: []domain.UnsentReminder{{ReviewerID: "u3", Reason: "chat is unavailable"}},
+	}, s.err
+}
+
+func TestReminderWorker_Run(t *testing.T) {
+	uc := &reminderUseCaseStub{err: errors.New("db error")}
+	w := NewReminderWorker(uc, 10*time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	// errors don't stop the worker
+	assert.Eventually(t, func() bool { return uc.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"go.uber.org/zap"
+)
+
+type slaUseCase interface {
+	CheckReviewSLA(ctx context.Context) (*domain.SLAReport, error)
+}
+
+// SLAWorker periodically marks overdue reviews and hands over reviews overdue for too long
+type SLAWorker struct {
+	prUC     slaUseCase
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewSLAWorker creates a new instance of SLAWorker checking reviews every interval
+func NewSLAWorker(prUC slaUseCase, interval time.Duration, logger *zap.Logger) *SLAWorker {
+	return &SLAWorker{prUC: prUC, interval: interval, logger: logger}
+}
+
+// Run checks reviews right away and then every interval until ctx is cancelled.
+// Changes are recorded in the PR events as made by domain.ActorSystem.
+func (w *SLAWorker) Run(ctx context.Context) {
+	ctx = domain.WithActor(ctx, domain.ActorSystem)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check runs a single check, logging the outcome
+func (w *SLAWorker) check(ctx context.Context) {
+	report, err := w.prUC.CheckReviewSLA(ctx)
+
+	if report != nil {
+		for _, r := range report.Overdue {
+			w.logger.Info("review is overdue",
+				zap.String("pr_id", r.PRID),
+				zap.String("reviewer_id", r.ReviewerID),
+				zap.String("team_name", r.TeamName),
+				zap.Int("review_sla_hours", r.ReviewSLAHours))
+		}
+		for _, r := range report.Reassigned {
+			w.logger.Info("overdue review reassigned",
+				zap.String("pr_id", r.PRID),
+				zap.String("old_reviewer_id", r.OldReviewerID),
+				zap.String("new_reviewer_id", r.NewReviewerID))
+		}
+		for _, r := range report.NotReassigned {
+			w.logger.Warn("overdue review kept its reviewer",
+				zap.String("pr_id", r.PRID),
+				zap.String("reason", r.Reason))
+		}
+	}
+
+	if err != nil && ctx.Err() == nil {
+		w.logger.Error("failed to check review SLA", zap.Error(err))
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type slaUseCaseStub struct {
+	calls atomic.Int32
+	actor atomic.Value
+	err   error
+}
+
+func (s *slaUseCaseStub) CheckReviewSLA(ctx context.Context) (*domain.SLAReport, error) {
+	s.calls.Add(1)
+	s.actor.Store(domain.ActorFromContext(ctx))
+	return &domain.SLAReport{
+		Overdue:       []domain.PendingReview{{PRID: "pr-1", ReviewerID: "u2"}},
+		NotReassigned: []domain.UnreassignedReview{{PRID: "pr-1", Reason: domain.ErrNoCandidate.Error()}},
+	}, s.err
+}
+
+func TestSLAWorker_Run(t *testing.T) {
+	uc := &slaUseCaseStub{err: errors.New("db error")}
+	w := NewSLAWorker(uc, 10*time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+
+	// errors don't stop the worker
+	assert.Eventually(t, func() bool { return uc.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, domain.ActorSystem, uc.actor.Load())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,3 @@
+-- 0 means the user has no limit of concurrently open reviews
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
@@ -0,0 +1,4 @@
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS teams_reviewers_policy_check,
+    DROP COLUMN IF EXISTS min_reviewers,
+    DROP COLUMN IF EXISTS max_reviewers;
@@ -0,0 +1,4 @@
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS source;
+
+DROP TABLE IF EXISTS team_fallbacks;
@@ -0,0 +1,12 @@
+-- Teams that provide reviewers when the team itself lacks candidates, in priority order
+CREATE TABLE team_fallbacks (
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    fallback_team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (team_id, fallback_team_id),
+    CHECK (team_id != fallback_team_id)
+);
+
+-- How the reviewer was assigned: from the author's team or from a fallback team
+ALTER TABLE pr_reviewers
+    ADD COLUMN source VARCHAR(32) NOT NULL DEFAULT 'TEAM';
@@ -0,0 +1,6 @@
+-- Path-based ownership rules of a repository, stored as a CODEOWNERS file
+CREATE TABLE code_owners (
+    repository VARCHAR(255) PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS labels;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,6 @@
+-- Skills of the user (go, sql, frontend, ...) matched against PR labels on reviewer selection
+ALTER TABLE users
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests
+    ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}';
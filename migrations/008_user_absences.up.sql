@@ -0,0 +1,13 @@
+-- Out-of-office periods of users: a user is not selected as a reviewer while an absence covers the current time
+CREATE TABLE user_absences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    -- hand over open reviews of the user to other reviewers when the absence starts
+    reassign_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+    reassigned_at TIMESTAMPTZ DEFAULT NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_absences_user_id_ends_at ON user_absences (user_id, ends_at);
@@ -0,0 +1,4 @@
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS decision,
+    DROP COLUMN IF EXISTS decision_text,
+    DROP COLUMN IF EXISTS decided_at;
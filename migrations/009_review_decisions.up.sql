@@ -0,0 +1,6 @@
+-- Latest outcome of the review submitted by the reviewer, NULL while the review is pending
+ALTER TABLE pr_reviewers
+    ADD COLUMN decision VARCHAR(32) DEFAULT NULL
+        CONSTRAINT pr_reviewers_decision_check CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN decision_text TEXT NOT NULL DEFAULT '',
+    ADD COLUMN decided_at TIMESTAMP DEFAULT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS forced_merges;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS min_approvals,
+    DROP COLUMN IF EXISTS allow_changes_requested;
@@ -0,0 +1,15 @@
+-- Conditions a PR of team members must meet to be merged
+ALTER TABLE teams
+    ADD COLUMN min_approvals INTEGER NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN allow_changes_requested BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Audit trail of merges that bypassed the merge policy
+CREATE TABLE forced_merges (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    forced_by VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    -- conditions of the policy that were not met at the moment of the merge
+    unmet_conditions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+-- PRs can be drafts (no reviewers until marked ready) or closed without merging
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+        CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
@@ -0,0 +1,8 @@
+-- Reviewers that refused to review a PR; they are never assigned to it again
+CREATE TABLE review_declines (
+    pr_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    declined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pr_id, user_id)
+);
@@ -0,0 +1,10 @@
+DELETE FROM pr_reviewers WHERE unassigned_at IS NOT NULL;
+
+DROP INDEX IF EXISTS pr_reviewers_current_idx;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS unassign_reason,
+    DROP COLUMN IF EXISTS unassigned_at,
+    DROP COLUMN IF EXISTS id;
+
+ALTER TABLE pr_reviewers ADD PRIMARY KEY (pr_id, user_id);
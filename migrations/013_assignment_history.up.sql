@@ -0,0 +1,12 @@
+-- Assignments are ended instead of deleted, so pr_reviewers keeps the full history of a PR.
+-- A user can be assigned to the same PR again, but has at most one current assignment.
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pkey;
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN id BIGSERIAL PRIMARY KEY,
+    ADD COLUMN unassigned_at TIMESTAMP DEFAULT NULL,
+    ADD COLUMN unassign_reason VARCHAR(32) DEFAULT NULL
+        CHECK (unassign_reason IN ('REASSIGNED', 'DECLINED', 'DEACTIVATED', 'MANUAL', 'CLOSED')),
+    ADD CHECK ((unassigned_at IS NULL) = (unassign_reason IS NULL));
+
+CREATE UNIQUE INDEX pr_reviewers_current_idx ON pr_reviewers (pr_id, user_id) WHERE unassigned_at IS NULL;
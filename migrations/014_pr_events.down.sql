@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pr_events;
+DROP FUNCTION IF EXISTS pr_events_forbid_update();
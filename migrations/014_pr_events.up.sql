@@ -0,0 +1,24 @@
+-- Append-only timeline of what happened to pull requests
+CREATE TABLE pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL
+        CHECK (type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_REMOVED', 'MERGED', 'STATUS_CHANGED')),
+    -- who caused the event, NULL if unknown
+    actor VARCHAR(255),
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX pr_events_pr_idx ON pr_events (pr_id, created_at, id);
+
+-- Recorded events are never changed
+CREATE FUNCTION pr_events_forbid_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pr_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pr_events_append_only
+    BEFORE UPDATE ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION pr_events_forbid_update();
@@ -0,0 +1,21 @@
+DELETE FROM pr_events WHERE type = 'REVIEW_OVERDUE';
+
+ALTER TABLE pr_events
+    DROP CONSTRAINT IF EXISTS pr_events_type_check,
+    ADD CONSTRAINT pr_events_type_check
+        CHECK (type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_REMOVED', 'MERGED', 'STATUS_CHANGED'));
+
+-- Older versions don't know overdue reassignments
+UPDATE pr_reviewers SET unassign_reason = 'REASSIGNED' WHERE unassign_reason = 'OVERDUE';
+
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT IF EXISTS pr_reviewers_unassign_reason_check,
+    ADD CONSTRAINT pr_reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('REASSIGNED', 'DECLINED', 'DEACTIVATED', 'MANUAL', 'CLOSED'));
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS overdue_at;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS review_sla_hours,
+    DROP COLUMN IF EXISTS sla_reassign_hours;
@@ -0,0 +1,20 @@
+-- Review SLA of PRs authored by team members, in working hours; 0 disables it.
+-- Overdue reviews are handed over after sla_reassign_hours, if set.
+ALTER TABLE teams
+    ADD COLUMN review_sla_hours INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0),
+    ADD COLUMN sla_reassign_hours INTEGER NOT NULL DEFAULT 0 CHECK (sla_reassign_hours >= 0);
+
+-- When the SLA job found the assignment overdue
+ALTER TABLE pr_reviewers
+    ADD COLUMN overdue_at TIMESTAMP DEFAULT NULL;
+
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT pr_reviewers_unassign_reason_check,
+    ADD CONSTRAINT pr_reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('REASSIGNED', 'DECLINED', 'DEACTIVATED', 'MANUAL', 'CLOSED', 'OVERDUE'));
+
+ALTER TABLE pr_events
+    DROP CONSTRAINT pr_events_type_check,
+    ADD CONSTRAINT pr_events_type_check
+        CHECK (type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_REMOVED', 'MERGED',
+                        'STATUS_CHANGED', 'REVIEW_OVERDUE'));
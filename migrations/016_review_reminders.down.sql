@@ -0,0 +1,3 @@
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS last_reminded_at,
+    DROP COLUMN IF EXISTS reminder_count;
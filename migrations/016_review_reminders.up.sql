@@ -0,0 +1,4 @@
+-- Reminders sent to the reviewer about the assignment, used to dedupe and back off reminders
+ALTER TABLE pr_reviewers
+    ADD COLUMN reminder_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_reminded_at TIMESTAMP DEFAULT NULL;
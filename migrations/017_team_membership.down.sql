@@ -0,0 +1,7 @@
+-- Older versions don't know reassignments on leaving the team
+UPDATE pr_reviewers SET unassign_reason = 'REASSIGNED' WHERE unassign_reason = 'LEFT_TEAM';
+
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT IF EXISTS pr_reviewers_unassign_reason_check,
+    ADD CONSTRAINT pr_reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('REASSIGNED', 'DECLINED', 'DEACTIVATED', 'MANUAL', 'CLOSED', 'OVERDUE'));
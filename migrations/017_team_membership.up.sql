@@ -0,0 +1,5 @@
+-- Reviews handed over when the reviewer left the team or moved to another one
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT pr_reviewers_unassign_reason_check,
+    ADD CONSTRAINT pr_reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('REASSIGNED', 'DECLINED', 'DEACTIVATED', 'MANUAL', 'CLOSED', 'OVERDUE', 'LEFT_TEAM'));
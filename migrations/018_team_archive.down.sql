@@ -0,0 +1,18 @@
+DELETE FROM pr_events WHERE type = 'AUTHOR_TEAM_ARCHIVED';
+
+ALTER TABLE pr_events
+    DROP CONSTRAINT IF EXISTS pr_events_type_check,
+    ADD CONSTRAINT pr_events_type_check
+        CHECK (type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_REMOVED', 'MERGED',
+                        'STATUS_CHANGED', 'REVIEW_OVERDUE'));
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS archived_team_id;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_id_fkey,
+    ADD CONSTRAINT users_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+
+-- Archived teams become visible again, without members
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,18 @@
+-- Archived teams are hidden from lookups; the name stays taken until the team is deleted
+ALTER TABLE teams
+    ADD COLUMN archived_at TIMESTAMP DEFAULT NULL;
+
+-- Deleting a team detaches its members instead of deleting them
+ALTER TABLE users
+    DROP CONSTRAINT users_team_id_fkey,
+    ADD CONSTRAINT users_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL;
+
+-- Team of the author at the moment it was archived, set on PRs that were still open then
+ALTER TABLE pull_requests
+    ADD COLUMN archived_team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
+
+ALTER TABLE pr_events
+    DROP CONSTRAINT pr_events_type_check,
+    ADD CONSTRAINT pr_events_type_check
+        CHECK (type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_REMOVED', 'MERGED',
+                        'STATUS_CHANGED', 'REVIEW_OVERDUE', 'AUTHOR_TEAM_ARCHIVED'));
@@ -0,0 +1,2 @@
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS archived_team_id;
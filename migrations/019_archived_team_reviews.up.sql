@@ -0,0 +1,4 @@
+-- Team of the reviewer at the moment it was archived, set on assignments that were current then,
+-- so the team can't be deleted while a former member still reviews an open PR
+ALTER TABLE pr_reviewers
+    ADD COLUMN archived_team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
@@ -3,7 +3,10 @@
 package e2e
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func (s *E2ETestSuite) TestPRCreate_Success_TwoReviewers() {
@@ -40,6 +43,205 @@ func (s *E2ETestSuite) TestPRCreate_Success_TwoReviewers() {
 	assert.NotContains(s.T(), reviewers, "u1")
 }
 
+func (s *E2ETestSuite) TestPRCreate_TeamReviewersPolicy() {
+	teamPayload := map[string]interface{}{
+		"team_name":     "platform",
+		"min_reviewers": 3,
+		"max_reviewers": 3,
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	}
+	s.post("/team/add", teamPayload)
+
+	resp := s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	pr := result["pr"].(map[string]interface{})
+	assert.Len(s.T(), pr["assigned_reviewers"].([]interface{}), 3)
+
+	// One of the candidates leaves - minimum can no longer be reached
+	s.post("/users/setIsActive", map[string]interface{}{"user_id": "u4", "is_active": false})
+
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "Add another feature",
+		"author_id":         "u1",
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+
+	errResp := s.parseError(resp)
+	errorObj := errResp["error"].(map[string]interface{})
+	assert.Equal(s.T(), "NOT_ENOUGH_REVIEWERS", errorObj["code"])
+}
+
+func (s *E2ETestSuite) TestPRCreate_FallbackTeams() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "docs",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+		},
+	})
+
+	resp := s.post("/team/setFallbackTeams", map[string]interface{}{
+		"team_name":      "docs",
+		"fallback_teams": []string{"backend"},
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var teamResult map[string]interface{}
+	s.parseJSON(resp, &teamResult)
+	team := teamResult["team"].(map[string]interface{})
+	assert.Equal(s.T(), []interface{}{"backend"}, team["fallback_teams"])
+
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Fix typos",
+		"author_id":         "u1",
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	pr := result["pr"].(map[string]interface{})
+	assert.ElementsMatch(s.T(), []interface{}{"u2", "u3"}, pr["assigned_reviewers"])
+	assert.ElementsMatch(s.T(), []interface{}{"u2", "u3"}, pr["fallback_reviewers"])
+
+	// a team can't be its own fallback
+	resp = s.post("/team/setFallbackTeams", map[string]interface{}{
+		"team_name":      "docs",
+		"fallback_teams": []string{"docs"},
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestPRCreate_CodeOwners() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "docs",
+		"members": []map[string]interface{}{
+			{"user_id": "d1", "username": "Eve", "is_active": true},
+		},
+	})
+
+	resp := s.post("/codeOwners/set", map[string]interface{}{
+		"repository": "avito/service",
+		"content":    "*.go @u4\n/docs/ @avito/docs\n",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	// Owners of the changed files are assigned, even from another team
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Document handlers",
+		"author_id":         "u1",
+		"repository":        "avito/service",
+		"changed_files":     []string{"internal/handler.go", "docs/handlers.md"},
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	pr := result["pr"].(map[string]interface{})
+	assert.ElementsMatch(s.T(), []interface{}{"u4", "d1"}, pr["assigned_reviewers"])
+	assert.ElementsMatch(s.T(), []interface{}{"u4", "d1"}, pr["code_owner_reviewers"])
+
+	// No rule matches - team members are picked as usual
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "Update README",
+		"author_id":         "u1",
+		"repository":        "avito/service",
+		"changed_files":     []string{"README.md"},
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var plainResult map[string]interface{}
+	s.parseJSON(resp, &plainResult)
+	pr = plainResult["pr"].(map[string]interface{})
+	assert.Len(s.T(), pr["assigned_reviewers"].([]interface{}), 2)
+	assert.Nil(s.T(), pr["code_owner_reviewers"])
+}
+
+func (s *E2ETestSuite) TestCodeOwnersSet_InvalidSyntax() {
+	resp := s.post("/codeOwners/set", map[string]interface{}{
+		"repository": "avito/service",
+		"content":    "*.go dev@example.com",
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+
+	errResp := s.parseError(resp)
+	errorObj := errResp["error"].(map[string]interface{})
+	assert.Equal(s.T(), "INVALID_INPUT", errorObj["code"])
+	assert.Contains(s.T(), errorObj["message"], "line 1")
+
+	resp = s.get("/codeOwners/get?repository=avito/service")
+	assert.Equal(s.T(), 404, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestPRCreate_LabelsMatchTags() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true, "tags": []string{"go"}},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+
+	resp := s.post("/users/setTags", map[string]interface{}{
+		"user_id": "u3",
+		"tags":    []string{"SQL", "go"},
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var userResult map[string]interface{}
+	s.parseJSON(resp, &userResult)
+	user := userResult["user"].(map[string]interface{})
+	assert.Equal(s.T(), []interface{}{"sql", "go"}, user["tags"])
+
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add migration",
+		"author_id":         "u1",
+		"labels":            []string{"sql"},
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	pr := result["pr"].(map[string]interface{})
+	reviewers := pr["assigned_reviewers"].([]interface{})
+	assert.Len(s.T(), reviewers, 2)
+	assert.Contains(s.T(), reviewers, "u3") // the only SQL reviewer is always picked
+	assert.Equal(s.T(), []interface{}{"sql"}, pr["labels"])
+}
+
 func (s *E2ETestSuite) TestPRCreate_OneReviewer() {
 	teamPayload := map[string]interface{}{
 		"team_name": "backend",
@@ -205,6 +407,57 @@ func (s *E2ETestSuite) TestPRReassign_Success() {
 	assert.Contains(s.T(), newReviewers, replacedBy)
 }
 
+func (s *E2ETestSuite) TestPRReassign_ChosenReviewer() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "frontend",
+		"members": []map[string]interface{}{
+			{"user_id": "x1", "username": "Eve", "is_active": true},
+		},
+	})
+
+	// Round robin assigns u2 and u3
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+
+	// Chosen users that break the rules are rejected
+	for _, newUserID := range []string{"u1", "u3", "x1"} {
+		resp := s.post("/pullRequest/reassign", map[string]interface{}{
+			"pull_request_id": "pr-1",
+			"old_user_id":     "u2",
+			"new_user_id":     newUserID,
+		})
+		assert.Equal(s.T(), 409, resp.StatusCode, newUserID)
+
+		errResp := s.parseError(resp)
+		assert.Equal(s.T(), "INVALID_CANDIDATE", errResp["error"].(map[string]interface{})["code"], newUserID)
+	}
+
+	resp := s.post("/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_user_id":     "u2",
+		"new_user_id":     "u4",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	assert.Equal(s.T(), "u4", result["replaced_by"])
+	assert.ElementsMatch(s.T(), []interface{}{"u3", "u4"}, result["pr"].(map[string]interface{})["assigned_reviewers"])
+}
+
 func (s *E2ETestSuite) TestPRReassign_AfterMerge() {
 	teamPayload := map[string]interface{}{
 		"team_name": "backend",
@@ -271,3 +524,672 @@ func (s *E2ETestSuite) TestPRReassign_NotAssigned() {
 	errorObj := errResp["error"].(map[string]interface{})
 	assert.Equal(s.T(), "NOT_ASSIGNED", errorObj["code"])
 }
+
+func (s *E2ETestSuite) TestPRPreview_ExplainsSelection() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"max_reviewers":       1,
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": false},
+			{"user_id": "u5", "username": "Eve", "is_active": true},
+		},
+	})
+	now := time.Now().UTC()
+	s.post("/users/addAbsence", map[string]interface{}{
+		"user_id":   "u5",
+		"starts_at": now.Add(-time.Minute).Format(time.RFC3339),
+		"ends_at":   now.Add(24 * time.Hour).Format(time.RFC3339),
+	})
+
+	// Previewing twice gives the same answer - the rotation is not advanced
+	for range 2 {
+		resp := s.post("/pullRequest/preview", map[string]interface{}{"author_id": "u1"})
+		assert.Equal(s.T(), 200, resp.StatusCode)
+
+		var result map[string]interface{}
+		s.parseJSON(resp, &result)
+		preview := result["preview"].(map[string]interface{})
+		assert.Equal(s.T(), "backend", preview["team_name"])
+		assert.Equal(s.T(), float64(1), preview["reviewers_to_assign"])
+
+		selected := preview["selected_reviewers"].([]interface{})
+		require.Len(s.T(), selected, 1)
+		assert.Equal(s.T(), "u2", selected[0].(map[string]interface{})["user_id"])
+
+		candidates := preview["candidates"].([]interface{})
+		assert.Len(s.T(), candidates, 2) // u2, u3
+
+		reasons := map[string]interface{}{}
+		for _, e := range preview["excluded"].([]interface{}) {
+			excluded := e.(map[string]interface{})
+			reasons[excluded["user_id"].(string)] = excluded["reason"]
+		}
+		assert.Equal(s.T(), map[string]interface{}{"u1": "AUTHOR", "u4": "INACTIVE", "u5": "ABSENT"}, reasons)
+	}
+
+	// Nothing was created
+	resp := s.get("/users/getReview?user_id=u2")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Empty(s.T(), reviews["pull_requests"])
+
+	// The actual assignment picks the previewed reviewer
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	assert.Equal(s.T(), []interface{}{"u2"}, created["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	// Reviewers given as assigned fill the places
+	resp = s.post("/pullRequest/preview", map[string]interface{}{
+		"author_id":          "u1",
+		"assigned_reviewers": []string{"u3"},
+	})
+	var full map[string]interface{}
+	s.parseJSON(resp, &full)
+	preview := full["preview"].(map[string]interface{})
+	assert.Equal(s.T(), float64(0), preview["reviewers_to_assign"])
+	assert.Empty(s.T(), preview["selected_reviewers"])
+}
+
+func (s *E2ETestSuite) TestPRPreview_AuthorNotFound() {
+	resp := s.post("/pullRequest/preview", map[string]interface{}{"author_id": "ghost"})
+	assert.Equal(s.T(), 404, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestPRAddRemoveReviewer() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "frontend",
+		"members": []map[string]interface{}{
+			{"user_id": "x1", "username": "Eve", "is_active": true},
+			{"user_id": "x2", "username": "Frank", "is_active": true},
+		},
+	})
+
+	// Only u2 can be assigned automatically
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+
+	// A reviewer from another team is added by hand
+	resp := s.post("/pullRequest/addReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "x1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var added map[string]interface{}
+	s.parseJSON(resp, &added)
+	pr := added["pr"].(map[string]interface{})
+	assert.ElementsMatch(s.T(), []interface{}{"u2", "x1"}, pr["assigned_reviewers"])
+	assert.Equal(s.T(), []interface{}{"x1"}, pr["manual_reviewers"])
+
+	// The team allows two reviewers
+	resp = s.post("/pullRequest/addReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "x2"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "TOO_MANY_REVIEWERS", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	// The author can't review his PR
+	resp = s.post("/pullRequest/addReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u1"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "INVALID_CANDIDATE", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/removeReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u2"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var removed map[string]interface{}
+	s.parseJSON(resp, &removed)
+	assert.Equal(s.T(), []interface{}{"x1"}, removed["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	resp = s.post("/pullRequest/removeReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u2"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "NOT_ASSIGNED", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	// Merged PRs can't be changed
+	s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	resp = s.post("/pullRequest/addReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u2"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "PR_MERGED", s.parseError(resp)["error"].(map[string]interface{})["code"])
+}
+
+func (s *E2ETestSuite) TestPRSubmitReview() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+
+	resp := s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"decision":        "CHANGES_REQUESTED",
+		"text":            "Please add tests",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	// The latest decision is returned
+	resp = s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"decision":        "APPROVED",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	decisions := result["pr"].(map[string]interface{})["review_decisions"].([]interface{})
+	require.Len(s.T(), decisions, 1)
+	decision := decisions[0].(map[string]interface{})
+	assert.Equal(s.T(), "u2", decision["user_id"])
+	assert.Equal(s.T(), "APPROVED", decision["decision"])
+	assert.NotEmpty(s.T(), decision["decided_at"])
+
+	// u2 has decided, u3 has not
+	resp = s.get("/users/getReview?user_id=u2&review_status=DECIDED")
+	var decided map[string]interface{}
+	s.parseJSON(resp, &decided)
+	assert.Len(s.T(), decided["pull_requests"].([]interface{}), 1)
+
+	resp = s.get("/users/getReview?user_id=u2&review_status=PENDING")
+	var pending map[string]interface{}
+	s.parseJSON(resp, &pending)
+	assert.Empty(s.T(), pending["pull_requests"])
+
+	resp = s.get("/users/getReview?user_id=u3&review_status=PENDING")
+	var pendingU3 map[string]interface{}
+	s.parseJSON(resp, &pendingU3)
+	assert.Len(s.T(), pendingU3["pull_requests"].([]interface{}), 1)
+
+	resp = s.get("/users/getReview?user_id=u3&review_status=unknown")
+	assert.Equal(s.T(), 400, resp.StatusCode)
+
+	// Only assigned reviewers can submit a decision
+	resp = s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u1",
+		"decision":        "APPROVED",
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "NOT_ASSIGNED", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u3",
+		"decision":        "LGTM",
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestPRMerge_BlockedByMergePolicy() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":     "backend",
+		"min_approvals": 1,
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"decision":        "CHANGES_REQUESTED",
+	})
+
+	resp := s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	errResp := s.parseError(resp)["error"].(map[string]interface{})
+	assert.Equal(s.T(), "MERGE_BLOCKED", errResp["code"])
+	assert.Contains(s.T(), errResp["message"], "1 approvals required, 0 given")
+	assert.Contains(s.T(), errResp["message"], "changes requested by u2")
+
+	// The policy is changed only by admins
+	resp = s.post("/admin/setMergePolicy", map[string]interface{}{"team_name": "backend", "min_approvals": 0})
+	assert.Equal(s.T(), 401, resp.StatusCode)
+	resp.Body.Close()
+
+	// Approvals required by the policy can't exceed max reviewers
+	resp = s.postAdmin("/admin/setMergePolicy", map[string]interface{}{"team_name": "backend", "min_approvals": 3})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.postAdmin("/admin/setMergePolicy", map[string]interface{}{
+		"team_name":               "backend",
+		"min_approvals":           1,
+		"allow_changes_requested": true,
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var team map[string]interface{}
+	s.parseJSON(resp, &team)
+	assert.Equal(s.T(), float64(1), team["team"].(map[string]interface{})["min_approvals"])
+	assert.Equal(s.T(), true, team["team"].(map[string]interface{})["allow_changes_requested"])
+
+	// Changes requested by u2 no longer block the merge once u3 approves
+	s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u3",
+		"decision":        "APPROVED",
+	})
+
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (s *E2ETestSuite) TestPRForceMerge() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":     "backend",
+		"min_approvals": 2,
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Hotfix",
+		"author_id":         "u1",
+	})
+
+	forceMerge := map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"forced_by":       "Alice",
+		"reason":          "production is down",
+	}
+
+	// Only admins can force a merge
+	resp := s.post("/admin/forceMerge", forceMerge)
+	assert.Equal(s.T(), 401, resp.StatusCode)
+	assert.Equal(s.T(), "UNAUTHORIZED", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	resp = s.postAdmin("/admin/forceMerge", forceMerge)
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	assert.Equal(s.T(), "MERGED", result["pr"].(map[string]interface{})["status"])
+
+	// Repeated force merge is idempotent and is not recorded again
+	resp = s.postAdmin("/admin/forceMerge", forceMerge)
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.getAdmin("/admin/forcedMerges")
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var audit map[string]interface{}
+	s.parseJSON(resp, &audit)
+	merges := audit["forced_merges"].([]interface{})
+	require.Len(s.T(), merges, 1)
+	merge := merges[0].(map[string]interface{})
+	assert.Equal(s.T(), "pr-1", merge["pull_request_id"])
+	assert.Equal(s.T(), "Alice", merge["forced_by"])
+	assert.Equal(s.T(), "production is down", merge["reason"])
+	assert.Equal(s.T(), []interface{}{"2 approvals required, 0 given"}, merge["unmet_conditions"])
+
+	resp = s.postAdmin("/admin/forceMerge", map[string]interface{}{"pull_request_id": "ghost", "forced_by": "Alice", "reason": "x"})
+	assert.Equal(s.T(), 404, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (s *E2ETestSuite) TestPRDraftCloseReopen() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+
+	// Drafts get no reviewers
+	resp := s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "WIP",
+		"author_id":         "u1",
+		"draft":             true,
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	assert.Equal(s.T(), "DRAFT", created["pr"].(map[string]interface{})["status"])
+	assert.Empty(s.T(), created["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	// Drafts can't be merged
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "INVALID_TRANSITION", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/addReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u2"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "PR_NOT_OPEN", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	// Reviewers are assigned when the draft is ready
+	resp = s.post("/pullRequest/markReady", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var ready map[string]interface{}
+	s.parseJSON(resp, &ready)
+	assert.Equal(s.T(), "OPEN", ready["pr"].(map[string]interface{})["status"])
+	assert.Len(s.T(), ready["pr"].(map[string]interface{})["assigned_reviewers"], 2)
+
+	resp = s.post("/pullRequest/markReady", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "INVALID_TRANSITION", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	// Closing releases the reviewers
+	resp = s.post("/pullRequest/close", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var closed map[string]interface{}
+	s.parseJSON(resp, &closed)
+	assert.Equal(s.T(), "CLOSED", closed["pr"].(map[string]interface{})["status"])
+	assert.Empty(s.T(), closed["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	resp = s.get("/users/getReview?user_id=u2")
+	var review map[string]interface{}
+	s.parseJSON(resp, &review)
+	assert.Empty(s.T(), review["pull_requests"])
+
+	resp = s.get("/stats")
+	var stats map[string]interface{}
+	s.parseJSON(resp, &stats)
+	assert.Equal(s.T(), float64(0), stats["open_prs"])
+	assert.Equal(s.T(), float64(1), stats["closed_prs"])
+
+	// Reopened PR gets reviewers anew and can be merged
+	resp = s.post("/pullRequest/reopen", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var reopened map[string]interface{}
+	s.parseJSON(resp, &reopened)
+	assert.Equal(s.T(), "OPEN", reopened["pr"].(map[string]interface{})["status"])
+	assert.Len(s.T(), reopened["pr"].(map[string]interface{})["assigned_reviewers"], 2)
+
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	// Merged PR is final
+	resp = s.post("/pullRequest/close", map[string]interface{}{"pull_request_id": "pr-1"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "INVALID_TRANSITION", s.parseError(resp)["error"].(map[string]interface{})["code"])
+}
+
+func (s *E2ETestSuite) TestPRDecline() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+
+	// Round robin assigns u2 and u3
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+
+	// Reason is required
+	resp := s.post("/pullRequest/decline", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.post("/pullRequest/decline", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"reason":          "no context",
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	assert.Equal(s.T(), "u4", result["replaced_by"])
+	pr := result["pr"].(map[string]interface{})
+	assert.ElementsMatch(s.T(), []interface{}{"u3", "u4"}, pr["assigned_reviewers"])
+	declines := pr["declines"].([]interface{})
+	require.Len(s.T(), declines, 1)
+	decline := declines[0].(map[string]interface{})
+	assert.Equal(s.T(), "u2", decline["user_id"])
+	assert.Equal(s.T(), "no context", decline["reason"])
+
+	// u2 is not assigned to the PR again, so u4 has no replacement and stays
+	resp = s.post("/pullRequest/decline", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u4",
+		"reason":          "overloaded",
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "NO_CANDIDATE", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/addReviewer", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "INVALID_CANDIDATE", s.parseError(resp)["error"].(map[string]interface{})["code"])
+
+	// Only assigned reviewers can decline
+	resp = s.post("/pullRequest/decline", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"reason":          "no context",
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	assert.Equal(s.T(), "NOT_ASSIGNED", s.parseError(resp)["error"].(map[string]interface{})["code"])
+}
+
+func (s *E2ETestSuite) TestPRAssignmentHistory() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+
+	// Round robin assigns u2 and u3
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+
+	// u2 is replaced by u4, u3 declines and the only candidate left is u2, u4 is removed by hand
+	resp := s.post("/pullRequest/reassign", map[string]interface{}{"pull_request_id": "pr-1", "old_user_id": "u2"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/pullRequest/decline", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u3", "reason": "overloaded"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/pullRequest/removeReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u4"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.get("/pullRequest/assignments?pull_request_id=pr-1")
+	require.Equal(s.T(), 200, resp.StatusCode)
+	var history map[string]interface{}
+	s.parseJSON(resp, &history)
+	assert.Equal(s.T(), "pr-1", history["pull_request_id"])
+
+	type assignment struct{ userID, reason interface{} }
+	var got []assignment
+	for _, a := range history["assignments"].([]interface{}) {
+		a := a.(map[string]interface{})
+		got = append(got, assignment{a["user_id"], a["unassign_reason"]})
+	}
+	// u2 and u3 are assigned at once, so only the rest of the order is fixed
+	require.Len(s.T(), got, 4)
+	assert.ElementsMatch(s.T(), []assignment{{"u2", "REASSIGNED"}, {"u3", "DECLINED"}}, got[:2])
+	assert.Equal(s.T(), []assignment{{"u4", "MANUAL"}, {"u2", nil}}, got[2:])
+
+	resp = s.get("/pullRequest/assignments?pull_request_id=ghost")
+	assert.Equal(s.T(), 404, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.get("/pullRequest/assignments")
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (s *E2ETestSuite) TestPREvents() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+
+	// Round robin assigns u2 and u3, then the lead replaces u2 with u4
+	resp := s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.postAs("lead", "/pullRequest/reassign", map[string]interface{}{"pull_request_id": "pr-1", "old_user_id": "u2"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.get("/pullRequest/events?pull_request_id=pr-1")
+	require.Equal(s.T(), 200, resp.StatusCode)
+	var timeline map[string]interface{}
+	s.parseJSON(resp, &timeline)
+	assert.Equal(s.T(), "pr-1", timeline["pull_request_id"])
+
+	events := timeline["events"].([]interface{})
+	require.Len(s.T(), events, 5)
+	event := func(i int) map[string]interface{} { return events[i].(map[string]interface{}) }
+
+	assert.Equal(s.T(), "CREATED", event(0)["type"])
+	assert.Equal(s.T(), "u1", event(0)["actor"]) // the author
+	for i := 1; i <= 2; i++ {
+		assert.Equal(s.T(), "REVIEWER_ASSIGNED", event(i)["type"])
+	}
+	assert.Equal(s.T(), "REVIEWER_REASSIGNED", event(3)["type"])
+	assert.Equal(s.T(), "lead", event(3)["actor"])
+	assert.Equal(s.T(), map[string]interface{}{
+		"old_reviewer_id": "u2",
+		"new_reviewer_id": "u4",
+		"source":          "TEAM",
+		"reason":          "REASSIGNED",
+	}, event(3)["payload"])
+	assert.Equal(s.T(), "MERGED", event(4)["type"])
+	assert.Nil(s.T(), event(4)["actor"])
+	assert.Equal(s.T(), map[string]interface{}{"forced": false}, event(4)["payload"])
+
+	resp = s.get("/pullRequest/events?pull_request_id=ghost")
+	assert.Equal(s.T(), 404, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.get("/pullRequest/events")
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (s *E2ETestSuite) TestPROverdueReviews() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name":           "backend",
+		"assignment_strategy": "ROUND_ROBIN",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+		},
+	})
+
+	// Reassignment can't start before the review becomes overdue
+	resp := s.post("/team/setReviewSLA", map[string]interface{}{
+		"team_name":          "backend",
+		"review_sla_hours":   24,
+		"sla_reassign_hours": 8,
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.post("/team/setReviewSLA", map[string]interface{}{"team_name": "backend", "review_sla_hours": 24})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	var team map[string]interface{}
+	s.parseJSON(resp, &team)
+	assert.Equal(s.T(), float64(24), team["team"].(map[string]interface{})["review_sla_hours"])
+	assert.Equal(s.T(), float64(0), team["team"].(map[string]interface{})["sla_reassign_hours"])
+
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	resp.Body.Close()
+
+	// Fresh reviews are within the SLA
+	resp = s.get("/pullRequest/overdue")
+	require.Equal(s.T(), 200, resp.StatusCode)
+	var overdue map[string]interface{}
+	s.parseJSON(resp, &overdue)
+	assert.Empty(s.T(), overdue["reviews"])
+
+	// u2 has decided, u3 keeps the PR waiting for ten days
+	resp = s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"decision":        "APPROVED",
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+	_, err := s.db.Exec("UPDATE pr_reviewers SET assigned_at = NOW() - INTERVAL '10 days'")
+	require.NoError(s.T(), err)
+
+	resp = s.get("/pullRequest/overdue?team_name=backend")
+	require.Equal(s.T(), 200, resp.StatusCode)
+	s.parseJSON(resp, &overdue)
+	reviews := overdue["reviews"].([]interface{})
+	require.Len(s.T(), reviews, 1)
+	review := reviews[0].(map[string]interface{})
+	assert.Equal(s.T(), "pr-1", review["pull_request_id"])
+	assert.Equal(s.T(), "u3", review["reviewer_id"])
+	assert.Equal(s.T(), "backend", review["team_name"])
+	assert.Greater(s.T(), review["waiting_hours"], float64(24))
+
+	resp = s.get("/pullRequest/overdue?team_name=ghost")
+	assert.Equal(s.T(), 404, resp.StatusCode)
+	resp.Body.Close()
+}
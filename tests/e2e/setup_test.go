@@ -16,6 +16,7 @@ import (
 
 	httpAdapter "github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/http"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/adapter/postgres"
+	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/domain"
 	"github.com/blxxdclxud/PR-reviewers-assigner-avito/internal/usecase"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/require"
@@ -23,6 +24,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// adminToken authorizes requests to the /admin endpoints
+const adminToken = "test-admin-token"
+
 // E2ETestSuite contains end-to-end tests for the entire application
 type E2ETestSuite struct {
 	suite.Suite
@@ -32,6 +36,8 @@ type E2ETestSuite struct {
 	teamRepo *postgres.TeamRepository
 	userRepo *postgres.UserRepository
 	prRepo   *postgres.PullRequestRepository
+
+	absenceUC *usecase.AbsenceUseCase
 }
 
 // SetupSuite runs once before all tests
@@ -58,18 +64,22 @@ func (s *E2ETestSuite) SetupSuite() {
 	s.teamRepo = postgres.NewTeamRepository(db, logger)
 	s.userRepo = postgres.NewUserRepository(db, logger)
 	s.prRepo = postgres.NewPullRequestRepository(db, logger)
+	codeOwnersRepo := postgres.NewCodeOwnersRepository(db, logger)
+	absenceRepo := postgres.NewAbsenceRepository(db, logger)
 
 	statsRepo := postgres.NewStatsRepository(db)
 
 	// Initialize use cases
-	teamUC := usecase.NewTeamUseCase(s.teamRepo, s.userRepo, db)
-	userUC := usecase.NewUserUseCase(s.userRepo, s.prRepo, s.teamRepo, db)
-	prUC := usecase.NewPRUseCase(s.userRepo, s.prRepo, db)
+	prUC := usecase.NewPRUseCase(s.userRepo, s.prRepo, s.teamRepo, codeOwnersRepo, domain.DefaultWorkingHours, db)
+	teamUC := usecase.NewTeamUseCase(s.teamRepo, s.userRepo, s.prRepo, prUC, db, logger)
+	userUC := usecase.NewUserUseCase(s.userRepo, s.prRepo, s.teamRepo, prUC, db, logger)
+	codeOwnersUC := usecase.NewCodeOwnersUseCase(codeOwnersRepo, db)
+	s.absenceUC = usecase.NewAbsenceUseCase(absenceRepo, s.userRepo, prUC, db)
 
 	statsUC := usecase.NewStatsUseCase(statsRepo)
 
 	// Setup router and test server
-	router := httpAdapter.SetupRouter(teamUC, userUC, prUC, statsUC)
+	router := httpAdapter.SetupRouter(teamUC, userUC, prUC, codeOwnersUC, s.absenceUC, statsUC, adminToken)
 	s.server = httptest.NewServer(router)
 	s.baseURL = s.server.URL
 }
@@ -95,7 +105,7 @@ func (s *E2ETestSuite) TearDownTest() {
 }
 
 func (s *E2ETestSuite) cleanupTables() {
-	tables := []string{"pr_reviewers", "pull_requests", "users", "teams"}
+	tables := []string{"pr_reviewers", "pull_requests", "user_absences", "users", "team_fallbacks", "teams", "code_owners", "forced_merges", "review_declines", "pr_events"}
 	for _, table := range tables {
 		_, err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
 		require.NoError(s.T(), err)
@@ -113,6 +123,65 @@ func (s *E2ETestSuite) post(path string, body interface{}) *http.Response {
 	return resp
 }
 
+// put sends a PUT request with the JSON body
+func (s *E2ETestSuite) put(path string, body interface{}) *http.Response {
+	jsonBody, err := json.Marshal(body)
+	require.NoError(s.T(), err)
+
+	req, err := http.NewRequest(http.MethodPut, s.baseURL+path, bytes.NewBuffer(jsonBody))
+	require.NoError(s.T(), err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(s.T(), err)
+
+	return resp
+}
+
+// postAs works like post, but tells who makes the request with the X-Actor header
+func (s *E2ETestSuite) postAs(actor, path string, body interface{}) *http.Response {
+	jsonBody, err := json.Marshal(body)
+	require.NoError(s.T(), err)
+
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+path, bytes.NewBuffer(jsonBody))
+	require.NoError(s.T(), err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor", actor)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(s.T(), err)
+
+	return resp
+}
+
+// postAdmin works like post, but authorizes the request with the admin token
+func (s *E2ETestSuite) postAdmin(path string, body interface{}) *http.Response {
+	jsonBody, err := json.Marshal(body)
+	require.NoError(s.T(), err)
+
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+path, bytes.NewBuffer(jsonBody))
+	require.NoError(s.T(), err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(s.T(), err)
+
+	return resp
+}
+
+// getAdmin works like get, but authorizes the request with the admin token
+func (s *E2ETestSuite) getAdmin(path string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, s.baseURL+path, nil)
+	require.NoError(s.T(), err)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(s.T(), err)
+
+	return resp
+}
+
 func (s *E2ETestSuite) get(path string) *http.Response {
 	resp, err := http.Get(s.baseURL + path)
 	require.NoError(s.T(), err)
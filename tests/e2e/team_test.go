@@ -4,6 +4,7 @@ package e2e
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func (s *E2ETestSuite) TestTeamAdd_Success() {
@@ -74,3 +75,488 @@ func (s *E2ETestSuite) TestTeamGet_NotFound() {
 	errorObj := errResp["error"].(map[string]interface{})
 	assert.Equal(s.T(), "NOT_FOUND", errorObj["code"])
 }
+
+func (s *E2ETestSuite) TestTeamMembers_AddMoveRemove() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "frontend",
+		"members": []map[string]interface{}{
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+
+	// pr-1 gets a single reviewer, u2
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+
+	resp := s.post("/team/addMembers", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var added map[string]interface{}
+	s.parseJSON(resp, &added)
+	assert.Len(s.T(), added["team"].(map[string]interface{})["members"], 3)
+
+	// the new member fills the free reviewer place of pr-1
+	resp = s.get("/users/getReview?user_id=u3")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 1)
+
+	// u4 has to be moved, not added
+	resp = s.post("/team/addMembers", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+	})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	errResp := s.parseError(resp)
+	assert.Equal(s.T(), "MEMBER_OF_OTHER_TEAM", errResp["error"].(map[string]interface{})["code"])
+
+	// u2 moves to frontend; nobody in backend can replace them on pr-1, so they keep the review
+	resp = s.post("/team/moveMember", map[string]interface{}{
+		"user_id":          "u2",
+		"team_name":        "frontend",
+		"reassign_reviews": true,
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var moved map[string]interface{}
+	s.parseJSON(resp, &moved)
+	assert.Equal(s.T(), "frontend", moved["user"].(map[string]interface{})["team_name"])
+	reassignment := moved["reassignment"].(map[string]interface{})
+	assert.Empty(s.T(), reassignment["reassigned"])
+	notReassigned := reassignment["not_reassigned"].([]interface{})
+	require.Len(s.T(), notReassigned, 1)
+	assert.Equal(s.T(), "pr-1", notReassigned[0].(map[string]interface{})["pull_request_id"])
+
+	// u3 leaves the team, keeping the review
+	resp = s.post("/team/removeMember", map[string]interface{}{
+		"team_name": "backend",
+		"user_id":   "u3",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var removed map[string]interface{}
+	s.parseJSON(resp, &removed)
+	assert.Len(s.T(), removed["team"].(map[string]interface{})["members"], 1)
+	assert.Nil(s.T(), removed["reassignment"])
+
+	resp = s.get("/users/getReview?user_id=u3")
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 1)
+
+	// u3 is not a member anymore
+	resp = s.post("/team/removeMember", map[string]interface{}{
+		"team_name": "backend",
+		"user_id":   "u3",
+	})
+	assert.Equal(s.T(), 404, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestTeamSync() {
+	roster := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true, "max_open_reviews": 3, "tags": []string{"go"}},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	}
+
+	// the first sync creates the team
+	resp := s.put("/team/sync", roster)
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	diff := created["diff"].(map[string]interface{})
+	assert.Equal(s.T(), true, diff["team_created"])
+	assert.ElementsMatch(s.T(), []interface{}{"u1", "u2"}, diff["created"])
+
+	// the same roster changes nothing
+	resp = s.put("/team/sync", roster)
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var unchanged map[string]interface{}
+	s.parseJSON(resp, &unchanged)
+	diff = unchanged["diff"].(map[string]interface{})
+	assert.Equal(s.T(), false, diff["team_created"])
+	assert.Empty(s.T(), diff["created"])
+	assert.Empty(s.T(), diff["updated"])
+	assert.Empty(s.T(), diff["removed"])
+
+	// a member of another team has to be moved first
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "frontend",
+		"members": []map[string]interface{}{
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	newRoster := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice Smith", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	}
+	resp = s.put("/team/sync", newRoster)
+	require.Equal(s.T(), 409, resp.StatusCode)
+	errorObj := s.parseError(resp)["error"].(map[string]interface{})
+	assert.Equal(s.T(), "MEMBER_OF_OTHER_TEAM", errorObj["code"])
+
+	resp = s.post("/team/removeMember", map[string]interface{}{"team_name": "frontend", "user_id": "u3"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	// u1 is renamed without listing the review capacity and tags, which are kept;
+	// u2 leaves, u3 joins without a team
+	resp = s.put("/team/sync", newRoster)
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var synced map[string]interface{}
+	s.parseJSON(resp, &synced)
+	diff = synced["diff"].(map[string]interface{})
+	assert.Equal(s.T(), []interface{}{"u1"}, diff["updated"])
+	assert.Equal(s.T(), []interface{}{"u3"}, diff["joined"])
+	assert.Equal(s.T(), []interface{}{"u2"}, diff["removed"])
+	assert.NotContains(s.T(), synced, "reassignment")
+	members := synced["team"].(map[string]interface{})["members"].([]interface{})
+	require.Len(s.T(), members, 2)
+	for _, m := range members {
+		member := m.(map[string]interface{})
+		if member["user_id"] == "u1" {
+			assert.Equal(s.T(), float64(3), member["max_open_reviews"])
+			assert.Equal(s.T(), []interface{}{"go"}, member["tags"])
+		}
+	}
+
+	resp = s.get("/team/get?team_name=frontend")
+	var frontend map[string]interface{}
+	s.parseJSON(resp, &frontend)
+	assert.Empty(s.T(), frontend["members"])
+
+	// a user listed twice is rejected
+	resp = s.put("/team/sync", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u1", "username": "Alice", "is_active": false},
+		},
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestTeamSync_ReassignReviews() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+		},
+	})
+	// u2 and u3 review pr-1, then u4 joins
+	resp := s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/team/addMembers", map[string]interface{}{
+		"team_name": "backend",
+		"members":   []map[string]interface{}{{"user_id": "u4", "username": "Dave", "is_active": true}},
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	// u3 leaves the roster and hands over the review to u4, the only free member
+	resp = s.put("/team/sync", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		},
+		"reassign_reviews": true,
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	var synced map[string]interface{}
+	s.parseJSON(resp, &synced)
+	assert.Equal(s.T(), []interface{}{"u3"}, synced["diff"].(map[string]interface{})["removed"])
+	reassigned := synced["reassignment"].(map[string]interface{})["reassigned"].([]interface{})
+	require.Len(s.T(), reassigned, 1)
+	assert.Equal(s.T(), "u3", reassigned[0].(map[string]interface{})["old_user_id"])
+	assert.Equal(s.T(), "u4", reassigned[0].(map[string]interface{})["replaced_by"])
+
+	resp = s.get("/users/getReview?user_id=u3")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Empty(s.T(), reviews["pull_requests"])
+}
+
+func (s *E2ETestSuite) TestTeamArchiveAndDelete() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	resp := s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+
+	// archiving is admin-only, it detaches the members and flags the open PR
+	resp = s.post("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 401, resp.StatusCode)
+	resp = s.get("/team/get?team_name=backend")
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	resp = s.postAdmin("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	var archive map[string]interface{}
+	s.parseJSON(resp, &archive)
+	assert.Equal(s.T(), []interface{}{"u1", "u2"}, archive["detached_members"])
+	assert.Equal(s.T(), []interface{}{"pr-1"}, archive["flagged_pull_requests"])
+	notReassigned := archive["reassignment"].(map[string]interface{})["not_reassigned"].([]interface{})
+	require.Len(s.T(), notReassigned, 1, "the author of pr-1 leaves as well")
+	assert.Equal(s.T(), "u2", notReassigned[0].(map[string]interface{})["user_id"])
+
+	resp = s.get("/team/get?team_name=backend")
+	assert.Equal(s.T(), 404, resp.StatusCode)
+	resp = s.postAdmin("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 404, resp.StatusCode)
+
+	resp = s.get("/users/getReview?user_id=u2")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"], 1, "reviews are kept")
+
+	resp = s.get("/pullRequest/orphaned")
+	var orphaned map[string]interface{}
+	s.parseJSON(resp, &orphaned)
+	require.Len(s.T(), orphaned["pull_requests"], 1)
+	assert.Equal(s.T(), "backend", orphaned["pull_requests"].([]interface{})[0].(map[string]interface{})["archived_team"])
+
+	resp = s.get("/pullRequest/events?pull_request_id=pr-1")
+	var timeline map[string]interface{}
+	s.parseJSON(resp, &timeline)
+	events := timeline["events"].([]interface{})
+	assert.Equal(s.T(), "AUTHOR_TEAM_ARCHIVED", events[len(events)-1].(map[string]interface{})["type"])
+
+	// the name stays taken until the team is deleted
+	resp = s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members":   []map[string]interface{}{{"user_id": "u3", "username": "Carol", "is_active": true}},
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	resp = s.put("/team/sync", map[string]interface{}{
+		"team_name": "backend",
+		"members":   []map[string]interface{}{{"user_id": "u3", "username": "Carol", "is_active": true}},
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+	errResp := s.parseError(resp)
+	assert.Equal(s.T(), "TEAM_EXISTS", errResp["error"].(map[string]interface{})["code"])
+
+	// deletion is admin-only and waits for the open review of pr-1
+	resp = s.post("/admin/deleteTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 401, resp.StatusCode)
+
+	resp = s.postAdmin("/admin/deleteTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	errResp = s.parseError(resp)
+	assert.Equal(s.T(), "TEAM_IN_USE", errResp["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	resp = s.postAdmin("/admin/deleteTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	// the former members are kept and can form a new team with the same name
+	resp = s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members":   []map[string]interface{}{{"user_id": "u1", "username": "Alice", "is_active": true}},
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestTeamArchive_HandsOverReviews() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "payments",
+		"members": []map[string]interface{}{
+			{"user_id": "u10", "username": "Judy", "is_active": true},
+			{"user_id": "u11", "username": "Mallory", "is_active": true},
+		},
+	})
+	resp := s.post("/team/setFallbackTeams", map[string]interface{}{
+		"team_name":      "payments",
+		"fallback_teams": []string{"backend"},
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	// a backend member reviews the payments PR as a fallback reviewer
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Refunds",
+		"author_id":         "u10",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	fallbackReviewers := created["pr"].(map[string]interface{})["fallback_reviewers"].([]interface{})
+	require.Len(s.T(), fallbackReviewers, 1)
+	backendReviewer := fallbackReviewers[0]
+
+	resp = s.post("/team/addMembers", map[string]interface{}{
+		"team_name": "payments",
+		"members":   []map[string]interface{}{{"user_id": "u12", "username": "Niaj", "is_active": true}},
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	// archiving hands the review over to the new payments member, not to the other backend member
+	resp = s.postAdmin("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	var archive map[string]interface{}
+	s.parseJSON(resp, &archive)
+	reassignment := archive["reassignment"].(map[string]interface{})
+	assert.Equal(s.T(), []interface{}{map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_user_id":     backendReviewer,
+		"replaced_by":     "u12",
+	}}, reassignment["reassigned"])
+	assert.Empty(s.T(), reassignment["not_reassigned"])
+
+	resp = s.get("/users/getReview?user_id=" + backendReviewer.(string))
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Empty(s.T(), reviews["pull_requests"])
+}
+
+func (s *E2ETestSuite) TestTeamArchive_KeepsMergePolicy() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	resp := s.postAdmin("/admin/setMergePolicy", map[string]interface{}{"team_name": "backend", "min_approvals": 1})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	resp.Body.Close()
+	resp = s.post("/pullRequest/submitReview", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"user_id":         "u2",
+		"decision":        "CHANGES_REQUESTED",
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.postAdmin("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	resp.Body.Close()
+
+	// the author has no team anymore, but the PR keeps the policy of the archived team
+	resp = s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(s.T(), 409, resp.StatusCode)
+	errObj := s.parseError(resp)["error"].(map[string]interface{})
+	assert.Equal(s.T(), "MERGE_BLOCKED", errObj["code"])
+	assert.Contains(s.T(), errObj["message"], "1 approvals required, 0 given")
+	assert.Contains(s.T(), errObj["message"], "changes requested by u2")
+
+	resp = s.postAdmin("/admin/forceMerge", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"forced_by":       "Alice",
+		"reason":          "team is gone",
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+}
+
+func (s *E2ETestSuite) TestTeamArchive_DeleteWaitsForFormerMemberReviews() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	})
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "payments",
+		"members": []map[string]interface{}{
+			{"user_id": "u10", "username": "Judy", "is_active": true},
+			{"user_id": "u11", "username": "Mallory", "is_active": true},
+		},
+	})
+	resp := s.post("/team/setFallbackTeams", map[string]interface{}{
+		"team_name":      "payments",
+		"fallback_teams": []string{"backend"},
+	})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Refunds",
+		"author_id":         "u10",
+	})
+	require.Equal(s.T(), 201, resp.StatusCode)
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	fallbackReviewers := created["pr"].(map[string]interface{})["fallback_reviewers"].([]interface{})
+	require.Len(s.T(), fallbackReviewers, 1)
+	backendReviewer := fallbackReviewers[0]
+
+	// payments has nobody else to take the review, so the former backend member keeps it
+	resp = s.postAdmin("/admin/archiveTeam", map[string]interface{}{"team_name": "backend"})
+	require.Equal(s.T(), 200, resp.StatusCode)
+	var archive map[string]interface{}
+	s.parseJSON(resp, &archive)
+	notReassigned := archive["reassignment"].(map[string]interface{})["not_reassigned"].([]interface{})
+	require.Len(s.T(), notReassigned, 1)
+	assert.Equal(s.T(), backendReviewer, notReassigned[0].(map[string]interface{})["user_id"])
+
+	resp = s.postAdmin("/admin/deleteTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 409, resp.StatusCode)
+	errResp := s.parseError(resp)
+	assert.Equal(s.T(), "TEAM_IN_USE", errResp["error"].(map[string]interface{})["code"])
+
+	resp = s.post("/pullRequest/removeReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": backendReviewer})
+	require.Equal(s.T(), 200, resp.StatusCode)
+
+	resp = s.postAdmin("/admin/deleteTeam", map[string]interface{}{"team_name": "backend"})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+}
@@ -3,7 +3,11 @@
 package e2e
 
 import (
+	"context"
+	"time"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func (s *E2ETestSuite) TestSetIsActive_Success() {
@@ -47,6 +51,109 @@ func (s *E2ETestSuite) TestSetIsActive_UserNotFound() {
 	assert.Equal(s.T(), "NOT_FOUND", errorObj["code"])
 }
 
+func (s *E2ETestSuite) TestSetIsActive_ReassignReviews() {
+	teamPayload := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": false},
+		},
+	}
+	s.post("/team/add", teamPayload)
+
+	// u2 and u3 review both PRs, pr-2 is merged
+	for _, id := range []string{"pr-1", "pr-2"} {
+		s.post("/pullRequest/create", map[string]interface{}{
+			"pull_request_id":   id,
+			"pull_request_name": "Feature " + id,
+			"author_id":         "u1",
+		})
+	}
+	s.post("/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-2"})
+	s.post("/users/setIsActive", map[string]interface{}{"user_id": "u4", "is_active": true})
+
+	// u4 replaces u2 on the open PR
+	resp := s.post("/users/setIsActive", map[string]interface{}{
+		"user_id":          "u2",
+		"is_active":        false,
+		"reassign_reviews": true,
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	assert.Equal(s.T(), false, result["user"].(map[string]interface{})["is_active"])
+
+	reassignment := result["reassignment"].(map[string]interface{})
+	reassigned := reassignment["reassigned"].([]interface{})
+	require.Len(s.T(), reassigned, 1) // merged pr-2 is not touched
+	assert.Equal(s.T(), "pr-1", reassigned[0].(map[string]interface{})["pull_request_id"])
+	assert.Equal(s.T(), "u4", reassigned[0].(map[string]interface{})["replaced_by"])
+	assert.Empty(s.T(), reassignment["not_reassigned"])
+
+	// Nobody is left to replace u3: u1 is the author, u2 is inactive, u4 is already assigned
+	resp = s.post("/users/setIsActive", map[string]interface{}{
+		"user_id":          "u3",
+		"is_active":        false,
+		"reassign_reviews": true,
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var second map[string]interface{}
+	s.parseJSON(resp, &second)
+	reassignment = second["reassignment"].(map[string]interface{})
+	assert.Empty(s.T(), reassignment["reassigned"])
+	notReassigned := reassignment["not_reassigned"].([]interface{})
+	require.Len(s.T(), notReassigned, 1)
+	assert.Equal(s.T(), "pr-1", notReassigned[0].(map[string]interface{})["pull_request_id"])
+
+	// u3 keeps the review
+	resp = s.get("/users/getReview?user_id=u3")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 2)
+}
+
+func (s *E2ETestSuite) TestSetMaxOpenReviews_LimitsAssignment() {
+	teamPayload := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	}
+	s.post("/team/add", teamPayload)
+
+	resp := s.post("/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id":          "u2",
+		"max_open_reviews": 1,
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	user := result["user"].(map[string]interface{})
+	assert.Equal(s.T(), float64(1), user["max_open_reviews"])
+
+	// First PR takes the only slot of u2, the second one gets no reviewers
+	for _, id := range []string{"pr-1", "pr-2"} {
+		s.post("/pullRequest/create", map[string]interface{}{
+			"pull_request_id":   id,
+			"pull_request_name": "Feature " + id,
+			"author_id":         "u1",
+		})
+	}
+
+	resp = s.get("/users/getReview?user_id=u2")
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 1)
+}
+
 func (s *E2ETestSuite) TestGetReview_Success() {
 	// Create team
 	teamPayload := map[string]interface{}{
@@ -77,3 +184,157 @@ func (s *E2ETestSuite) TestGetReview_Success() {
 	prs := result["pull_requests"].([]interface{})
 	assert.NotEmpty(s.T(), prs)
 }
+
+func (s *E2ETestSuite) TestAbsence_SkipsAndReassignsReviewer() {
+	teamPayload := map[string]interface{}{
+		"team_name":     "backend",
+		"max_reviewers": 1,
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": false},
+		},
+	}
+	s.post("/team/add", teamPayload)
+
+	// u2 is the only available reviewer
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	s.post("/users/setIsActive", map[string]interface{}{"user_id": "u3", "is_active": true})
+
+	// u2 goes on vacation right now
+	now := time.Now().UTC()
+	resp := s.post("/users/addAbsence", map[string]interface{}{
+		"user_id":          "u2",
+		"starts_at":        now.Add(-time.Minute).Format(time.RFC3339),
+		"ends_at":          now.Add(7 * 24 * time.Hour).Format(time.RFC3339),
+		"reassign_reviews": true,
+	})
+	assert.Equal(s.T(), 201, resp.StatusCode)
+
+	var created map[string]interface{}
+	s.parseJSON(resp, &created)
+	absence := created["absence"].(map[string]interface{})
+	assert.Equal(s.T(), "u2", absence["user_id"])
+	assert.Equal(s.T(), true, absence["reassign_reviews"])
+
+	resp = s.get("/users/getAbsences?user_id=u2")
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var listed map[string]interface{}
+	s.parseJSON(resp, &listed)
+	assert.Len(s.T(), listed["absences"].([]interface{}), 1)
+
+	// New PRs skip the absent user
+	resp = s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "Fix",
+		"author_id":         "u1",
+	})
+	var pr2 map[string]interface{}
+	s.parseJSON(resp, &pr2)
+	assert.Equal(s.T(), []interface{}{"u3"}, pr2["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	// Open reviews are handed over when the absence starts
+	reassignments, err := s.absenceUC.ReassignStartedAbsences(context.Background())
+	require.NoError(s.T(), err)
+	require.Len(s.T(), reassignments, 1)
+	require.Len(s.T(), reassignments[0].Report.Reassigned, 1)
+	assert.Equal(s.T(), "pr-1", reassignments[0].Report.Reassigned[0].PRID)
+	assert.Equal(s.T(), "u3", reassignments[0].Report.Reassigned[0].NewReviewerID)
+
+	resp = s.get("/users/getReview?user_id=u3")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 2)
+
+	// The absence is processed only once
+	reassignments, err = s.absenceUC.ReassignStartedAbsences(context.Background())
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), reassignments)
+
+	resp = s.post("/users/removeAbsence", map[string]interface{}{
+		"user_id":    "u2",
+		"absence_id": absence["absence_id"],
+	})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	var remaining map[string]interface{}
+	s.parseJSON(resp, &remaining)
+	assert.Empty(s.T(), remaining["absences"])
+}
+
+func (s *E2ETestSuite) TestAddAbsence_InvalidPeriod() {
+	s.post("/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+		},
+	})
+
+	now := time.Now().UTC()
+	resp := s.post("/users/addAbsence", map[string]interface{}{
+		"user_id":   "u1",
+		"starts_at": now.Add(time.Hour).Format(time.RFC3339),
+		"ends_at":   now.Format(time.RFC3339),
+	})
+	assert.Equal(s.T(), 400, resp.StatusCode)
+
+	errResp := s.parseError(resp)
+	errorObj := errResp["error"].(map[string]interface{})
+	assert.Equal(s.T(), "INVALID_INPUT", errorObj["code"])
+}
+
+func (s *E2ETestSuite) TestSetIsActive_TopsUpReviewers() {
+	teamPayload := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": false},
+		},
+	}
+	s.post("/team/add", teamPayload)
+
+	// Only u2 can review, one place of two stays free
+	s.post("/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+
+	// u3 takes the free place on return
+	resp := s.post("/users/setIsActive", map[string]interface{}{"user_id": "u3", "is_active": true})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	resp = s.get("/users/getReview?user_id=u3")
+	var reviews map[string]interface{}
+	s.parseJSON(resp, &reviews)
+	assert.Len(s.T(), reviews["pull_requests"].([]interface{}), 1)
+
+	// The place freed by hand is filled by the admin endpoint
+	s.post("/pullRequest/removeReviewer", map[string]interface{}{"pull_request_id": "pr-1", "user_id": "u3"})
+
+	// Admin token is required
+	resp = s.post("/admin/topUpReviewers", map[string]interface{}{})
+	assert.Equal(s.T(), 401, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = s.postAdmin("/admin/topUpReviewers", map[string]interface{}{})
+	assert.Equal(s.T(), 200, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.parseJSON(resp, &result)
+	assigned := result["assigned"].([]interface{})
+	require.Len(s.T(), assigned, 1)
+	assert.Equal(s.T(), "pr-1", assigned[0].(map[string]interface{})["pull_request_id"])
+	assert.Equal(s.T(), "u3", assigned[0].(map[string]interface{})["user_id"])
+	assert.Equal(s.T(), "TEAM", assigned[0].(map[string]interface{})["source"])
+
+	// Nothing is left to fill
+	resp = s.postAdmin("/admin/topUpReviewers", map[string]interface{}{})
+	var again map[string]interface{}
+	s.parseJSON(resp, &again)
+	assert.Empty(s.T(), again["assigned"])
+}